@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/usecase"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runExportGroup(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("export-group", flag.ExitOnError)
+	groupID := flags.String("group", "", "書き出すグループのID")
+	out := flags.String("out", "", "書き出し先のファイル。省略した場合は標準出力")
+	flags.Parse(args)
+
+	if *groupID == "" {
+		return fmt.Errorf("-group を指定してください")
+	}
+
+	archive, err := usecase.NewExportGroupUseCase(
+		repository.NewGroupRepository(db),
+		repository.NewEventRepository(db),
+		entity.GroupID(*groupID),
+	).Execute()
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return printJSON(archive)
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o600); err != nil {
+		return err
+	}
+	log.Printf("Exported group %s with %d events to %s", archive.Group.GroupID, len(archive.Events), *out)
+	return nil
+}
+
+func runImportGroup(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("import-group", flag.ExitOnError)
+	in := flags.String("in", "", "export-group で書き出したファイル")
+	name := flags.String("name", "", "作成するグループの名前。省略した場合は書き出し元と同じ名前")
+	flags.Parse(args)
+
+	if *in == "" {
+		return fmt.Errorf("-in を指定してください")
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+	var archive usecase.GroupArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return fmt.Errorf("ファイルの形式が正しくありません: %w", err)
+	}
+
+	response, err := usecase.NewImportGroupUseCase(
+		repository.NewGroupRepository(db),
+		repository.NewEventRepository(db),
+		&archive,
+		entity.GroupName(*name),
+	).Execute()
+	if err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
+
+func runRecompute(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("recompute", flag.ExitOnError)
+	groupID := flags.String("group", "", "計算し直すグループのID")
+	flags.Parse(args)
+
+	if *groupID == "" {
+		return fmt.Errorf("-group を指定してください")
+	}
+
+	// 起動中のサーバーのランキングのキャッシュは期限が切れるまで古いまま残る
+	response, err := usecase.NewRecomputeGroupScoresUseCase(
+		repository.NewEventRepository(db),
+		repository.NewGroupRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewScoreRepository(db),
+		repository.NewTitleRepository(db),
+		nil,
+		entity.GroupID(*groupID),
+	).Execute()
+	if err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
@@ -0,0 +1,88 @@
+// chikokuctl はサーバーと同じ設定とリポジトリを使ってデータを操作する運用ツール
+//
+//	go run ./cmd/chikokuctl <command> [flags]
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"chikokulympic-api/config"
+	mongoDB "chikokulympic-api/infrastructure/mongo"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(db *mongo.Database, args []string) error
+}
+
+var commands = []command{
+	{name: "migrate", summary: "未適用のマイグレーションを適用する。status で適用状況を表示する", run: runMigrate},
+	{name: "seed", summary: "動作確認用のユーザー、グループ、イベントを作成する", run: runSeed},
+	{name: "export-group", summary: "グループとそのイベントを JSON に書き出す", run: runExportGroup},
+	{name: "import-group", summary: "export-group で書き出したグループを新しいIDで作成する", run: runImportGroup},
+	{name: "recompute", summary: "グループの確定済みイベントのスコアと称号を計算し直す", run: runRecompute},
+	{name: "delete-user", summary: "ユーザーを退会させる。過去のイベントの記録は匿名化して残す", run: runDeleteUser},
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("chikokuctl: ")
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == os.Args[1] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := run(cmd, os.Args[2:]); err != nil {
+		log.Fatalf("%s: %v", cmd.name, err)
+	}
+}
+
+func run(cmd *command, args []string) error {
+	// サーバーと同じく、環境変数がなければ .env.local を読み込む
+	if os.Getenv("MONGO_URI") == "" {
+		config.LoadFromFileOrEnv(".env.local")
+	}
+
+	db, client, err := mongoDB.GetMongoDBConnectionWithEnvFile("")
+	if err != nil {
+		return err
+	}
+	defer mongoDB.DisconnectMongoDB(client)
+
+	return cmd.run(db, args)
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: chikokuctl <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "各コマンドのフラグは chikokuctl <command> -h で確認できます")
+}
+
+// printJSON は結果を標準出力に書き出す。ログは標準エラーに出すため、出力をそのままファイルに保存できる
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
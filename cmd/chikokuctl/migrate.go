@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"chikokulympic-api/infrastructure/mongo/migration"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runMigrate(db *mongo.Database, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	migrator := migration.NewMigrator(db, migration.Migrations)
+
+	if len(args) > 0 && args[0] == "status" {
+		applied, err := migrator.Applied(ctx)
+		if err != nil {
+			return err
+		}
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range applied {
+			fmt.Printf("applied  %3d %s (%s)\n", m.Version, m.Name, m.AppliedAt.Format(time.RFC3339))
+		}
+		for _, m := range pending {
+			fmt.Printf("pending  %3d %s\n", m.Version, m.Name)
+		}
+		return nil
+	}
+
+	applied, err := migrator.Migrate(ctx)
+	for _, m := range applied {
+		log.Printf("Applied migration %d: %s", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		log.Println("No pending migrations")
+	}
+	return nil
+}
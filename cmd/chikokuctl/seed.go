@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/usecase"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type seedResponse struct {
+	Users  []entity.UserID  `json:"users"`
+	Group  entity.GroupID   `json:"group"`
+	Events []entity.EventID `json:"events"`
+}
+
+// runSeed は 3 人のユーザーが所属するグループと、確定済みの過去のイベント、これからのイベントを作成する
+func runSeed(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	groupName := flags.String("group-name", "デモグループ", "作成するグループの名前")
+	flags.Parse(args)
+
+	userRepo := repository.NewUserRepository(db)
+	groupRepo := repository.NewGroupRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	seasonRepo := repository.NewSeasonRepository(db)
+
+	response := &seedResponse{}
+
+	// 何度実行しても同じユーザーを使う
+	names := []entity.UserName{"遅刻 太郎", "時間 守子", "ぎりぎり 次郎"}
+	for i, name := range names {
+		authID := entity.AuthID(fmt.Sprintf("demo-auth-%d", i+1))
+		user, err := userRepo.FindUserByAuthID(authID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			user, err = usecase.NewRegisterUserUseCase(userRepo, &entity.User{AuthID: authID, UserName: name}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+		response.Users = append(response.Users, user.UserID)
+	}
+	manager := response.Users[0]
+
+	group, err := usecase.NewCreateGroupUseCase(groupRepo, userRepo, &entity.Group{
+		GroupName:        entity.GroupName(*groupName),
+		GroupPassword:    "demo",
+		GroupManagerID:   manager,
+		GroupDescription: "chikokuctl seed で作成したグループ",
+		GroupMembers:     entity.GroupMembers(response.Users[1:]),
+	}).Execute()
+	if err != nil {
+		return err
+	}
+	response.Group = group.GroupID
+
+	now := time.Now().Truncate(time.Minute)
+	pastStart := now.Add(-24 * time.Hour)
+	past := &entity.Event{
+		EventTitle:           "先週の飲み会",
+		EventLocationName:    "渋谷駅",
+		Cost:                 9000,
+		EventAuthorID:        manager,
+		Latitude:             35.658034,
+		Longitude:            139.701636,
+		EventStartDateTime:   entity.StartDateTIme(pastStart),
+		EventEndDateTime:     entity.EndDateTime(pastStart.Add(2 * time.Hour)),
+		EventClosingDateTime: entity.EventClosingDateTime(pastStart.Add(-24 * time.Hour)),
+	}
+	// 1 人は時間通り、1 人は 5 分前、1 人は 12 分遅刻
+	for i, offset := range []time.Duration{0, -5 * time.Minute, 12 * time.Minute} {
+		past.VotedMembers = append(past.VotedMembers, entity.VotedMember{
+			UserID:          response.Users[i],
+			Vote:            entity.VoteAttend,
+			IsArrival:       true,
+			ArrivalDateTime: pastStart.Add(offset),
+		})
+	}
+	// サンプルのデータでは通知しない
+	createdPast, err := usecase.NewCreateEventUseCase(eventRepo, groupRepo, seasonRepo, nil, past, group.GroupID).Execute()
+	if err != nil {
+		return err
+	}
+	response.Events = append(response.Events, createdPast.EventID)
+
+	_, err = usecase.NewFinalizeEventUseCase(eventRepo, groupRepo, userRepo,
+		repository.NewScoreRepository(db), repository.NewTitleRepository(db),
+		usecase.NewLeaderboardCache(0), nil, manager, createdPast.EventID).Execute()
+	if err != nil {
+		return err
+	}
+
+	upcomingStart := now.Add(7 * 24 * time.Hour)
+	upcoming := &entity.Event{
+		EventTitle:           "来週のランチ",
+		EventLocationName:    "新宿駅",
+		Cost:                 3000,
+		EventAuthorID:        manager,
+		Latitude:             35.690921,
+		Longitude:            139.700258,
+		EventStartDateTime:   entity.StartDateTIme(upcomingStart),
+		EventEndDateTime:     entity.EndDateTime(upcomingStart.Add(time.Hour)),
+		EventClosingDateTime: entity.EventClosingDateTime(upcomingStart.Add(-24 * time.Hour)),
+	}
+	createdUpcoming, err := usecase.NewCreateEventUseCase(eventRepo, groupRepo, seasonRepo, nil, upcoming, group.GroupID).Execute()
+	if err != nil {
+		return err
+	}
+	response.Events = append(response.Events, createdUpcoming.EventID)
+
+	log.Printf("Seeded group %s with %d users and %d events", group.GroupID, len(response.Users), len(response.Events))
+	return printJSON(response)
+}
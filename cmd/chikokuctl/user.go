@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"chikokulympic-api/config"
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/storage"
+	"chikokulympic-api/usecase"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runDeleteUser(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("delete-user", flag.ExitOnError)
+	userID := flags.String("user", "", "削除するユーザーのID")
+	yes := flags.Bool("yes", false, "確認なしで削除する")
+	flags.Parse(args)
+
+	if *userID == "" {
+		return fmt.Errorf("-user を指定してください")
+	}
+	// 元に戻せないため、明示的に指定したときだけ削除する
+	if !*yes {
+		return fmt.Errorf("ユーザー %s を削除するには -yes を指定してください", *userID)
+	}
+
+	response, err := usecase.NewDeleteUserUseCase(
+		repository.NewUserRepository(db),
+		repository.NewGroupRepository(db),
+		repository.NewEventRepository(db),
+		repository.NewScoreRepository(db),
+		repository.NewTitleRepository(db),
+		repository.NewLocationRepository(db),
+		repository.NewLocationHistoryRepository(db),
+		repository.NewAuditLogRepository(db),
+		repository.NewDeviceRepository(db),
+		repository.NewNotificationSettingsRepository(db),
+		repository.NewNotificationQueueRepository(db),
+		storage.NewLocalBlobStore(config.GetEnvWithDefault("BLOB_STORAGE_DIR", "data/blobs")),
+		// 起動中のサーバーのキャッシュは破棄できないため、リーダーボードには LEADERBOARD_CACHE_TTL が過ぎてから反映される
+		usecase.NewLeaderboardCache(0),
+		entity.UserID(*userID),
+		"chikokuctl",
+	).Execute()
+	if err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
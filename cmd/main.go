@@ -2,13 +2,22 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"chikokulympic-api/config"
+	domainRepository "chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/infrastructure/mongo/migration"
 	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/notification"
+	"chikokulympic-api/infrastructure/realtime"
+	"chikokulympic-api/infrastructure/storage"
 	serverV1 "chikokulympic-api/server/v1"
+	"chikokulympic-api/usecase"
 
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -16,6 +25,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	_ "chikokulympic-api/docs"
+	// 実行環境にタイムゾーンのデータがなくても通知の時間帯などを正しく扱えるようにする
+	_ "time/tzdata"
 )
 
 // @title Chikokulympic-API
@@ -52,6 +63,22 @@ func main() {
 		}
 	}
 
+	// go run ./cmd migrate [status] でマイグレーションだけを実行する
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if db == nil {
+			log.Fatalf("Failed to run migrations: %v", mongoConnectErr)
+		}
+		runMigrateCommand(db, os.Args[2:])
+		return
+	}
+
+	if db != nil && config.GetBoolEnvWithDefault("MIGRATE_ON_STARTUP", true) {
+		applyMigrations(db)
+	}
+	if db != nil {
+		syncLocationHistoryRetention(db)
+	}
+
 	e := echo.New()
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -71,10 +98,49 @@ func main() {
 		userRepo := repository.NewUserRepository(db)
 		groupRepo := repository.NewGroupRepository(db)
 		eventRepo := repository.NewEventRepository(db)
+		locationRepo := repository.NewLocationRepository(db)
+		scoreRepo := repository.NewScoreRepository(db)
+		titleRepo := repository.NewTitleRepository(db)
+		seasonRepo := repository.NewSeasonRepository(db)
+		seriesRepo := repository.NewEventSeriesRepository(db)
+		auditRepo := repository.NewAuditLogRepository(db)
+		deviceRepo := repository.NewDeviceRepository(db)
+		settingsRepo := repository.NewNotificationSettingsRepository(db)
+		queueRepo := repository.NewNotificationQueueRepository(db)
+		historyRepo := repository.NewLocationHistoryRepository(db)
+
+		locationHub := realtime.NewInMemoryLocationHub()
+		locationThrottle := usecase.NewLocationThrottle(config.GetDurationEnvWithDefault("LOCATION_THROTTLE_INTERVAL", 2*time.Second))
+
+		speedProfile := usecase.DefaultTravelSpeedProfile()
+		speedProfile[usecase.TravelModeWalking] = config.GetFloatEnvWithDefault("TRAVEL_SPEED_WALKING_KMH", speedProfile[usecase.TravelModeWalking])
+		speedProfile[usecase.TravelModeTransit] = config.GetFloatEnvWithDefault("TRAVEL_SPEED_TRANSIT_KMH", speedProfile[usecase.TravelModeTransit])
+		speedProfile[usecase.TravelModeCar] = config.GetFloatEnvWithDefault("TRAVEL_SPEED_CAR_KMH", speedProfile[usecase.TravelModeCar])
 
-		userServer := serverV1.NewUserServer(userRepo, groupRepo)
-		groupServer := serverV1.NewGroupServer(groupRepo, userRepo)
-		eventServer := serverV1.NewEventServer(eventRepo, groupRepo, userRepo)
+		arrivalPolicy := usecase.DefaultArrivalPolicy()
+		arrivalPolicy.GeofenceRadiusMeters = config.GetFloatEnvWithDefault("ARRIVAL_GEOFENCE_RADIUS_METERS", arrivalPolicy.GeofenceRadiusMeters)
+		arrivalPolicy.MinDwell = config.GetDurationEnvWithDefault("ARRIVAL_MIN_DWELL", arrivalPolicy.MinDwell)
+		arrivalPolicy.MaxSpeedKmh = config.GetFloatEnvWithDefault("ARRIVAL_MAX_SPEED_KMH", arrivalPolicy.MaxSpeedKmh)
+
+		checkinPolicy := usecase.DefaultCheckinCodePolicy()
+		checkinPolicy.Step = config.GetDurationEnvWithDefault("CHECKIN_CODE_STEP", checkinPolicy.Step)
+		checkinLimiter := usecase.NewCheckinAttemptLimiter(config.GetIntEnvWithDefault("CHECKIN_MAX_FAILURES", 5), config.GetDurationEnvWithDefault("CHECKIN_LOCKOUT", 15*time.Minute))
+
+		notifier := notification.NewPreferenceNotifier(settingsRepo, queueRepo, newNotifier(deviceRepo, userRepo))
+		go runNotificationQueue(notifier, config.GetDurationEnvWithDefault("NOTIFICATION_QUEUE_INTERVAL", time.Minute))
+
+		leaderboardCache := usecase.NewLeaderboardCache(config.GetDurationEnvWithDefault("LEADERBOARD_CACHE_TTL", 10*time.Minute))
+
+		seriesMaterializer := usecase.NewEventSeriesMaterializer(seriesRepo, eventRepo, groupRepo, seasonRepo, config.GetDurationEnvWithDefault("RECURRENCE_MATERIALIZE_WINDOW", 28*24*time.Hour))
+		go runSeriesMaterializer(seriesMaterializer, config.GetDurationEnvWithDefault("RECURRENCE_MATERIALIZE_INTERVAL", time.Hour))
+
+		blobStore := storage.NewLocalBlobStore(config.GetEnvWithDefault("BLOB_STORAGE_DIR", "data/blobs"))
+		dataExporter := usecase.NewDataExporter(repository.NewExportJobRepository(db), userRepo, groupRepo, eventRepo, historyRepo, titleRepo, blobStore, config.GetDurationEnvWithDefault("DATA_EXPORT_LINK_TTL", 24*time.Hour))
+		go runDataExporter(dataExporter, config.GetDurationEnvWithDefault("DATA_EXPORT_INTERVAL", time.Minute))
+
+		userServer := serverV1.NewUserServer(userRepo, eventRepo, groupRepo, locationRepo, historyRepo, titleRepo, scoreRepo, auditRepo, deviceRepo, settingsRepo, queueRepo, blobStore, leaderboardCache, dataExporter)
+		groupServer := serverV1.NewGroupServer(groupRepo, userRepo, eventRepo, scoreRepo, seasonRepo, leaderboardCache)
+		eventServer := serverV1.NewEventServer(eventRepo, groupRepo, userRepo, locationRepo, historyRepo, scoreRepo, titleRepo, seasonRepo, seriesRepo, seriesMaterializer, leaderboardCache, locationHub, locationThrottle, speedProfile, arrivalPolicy, checkinPolicy, checkinLimiter, notifier)
 
 		groupServer.RegisterRoutes(e)
 		userServer.RegisterRoutes(e)
@@ -88,3 +154,130 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// newNotifier は FCM_CREDENTIALS_FILE にサービスアカウントの鍵があればプッシュ通知を送り、なければログに出力する
+func newNotifier(deviceRepo domainRepository.DeviceRepository, userRepo domainRepository.UserRepository) service.Notifier {
+	path := config.GetEnvWithDefault("FCM_CREDENTIALS_FILE", "")
+	if path == "" {
+		return notification.NewLogNotifier()
+	}
+
+	client, err := notification.NewFCMClientFromFile(path)
+	if err != nil {
+		log.Printf("WARN: Failed to load FCM credentials, falling back to logging notifications: %v", err)
+		return notification.NewLogNotifier()
+	}
+	return notification.NewPushNotifier(deviceRepo, userRepo, client)
+}
+
+// runSeriesMaterializer は繰り返しイベントの回を定期的に作成する。起動直後にも一度実行する
+func runSeriesMaterializer(materializer *usecase.EventSeriesMaterializer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := materializer.MaterializeDue(time.Now()); err != nil {
+			log.Printf("WARN: Failed to materialize recurring events: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// runNotificationQueue は通知を受け取らない時間帯に保留した通知を、時間帯が明けてから送る
+func runNotificationQueue(notifier *notification.PreferenceNotifier, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := notifier.DeliverDue(time.Now()); err != nil {
+			log.Printf("WARN: Failed to deliver queued notifications: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// runDataExporter は依頼されたデータのエクスポートを作成し、期限の過ぎたアーカイブを削除する
+// 新しい依頼があればすぐに、なければ interval ごとに実行する
+func runDataExporter(exporter *usecase.DataExporter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := exporter.ProcessPending(); err != nil {
+			log.Printf("WARN: Failed to process data exports: %v", err)
+		}
+		if err := exporter.PurgeExpired(time.Now()); err != nil {
+			log.Printf("WARN: Failed to purge expired data exports: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-exporter.Wake():
+		}
+	}
+}
+
+// マイグレーションの適用と、他のインスタンスの適用を待つ時間の上限
+const migrationTimeout = 30 * time.Minute
+
+// applyMigrations は起動時に未適用のマイグレーションを適用する
+// 他のインスタンスが適用中の場合は、終わるまで待ってから起動する
+// インデックスや前提のデータがないまま動かさないよう、失敗した場合は起動しない
+func applyMigrations(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	defer cancel()
+
+	applied, err := migration.NewMigrator(db, migration.Migrations).MigrateWhenUnlocked(ctx, 5*time.Second)
+	for _, m := range applied {
+		log.Printf("Applied migration %d: %s", m.Version, m.Name)
+	}
+	if err != nil {
+		log.Fatalf("Failed to apply migrations: %v", err)
+	}
+}
+
+// syncLocationHistoryRetention は位置履歴の保持期間を設定に合わせる。失敗しても起動は続ける
+func syncLocationHistoryRetention(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := migration.SyncLocationHistoryRetention(ctx, db); err != nil {
+		log.Printf("Failed to update location history retention: %v", err)
+	}
+}
+
+func runMigrateCommand(db *mongo.Database, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	defer cancel()
+
+	migrator := migration.NewMigrator(db, migration.Migrations)
+
+	if len(args) > 0 && args[0] == "status" {
+		applied, err := migrator.Applied(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migrations: %v", err)
+		}
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migrations: %v", err)
+		}
+		for _, m := range applied {
+			fmt.Printf("applied  %3d %s (%s)\n", m.Version, m.Name, m.AppliedAt.Format(time.RFC3339))
+		}
+		for _, m := range pending {
+			fmt.Printf("pending  %3d %s\n", m.Version, m.Name)
+		}
+		return
+	}
+
+	applied, err := migrator.Migrate(ctx)
+	for _, m := range applied {
+		log.Printf("Applied migration %d: %s", m.Version, m.Name)
+	}
+	if err != nil {
+		log.Fatalf("Failed to apply migrations: %v", err)
+	}
+	if len(applied) == 0 {
+		log.Println("No pending migrations")
+	}
+	syncLocationHistoryRetention(db)
+}
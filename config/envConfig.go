@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -182,6 +184,62 @@ func GetEnvWithDefault(key, defaultValue string) string {
 	return value
 }
 
+func GetDurationEnvWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("WARN: Invalid duration for %s: %v, using default %s", key, err, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+func GetFloatEnvWithDefault(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("WARN: Invalid number for %s: %v, using default %v", key, err, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+func GetIntEnvWithDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("WARN: Invalid integer for %s: %v, using default %d", key, err, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+func GetBoolEnvWithDefault(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("WARN: Invalid boolean for %s: %v, using default %v", key, err, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
 func LoadFromFileOrEnv(filename string) {
 	ec := NewEnvConfig()
 	ec.LoadFromFileOrEnv(filename)
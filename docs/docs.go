@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/events": {
             "post": {
-                "description": "create a new event",
+                "description": "create a new event authored by the authenticated user. Only members of the group can create events in it. The event joins the group's season whose period contains its start time. When recurrence is given as an RFC 5545 RRULE (weekly or monthly), the event becomes the first occurrence of a series and later occurrences are created ahead of time as separate events. capacity limits the number of attendees; further attend votes join a waitlist.",
                 "consumes": [
                     "application/json"
                 ],
@@ -29,6 +29,13 @@ const docTemplate = `{
                 ],
                 "summary": "create event",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "request",
                         "name": "request",
@@ -52,6 +59,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -105,9 +130,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{event_id}/votes": {
-            "post": {
-                "description": "post a vote for an event",
+        "/events/{event_id}": {
+            "patch": {
+                "description": "update an event. Only the event author can edit it. For an occurrence of a recurring event, scope=following also applies the change to all later occurrences and to occurrences created in the future. Moving the start time without an end or closing time moves them by the same amount. Votes and arrivals of each occurrence are kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,7 +142,7 @@ const docTemplate = `{
                 "tags": [
                     "events"
                 ],
-                "summary": "post vote",
+                "summary": "update event",
                 "parameters": [
                     {
                         "type": "string",
@@ -126,19 +151,36 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "default": "this",
+                        "description": "this or following",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.PostVoteRequest"
+                            "$ref": "#/definitions/v1.PatchEventRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.PatchEventResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -146,6 +188,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -158,6 +206,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -167,9 +221,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups": {
+        "/events/{event_id}/arrival": {
             "post": {
-                "description": "create a new group",
+                "description": "claim arrival at the event venue. The claim is verified against the location trail the server received through location sharing and any samples sent with the request. Samples dated more than a minute after the request or more than 30 minutes before it are ignored. Claims that the server-received trail does not confirm, and other suspicious claims, are flagged for the event author to review.",
                 "consumes": [
                     "application/json"
                 ],
@@ -177,25 +231,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "events"
                 ],
-                "summary": "create group",
+                "summary": "post arrival",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "request",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.PostGroupRequest"
+                            "$ref": "#/definitions/v1.PostArrivalRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.PostGroupResponse"
+                            "$ref": "#/definitions/usecase.RecordArrivalResponse"
                         }
                     },
                     "400": {
@@ -204,6 +271,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -213,9 +310,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/join": {
+        "/events/{event_id}/arrivals/{user_id}/review": {
             "post": {
-                "description": "join a chosen group",
+                "description": "approve or reject a participant's arrival as the event author. arrival_date_time overrides the recorded arrival time when approving. Approving an arrival that was pending review notifies the other attendees. Arrivals can no longer be reviewed once the event is finalized.",
                 "consumes": [
                     "application/json"
                 ],
@@ -223,17 +320,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "events"
                 ],
-                "summary": "join group",
+                "summary": "review arrival",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.JoinGroupRequest"
+                            "$ref": "#/definitions/v1.ReviewArrivalRequest"
                         }
                     }
                 ],
@@ -241,7 +359,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.JoinGroupResponse"
+                            "$ref": "#/definitions/entity.VotedMember"
                         }
                     },
                     "400": {
@@ -250,6 +368,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -259,9 +401,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/{group_id}": {
-            "get": {
-                "description": "get chosen group info",
+        "/events/{event_id}/checkin": {
+            "post": {
+                "description": "record arrival with the check-in code displayed by the event author at the venue",
                 "consumes": [
                     "application/json"
                 ],
@@ -269,23 +411,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "events"
                 ],
-                "summary": "get group info",
+                "summary": "post check-in",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "group_id",
-                        "name": "group_id",
+                        "description": "Event ID",
+                        "name": "event_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostCheckinRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.GroupInfoResponse"
+                            "$ref": "#/definitions/entity.VotedMember"
                         }
                     },
                     "400": {
@@ -294,12 +452,42 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -309,9 +497,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/groups/{group_id}/leave": {
-            "post": {
-                "description": "leave a chosen group",
+        "/events/{event_id}/checkin-code": {
+            "get": {
+                "description": "get the current rotating check-in code for the event. Only the event author can display it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -319,30 +507,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "events"
                 ],
-                "summary": "leave group",
+                "summary": "get check-in code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "group_id",
-                        "name": "group_id",
+                        "description": "Event ID",
+                        "name": "event_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.LeaveGroupRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CheckinCodeResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -350,6 +539,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -359,35 +566,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "put": {
-                "description": "update user information(icon, name)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/events/{event_id}/checkin-qr": {
+            "get": {
+                "description": "render the current rotating check-in code as a PNG QR image. Only the event author can display it.",
                 "produces": [
-                    "application/json"
+                    "image/png"
                 ],
                 "tags": [
-                    "users"
+                    "events"
                 ],
-                "summary": "update user",
+                "summary": "get check-in QR code",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UpdateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.UpdateUserResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -396,44 +605,74 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/users/signin": {
-            "post": {
-                "description": "signin user from auth_id",
-                "consumes": [
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{event_id}/eta": {
+            "get": {
+                "description": "estimate each participant's arrival time and predicted lateness from their last shared location. Participants who stopped sharing their location with the group, or whose last location falls outside the event's tracking window or is older than 10 minutes, have no estimate (has_location is false)",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "events"
                 ],
-                "summary": "signin user",
+                "summary": "get estimated arrival",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.SigninRequest"
-                        }
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "transit",
+                        "description": "travel mode (walking, transit, car)",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.SigninResponse"
+                            "$ref": "#/definitions/usecase.EstimateArrivalResponse"
                         }
                     },
                     "400": {
@@ -442,6 +681,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -451,9 +708,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/signup": {
+        "/events/{event_id}/finalize": {
             "post": {
-                "description": "subscribe user to the service",
+                "description": "finalize an ended event and award points to participants according to the group's scoring rule. Titles are re-evaluated from the updated scores. Only the event author can finalize.",
                 "consumes": [
                     "application/json"
                 ],
@@ -461,25 +718,168 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "events"
                 ],
-                "summary": "subscribe user",
+                "summary": "finalize event",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.SignupRequest"
+                            "$ref": "#/definitions/usecase.FinalizeEventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{event_id}/locations/ws": {
+            "get": {
+                "description": "share live locations with the other participants of an event over WebSocket until the event ends. Send LocationMessage frames, receive entity.UserLocation frames. Users who turned off sharing for the group only receive.",
+                "tags": [
+                    "events"
+                ],
+                "summary": "stream participant locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "auth_id (for clients that cannot set headers on WebSocket)",
+                        "name": "auth_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/events/{event_id}/locations/{user_id}/trail": {
+            "get": {
+                "description": "replay a participant's recorded trail for an event (kept only for the retention period)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "get location trail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.SignupResponse"
+                            "$ref": "#/definitions/usecase.LocationTrailResponse"
                         }
                     },
                     "400": {
@@ -488,6 +888,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -497,9 +915,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user_id}/groups": {
+        "/events/{event_id}/settlement": {
             "get": {
-                "description": "get user groups",
+                "description": "get how the event cost is split among the members who voted to attend, and who has paid the event author. Late participants pay a surcharge that is taken off the on-time participants' shares. Members whose arrival is not confirmed are treated as late until the event ends (no_show). The amounts are provisional until the event ends.",
                 "consumes": [
                     "application/json"
                 ],
@@ -507,26 +925,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "events"
                 ],
-                "summary": "get user groups",
+                "summary": "get event settlement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user_id",
-                        "name": "user_id",
+                        "description": "Event ID",
+                        "name": "event_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/usecase.UserGroup"
-                            }
+                            "$ref": "#/definitions/usecase.EventSettlement"
                         }
                     },
                     "400": {
@@ -535,6 +957,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -543,162 +983,3660 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "middleware.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                }
-            }
         },
-        "usecase.EventBoardAuthor": {
+        "/events/{event_id}/settlement/payments/{user_id}": {
+            "put": {
+                "description": "mark a participant's share of the event cost as paid or unpaid. Only the event author, who paid the cost up front, can change it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "mark payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.MarkPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.SettlementShare"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{event_id}/settlement/surcharge-rule": {
+            "put": {
+                "description": "set the late-arrival surcharge of the event. Only the event author can change it. Late participants pay per_minute for each minute late, up to max (0 means no cap), and the total is taken off the on-time participants' shares. Set per_minute to 0 to disable the surcharge.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "update surcharge rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SurchargeRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SurchargeRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{event_id}/votes": {
+            "post": {
+                "description": "post a vote for an event. When the event has a capacity and the attend option is full, the attend vote joins an ordered waitlist instead. Waitlisted members are promoted in order when an attendee changes their vote, and are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "post vote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostVoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostVoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/{job_id}/download": {
+            "get": {
+                "description": "download a built data export archive. The link returned by the export endpoints carries its own token, so no Authorization header is needed",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "download personal data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "export job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "download token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "the export has not been built",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "the download link has expired",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "post": {
+                "description": "create a new group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "create group",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/join": {
+            "post": {
+                "description": "join a chosen group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "join group",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.JoinGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.JoinGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}": {
+            "get": {
+                "description": "get chosen group info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get group info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.GroupInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/balances": {
+            "get": {
+                "description": "get the unpaid event costs in the group as a running balance per member and as who owes whom. Only events that have ended are included, and debts between the same two members are netted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get group balances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.GetGroupBalancesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/events/import": {
+            "post": {
+                "description": "import the VEVENTs of an uploaded .ics file as events of the group. Each VEVENT is reported as created, skipped or failed. Events already imported into the group with the same UID are skipped, so importing the same file again creates nothing new. The first VALARM becomes the voting deadline. Recurring VEVENTs are not supported.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "import events from iCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": ".ics file (up to 1 MiB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ImportEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/leaderboard": {
+            "get": {
+                "description": "rank group members by a metric over finalized events in a time range, with deltas versus the previous period of the same length. The range defaults to the last 30 days, or the season's period when season_id is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get group leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "range start (YYYY-MM-DD or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "range end, exclusive (YYYY-MM-DD or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID. Takes precedence over from and to",
+                        "name": "season_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "total_late_minutes",
+                        "description": "total_late_minutes, average_late_minutes, on_time_rate or attendance",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.GetGroupLeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/leave": {
+            "post": {
+                "description": "leave a chosen group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "leave group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.LeaveGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/scoring-rule": {
+            "put": {
+                "description": "update how points are awarded for events in the group. Only the group manager can change it. Penalties are given as positive numbers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "update scoring rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ScoringRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ScoringRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/seasons": {
+            "get": {
+                "description": "get the seasons of the group ordered by start time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get group seasons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.GetGroupSeasonsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a season for the group. Only the group manager can create it. Existing events starting within the period are assigned to the season. The end time is exclusive and seasons must not overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "create season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostSeasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/seasons/{season_id}/close": {
+            "post": {
+                "description": "close the season and freeze its standings. Only the group manager can close it. Closing before the end time shortens the season so later events are not assigned to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "close season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "season_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/standings": {
+            "get": {
+                "description": "get all-time and seasonal point standings of the group. When season_id is given, the seasonal standings cover that season, and a closed season returns the standings frozen at closing. Otherwise the season defaults to the current year.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get group standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "season start (YYYY-MM-DD or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "season end, exclusive (YYYY-MM-DD or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID. Takes precedence over from and to",
+                        "name": "season_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.GetGroupStandingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "put": {
+                "description": "partially update the authenticated user's profile. Only the fields present in the request are changed; alias_preference must be a title the user currently holds, or empty to pick one automatically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "put": {
+                "description": "partially update the authenticated user's profile. Only the fields present in the request are changed; alias_preference must be a title the user currently holds, or empty to pick one automatically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete the authenticated user. Managed groups are handed over to the longest-standing other member, past event records are kept under an anonymous ID, and location data is purged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "delete account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.DeleteUserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "the user is the only member of a group they manage",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "partially update the authenticated user's profile. Only the fields present in the request are changed; alias_preference must be a title the user currently holds, or empty to pick one automatically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/calendar-token": {
+            "post": {
+                "description": "issue a secret token for subscribing to the user's events from a calendar app. Issuing a new token invalidates the previous one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "issue calendar feed token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.PostCalendarTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/devices": {
+            "get": {
+                "description": "list the devices registered for push notifications, most recently seen first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "list devices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FetchUserDevicesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/devices/{device_id}": {
+            "put": {
+                "description": "register or refresh the push notification token of one of the user's devices. device_id is chosen by the client and must stay the same across app launches. Call this on every launch to keep last_seen_at current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "register device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PutDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Device"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "stop sending push notifications to one of the user's devices, e.g. on logout",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "unregister device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "description": "request a ZIP archive of the authenticated user's profile, groups, authored events, votes and arrivals, location history and titles in JSON and CSV. The archive is built in the background; while it is being built 202 is returned. Once built, the response includes a download link that expires. An in-progress or still downloadable export is returned instead of starting a new one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "export personal data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DataExportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DataExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export/{job_id}": {
+            "get": {
+                "description": "get the status of one of the authenticated user's data exports",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get personal data export status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "export job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DataExportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DataExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/icon": {
+            "post": {
+                "description": "upload a JPEG or PNG image as the user's icon. The image is rotated according to its EXIF orientation, cropped to a centred square and resized to 64, 128 and 256 pixel JPEGs without any metadata. user_icon in the response points to GET /users/{user_id}/icon.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "upload user icon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "JPEG or PNG image (up to 5 MiB)",
+                        "name": "icon",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/location-history": {
+            "delete": {
+                "description": "delete all of the authenticated user's location history and last known location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "delete location history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DeleteLocationHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/location-sharing/{group_id}": {
+            "put": {
+                "description": "turn location sharing on or off for a group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update location sharing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateLocationSharingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notification-settings": {
+            "get": {
+                "description": "get which notifications the user receives. kinds lists every notification type; users who have never changed their settings receive everything",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get notification settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NotificationSettings"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "replace the user's notification settings. kinds toggles each notification type (new_event, ranking_posted, member_arrived, waitlist_promoted); omitted types stay enabled. groups overrides kinds for a group the user belongs to, or mutes it entirely. Notifications raised during quiet_hours, evaluated in its time_zone (Asia/Tokyo if omitted), are held and delivered when the quiet hours end.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update notification settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {auth_id}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.PutNotificationSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NotificationSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/signin": {
+            "post": {
+                "description": "signin user from auth_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "signin user",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SigninRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SigninResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/signup": {
+            "post": {
+                "description": "subscribe user to the service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "subscribe user",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SignupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/calendar.ics": {
+            "get": {
+                "description": "get an iCalendar feed of every event in the user's groups for calendar app subscriptions. Authenticated by the feed token instead of the Authorization header. Each event has an alarm at its voting deadline.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "calendar feed token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/groups": {
+            "get": {
+                "description": "get user groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get user groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecase.UserGroup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/icon": {
+            "get": {
+                "description": "get an icon uploaded with POST /users/me/icon as a JPEG",
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get user icon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 256,
+                        "description": "icon size in pixels (64, 128 or 256)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/stats": {
+            "get": {
+                "description": "get lateness statistics of the user, overall and per group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get user stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FetchUserStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/titles": {
+            "get": {
+                "description": "get all titles the user has earned, including ones that have since been revoked. Filter by season with season_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get user titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "season_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.FetchUserTitlesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.ArrivalFlag": {
+            "type": "object",
+            "properties": {
+                "flagged_at": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.ArrivalReviewStatus"
+                }
+            }
+        },
+        "entity.ArrivalReviewStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ArrivalReviewPending",
+                "ArrivalReviewApproved",
+                "ArrivalReviewRejected"
+            ]
+        },
+        "entity.Device": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-iphone"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "platform": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.DevicePlatform"
+                        }
+                    ],
+                    "example": "ios"
+                }
+            }
+        },
+        "entity.DevicePlatform": {
+            "type": "string",
+            "enum": [
+                "ios",
+                "android",
+                "web"
+            ],
+            "x-enum-varnames": [
+                "DevicePlatformIOS",
+                "DevicePlatformAndroid",
+                "DevicePlatformWeb"
+            ]
+        },
+        "entity.Event": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "参加の上限人数。0 の場合は上限なし",
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "event_author_id": {
+                    "type": "string"
+                },
+                "event_closing_date_time": {
+                    "type": "string"
+                },
+                "event_description": {
+                    "type": "string"
+                },
+                "event_end_date_time": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_location_name": {
+                    "type": "string"
+                },
+                "event_message": {
+                    "type": "string"
+                },
+                "event_start_date_time": {
+                    "type": "string"
+                },
+                "event_title": {
+                    "type": "string"
+                },
+                "finalized_at": {
+                    "type": "string"
+                },
+                "import_uid": {
+                    "description": "iCalendar から取り込んだイベントの UID。同じ予定を二重に取り込まないために使う",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "recurrence_id": {
+                    "type": "string"
+                },
+                "season_id": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "description": "繰り返しイベントの回の場合のみ設定される。RecurrenceID はルール上の本来の開始日時で、この回だけ日時を変えても変わらない",
+                    "type": "string"
+                },
+                "surcharge_rule": {
+                    "description": "費用の精算。費用はイベントの作成者が立て替え、到着した参加者で分担する",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.SurchargeRule"
+                        }
+                    ]
+                },
+                "voted_members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.VotedMember"
+                    }
+                },
+                "waitlist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WaitlistEntry"
+                    }
+                }
+            }
+        },
+        "entity.ExportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed",
+                "expired"
+            ],
+            "x-enum-varnames": [
+                "ExportJobPending",
+                "ExportJobRunning",
+                "ExportJobCompleted",
+                "ExportJobFailed",
+                "ExportJobExpired"
+            ]
+        },
+        "entity.GroupNotificationSettings": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "string"
+                },
+                "kinds": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "muted": {
+                    "description": "Muted のグループからは種類によらず通知を受け取らない",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.LocationTrailPoint": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.NotificationSettings": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "グループごとの設定。全体の設定より優先する",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GroupNotificationSettings"
+                    }
+                },
+                "kinds": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/entity.QuietHours"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.QuietHours": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
+        "entity.Score": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "event_start_date_time": {
+                    "description": "期間での集計に使うイベントの開始時刻",
+                    "type": "string"
+                },
+                "finalized_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "late_minutes": {
+                    "type": "integer"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/entity.ScoreOutcome"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score_id": {
+                    "type": "string"
+                },
+                "season_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ScoreOutcome": {
+            "type": "string",
+            "enum": [
+                "on_time",
+                "late",
+                "no_show"
+            ],
+            "x-enum-varnames": [
+                "ScoreOutcomeOnTime",
+                "ScoreOutcomeLate",
+                "ScoreOutcomeNoShow"
+            ]
+        },
+        "entity.ScoringRule": {
+            "type": "object",
+            "properties": {
+                "early_bonus": {
+                    "description": "開始時刻までに到着したときのボーナス",
+                    "type": "integer",
+                    "example": 10
+                },
+                "late_penalty_per_minute": {
+                    "description": "遅刻 1 分あたりの減点",
+                    "type": "integer",
+                    "example": 1
+                },
+                "no_show_penalty": {
+                    "description": "参加と投票したのに到着しなかったときの減点",
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "entity.Season": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "end_date_time": {
+                    "description": "終了時刻ちょうどに始まるイベントは含まない",
+                    "type": "string"
+                },
+                "final_standings": {
+                    "description": "締めた時点の順位表。締めた後は変更されない",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SeasonStanding"
+                    }
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "2024 春シーズン"
+                },
+                "season_id": {
+                    "type": "string"
+                },
+                "start_date_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SeasonStanding": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "integer"
+                },
+                "late_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "$ref": "#/definitions/entity.UserName"
+                },
+                "no_show_count": {
+                    "type": "integer"
+                },
+                "on_time_count": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "total_late_minutes": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SurchargeRule": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "description": "1 人あたりの上乗せ額の上限。0 の場合は上限なし",
+                    "type": "integer",
+                    "example": 500
+                },
+                "per_minute": {
+                    "description": "遅刻 1 分あたりの上乗せ額",
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "entity.Title": {
+            "type": "object",
+            "properties": {
+                "awarded_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "遅刻王"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "season_id": {
+                    "description": "称号を獲得したときのシーズン",
+                    "type": "string"
+                },
+                "title_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.UserName": {
+            "type": "string",
+            "enum": [
+                "退会したユーザー"
+            ],
+            "x-enum-varnames": [
+                "DeletedUserName"
+            ]
+        },
+        "entity.Vote": {
+            "type": "string",
+            "enum": [
+                "参加"
+            ],
+            "x-enum-varnames": [
+                "VoteAttend"
+            ]
+        },
+        "entity.VotedMember": {
+            "type": "object",
+            "properties": {
+                "arrival_date_time": {
+                    "type": "string"
+                },
+                "arrival_flag": {
+                    "$ref": "#/definitions/entity.ArrivalFlag"
+                },
+                "is_arrival": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "vote": {
+                    "$ref": "#/definitions/entity.Vote"
+                }
+            }
+        },
+        "entity.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "requested_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "middleware.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ArrivalReviewAction": {
+            "type": "string",
+            "enum": [
+                "approve",
+                "reject"
+            ],
+            "x-enum-varnames": [
+                "ArrivalReviewActionApprove",
+                "ArrivalReviewActionReject"
+            ]
+        },
+        "usecase.CheckinCodeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "492039"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string",
+                    "example": "chikokulympic://checkin?code=492039\u0026event_id=xxx"
+                }
+            }
+        },
+        "usecase.Debt": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.DeleteUserResponse": {
+            "type": "object",
+            "properties": {
+                "anonymized_events": {
+                    "description": "投票や作成者を匿名の ID に置き換えたイベントの数",
+                    "type": "integer"
+                },
+                "anonymized_scores": {
+                    "type": "integer"
+                },
+                "deleted_devices": {
+                    "type": "integer"
+                },
+                "deleted_locations": {
+                    "type": "integer"
+                },
+                "deleted_titles": {
+                    "type": "integer"
+                },
+                "left_groups": {
+                    "description": "メンバーから外したグループの数",
+                    "type": "integer"
+                },
+                "removed_from_events": {
+                    "description": "これから始まるイベントから投票やキャンセル待ちを取り除いた数",
+                    "type": "integer"
+                },
+                "transferred_groups": {
+                    "description": "管理者を他のメンバーに引き継いだグループ",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.EstimateArrivalResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "event_start_time": {
+                    "type": "string"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.ParticipantETA"
+                    }
+                },
+                "travel_mode": {
+                    "$ref": "#/definitions/usecase.TravelMode"
+                }
+            }
+        },
+        "usecase.EvaluateTitlesResponse": {
+            "type": "object",
+            "properties": {
+                "awarded": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Title"
+                    }
+                },
+                "revoked": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Title"
+                    }
+                }
+            }
+        },
+        "usecase.EventBoardAuthor": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "author_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.EventBoardEvent": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/usecase.EventBoardAuthor"
+                },
+                "closing_time": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_all_day": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "string"
+                },
+                "location_name": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.EventBoardOption"
+                    }
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.EventBoardOption": {
+            "type": "object",
+            "properties": {
+                "participant_count": {
+                    "type": "integer"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "user_id": {
+                                "type": "string"
+                            },
+                            "user_name": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.EventImportResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/usecase.EventImportStatus"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.EventImportStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "skipped",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "EventImportCreated",
+                "EventImportSkipped",
+                "EventImportFailed"
+            ]
+        },
+        "usecase.EventSettlement": {
+            "type": "object",
+            "properties": {
+                "collected": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "outstanding": {
+                    "type": "integer"
+                },
+                "payee_id": {
+                    "description": "費用を立て替えたイベントの作成者。参加者はこのユーザーに支払う",
+                    "type": "string"
+                },
+                "provisional": {
+                    "description": "イベントが終わるまでは到着に応じて金額が変わる",
+                    "type": "boolean"
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.SettlementShare"
+                    }
+                },
+                "surcharge_rule": {
+                    "$ref": "#/definitions/entity.SurchargeRule"
+                }
+            }
+        },
+        "usecase.FetchEventBoardResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.EventBoardEvent"
+                    }
+                }
+            }
+        },
+        "usecase.FetchUserDevicesResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Device"
+                    }
+                }
+            }
+        },
+        "usecase.FetchUserStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average_late_minutes": {
+                    "type": "number"
+                },
+                "events_attended": {
+                    "type": "integer"
+                },
+                "events_voted": {
+                    "type": "integer"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.GroupUserStats"
+                    }
+                },
+                "longest_on_time_streak": {
+                    "type": "integer"
+                },
+                "median_late_minutes": {
+                    "type": "number"
+                },
+                "no_shows": {
+                    "type": "integer"
+                },
+                "on_time_rate": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "worst_late_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.FetchUserTitlesResponse": {
             "type": "object",
             "properties": {
-                "author_id": {
-                    "type": "string"
+                "titles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Title"
+                    }
                 },
-                "author_name": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "usecase.EventBoardEvent": {
+        "usecase.FinalizeEventResponse": {
             "type": "object",
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/usecase.EventBoardAuthor"
+                "event_id": {
+                    "type": "string"
                 },
-                "closing_time": {
+                "finalized_at": {
                     "type": "string"
                 },
-                "cost": {
-                    "type": "integer"
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Score"
+                    }
                 },
-                "description": {
+                "titles": {
+                    "description": "このイベントの結果で新たに獲得・剥奪された称号",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecase.EvaluateTitlesResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "usecase.GetGroupBalancesResponse": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.MemberBalance"
+                    }
+                },
+                "debts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.Debt"
+                    }
+                },
+                "group_id": {
                     "type": "string"
+                }
+            }
+        },
+        "usecase.GetGroupLeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.LeaderboardEntry"
+                    }
                 },
-                "end_time": {
+                "from": {
                     "type": "string"
                 },
                 "group_id": {
                     "type": "string"
                 },
-                "group_name": {
+                "metric": {
+                    "$ref": "#/definitions/usecase.LeaderboardMetric"
+                },
+                "previous_from": {
                     "type": "string"
                 },
-                "id": {
+                "previous_to": {
                     "type": "string"
                 },
-                "is_all_day": {
-                    "type": "boolean"
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.GetGroupStandingsResponse": {
+            "type": "object",
+            "properties": {
+                "all_time": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.Standing"
+                    }
                 },
-                "latitude": {
+                "group_id": {
                     "type": "string"
                 },
-                "location_name": {
+                "scoring_rule": {
+                    "$ref": "#/definitions/entity.ScoringRule"
+                },
+                "season_from": {
                     "type": "string"
                 },
-                "longitude": {
+                "season_id": {
+                    "description": "シーズンを指定した場合のみ設定される",
                     "type": "string"
                 },
-                "message": {
+                "season_to": {
                     "type": "string"
                 },
-                "options": {
+                "seasonal": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/usecase.EventBoardOption"
+                        "$ref": "#/definitions/usecase.Standing"
                     }
+                }
+            }
+        },
+        "usecase.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "group123"
                 },
-                "start_time": {
+                "is_creator": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "テストグループ"
+                }
+            }
+        },
+        "usecase.GroupUserStats": {
+            "type": "object",
+            "properties": {
+                "average_late_minutes": {
+                    "type": "number"
+                },
+                "events_attended": {
+                    "type": "integer"
+                },
+                "events_voted": {
+                    "type": "integer"
+                },
+                "group_id": {
                     "type": "string"
                 },
-                "title": {
+                "group_name": {
                     "type": "string"
+                },
+                "longest_on_time_streak": {
+                    "type": "integer"
+                },
+                "median_late_minutes": {
+                    "type": "number"
+                },
+                "no_shows": {
+                    "type": "integer"
+                },
+                "on_time_rate": {
+                    "type": "number"
+                },
+                "worst_late_minutes": {
+                    "type": "integer"
                 }
             }
         },
-        "usecase.EventBoardOption": {
+        "usecase.ImportEventsResponse": {
             "type": "object",
             "properties": {
-                "participant_count": {
+                "created": {
                     "type": "integer"
                 },
-                "participants": {
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "user_id": {
-                                "type": "string"
-                            },
-                            "user_name": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/usecase.EventImportResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "$ref": "#/definitions/usecase.Member"
+                },
+                "previous_rank": {
+                    "description": "前の期間に記録がない場合は nil",
+                    "type": "integer"
+                },
+                "previous_value": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "rank_delta": {
+                    "description": "正の値は順位が上がったことを表す",
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "value_delta": {
+                    "type": "number"
+                }
+            }
+        },
+        "usecase.LeaderboardMetric": {
+            "type": "string",
+            "enum": [
+                "total_late_minutes",
+                "average_late_minutes",
+                "on_time_rate",
+                "attendance"
+            ],
+            "x-enum-varnames": [
+                "LeaderboardMetricTotalLateMinutes",
+                "LeaderboardMetricAverageLateMinutes",
+                "LeaderboardMetricOnTimeRate",
+                "LeaderboardMetricAttendance"
+            ]
+        },
+        "usecase.LocationTrailResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "trail": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.LocationTrailPoint"
                     }
                 },
-                "title": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.Member": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "$ref": "#/definitions/entity.UserName"
+                }
+            }
+        },
+        "usecase.MemberBalance": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "name": {
+                    "$ref": "#/definitions/entity.UserName"
+                },
+                "net": {
+                    "description": "正の場合は受け取る額、負の場合は支払う額",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ParticipantETA": {
+            "type": "object",
+            "properties": {
+                "distance_meters": {
+                    "type": "number"
+                },
+                "estimated_arrival_time": {
+                    "type": "string"
+                },
+                "eta_minutes": {
+                    "type": "integer"
+                },
+                "has_location": {
+                    "type": "boolean"
+                },
+                "is_arrival": {
+                    "type": "boolean"
+                },
+                "location_recorded_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "$ref": "#/definitions/entity.UserName"
+                },
+                "predicted_late_minutes": {
+                    "description": "負の値は開始前に到着する見込み",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.RecordArrivalResponse": {
+            "type": "object",
+            "properties": {
+                "arrival_date_time": {
+                    "type": "string"
+                },
+                "arrival_flag": {
+                    "$ref": "#/definitions/entity.ArrivalFlag"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.SettlementShare": {
+            "type": "object",
+            "properties": {
+                "adjustment": {
+                    "description": "遅刻した参加者は上乗せ額（正）、時間通りに着いた参加者は上乗せ分からの割引額（負）",
+                    "type": "integer"
+                },
+                "alias": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "base_amount": {
+                    "description": "費用を人数で割った額",
+                    "type": "integer"
+                },
+                "late_minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "$ref": "#/definitions/entity.UserName"
+                },
+                "no_show": {
+                    "description": "参加と投票したが到着が確認できていない。終了時刻まで遅刻したものとして扱う",
+                    "type": "boolean"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.Standing": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "integer"
+                },
+                "late_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "$ref": "#/definitions/entity.UserName"
+                },
+                "no_show_count": {
+                    "type": "integer"
+                },
+                "on_time_count": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "total_late_minutes": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "usecase.FetchEventBoardResponse": {
+        "usecase.TravelMode": {
+            "type": "string",
+            "enum": [
+                "walking",
+                "transit",
+                "car"
+            ],
+            "x-enum-varnames": [
+                "TravelModeWalking",
+                "TravelModeTransit",
+                "TravelModeCar"
+            ]
+        },
+        "usecase.UserGroup": {
             "type": "object",
             "properties": {
-                "events": {
+                "groups": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/usecase.EventBoardEvent"
+                        "$ref": "#/definitions/usecase.GroupResponse"
                     }
                 }
             }
         },
-        "usecase.GroupResponse": {
+        "v1.ArrivalSample": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "group123"
-                },
-                "is_creator": {
-                    "type": "boolean",
-                    "example": true
+                "latitude": {
+                    "type": "number",
+                    "example": 35.6895
                 },
-                "member_count": {
-                    "type": "integer",
-                    "example": 5
+                "longitude": {
+                    "type": "number",
+                    "example": 139.6917
                 },
-                "name": {
+                "recorded_at": {
                     "type": "string",
-                    "example": "テストグループ"
+                    "example": "2023-10-01T09:58:00Z"
                 }
             }
         },
-        "usecase.Member": {
+        "v1.DataExportResponse": {
             "type": "object",
             "properties": {
-                "icon": {
+                "completed_at": {
                     "type": "string"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "download_url": {
+                    "description": "完了していて期限内の場合のみ含まれる。認証なしでダウンロードできるため、他人と共有しない",
+                    "type": "string",
+                    "example": "/exports/job123/download?token=abc"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "この日時を過ぎるとダウンロードできなくなる",
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string",
+                    "example": "job123"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 20480
+                },
+                "started_at": {
                     "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.ExportJobStatus"
+                        }
+                    ],
+                    "example": "completed"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user123"
                 }
             }
         },
-        "usecase.UserGroup": {
+        "v1.DeleteLocationHistoryResponse": {
             "type": "object",
             "properties": {
-                "groups": {
+                "deleted_count": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "v1.GetGroupSeasonsResponse": {
+            "type": "object",
+            "properties": {
+                "seasons": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/usecase.GroupResponse"
+                        "$ref": "#/definitions/entity.Season"
                     }
                 }
             }
@@ -775,16 +4713,119 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.PostEventRequest": {
+        "v1.MarkPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "paid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "v1.PatchEventRequest": {
             "type": "object",
             "properties": {
                 "cost": {
                     "type": "integer",
                     "example": 1000
                 },
-                "event_author_id": {
+                "event_closing_date_time": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "2023-09-30T23:59:59Z"
+                },
+                "event_description": {
+                    "type": "string",
+                    "example": "これはテストイベントです"
+                },
+                "event_end_date_time": {
+                    "type": "string",
+                    "example": "2023-10-01T12:00:00Z"
+                },
+                "event_location_name": {
+                    "type": "string",
+                    "example": "東京ドーム"
+                },
+                "event_message": {
+                    "type": "string",
+                    "example": "参加してください！"
+                },
+                "event_start_date_time": {
+                    "type": "string",
+                    "example": "2023-10-01T10:00:00Z"
+                },
+                "event_title": {
+                    "type": "string",
+                    "example": "テストイベント"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 35.6895
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 139.6917
+                }
+            }
+        },
+        "v1.PatchEventResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Event"
+                    }
+                }
+            }
+        },
+        "v1.PostArrivalRequest": {
+            "type": "object",
+            "properties": {
+                "samples": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ArrivalSample"
+                    }
+                }
+            }
+        },
+        "v1.PostCalendarTokenResponse": {
+            "type": "object",
+            "properties": {
+                "feed_path": {
+                    "description": "カレンダーアプリに登録する購読用のパス",
+                    "type": "string",
+                    "example": "/users/user123/calendar.ics?token=3f2a..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3f2a..."
+                }
+            }
+        },
+        "v1.PostCheckinRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "492039"
+                }
+            }
+        },
+        "v1.PostEventRequest": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "参加の上限人数。0 または省略した場合は上限なし",
+                    "type": "integer",
+                    "example": 8
+                },
+                "cost": {
+                    "type": "integer",
+                    "example": 1000
                 },
                 "event_closing_date_time": {
                     "type": "string",
@@ -829,6 +4870,15 @@ const docTemplate = `{
                 "longitude": {
                     "type": "number",
                     "example": 139.6917
+                },
+                "recurrence": {
+                    "description": "指定した場合は繰り返しイベントとして作成し、このイベントを最初の回にする",
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=FR"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
                 }
             }
         },
@@ -838,6 +4888,10 @@ const docTemplate = `{
                 "event_id": {
                     "type": "string",
                     "example": "event123"
+                },
+                "series_id": {
+                    "type": "string",
+                    "example": "series123"
                 }
             }
         },
@@ -877,20 +4931,123 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PostSeasonRequest": {
+            "type": "object",
+            "properties": {
+                "end_date_time": {
+                    "type": "string",
+                    "example": "2024-07-01T00:00:00+09:00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "2024 春シーズン"
+                },
+                "start_date_time": {
+                    "type": "string",
+                    "example": "2024-04-01T00:00:00+09:00"
+                }
+            }
+        },
         "v1.PostVoteRequest": {
             "type": "object",
             "required": [
-                "option",
-                "user_id"
+                "option"
             ],
             "properties": {
                 "option": {
-                    "type": "string",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Vote"
+                        }
+                    ],
                     "example": "参加"
+                }
+            }
+        },
+        "v1.PostVoteResponse": {
+            "type": "object",
+            "properties": {
+                "waitlist_position": {
+                    "description": "キャンセル待ちの何番目に並んでいるか。キャンセル待ちでない場合は 0",
+                    "type": "integer",
+                    "example": 2
                 },
-                "user_id": {
+                "waitlisted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "v1.PutDeviceRequest": {
+            "type": "object",
+            "required": [
+                "platform",
+                "token"
+            ],
+            "properties": {
+                "platform": {
+                    "enum": [
+                        "ios",
+                        "android",
+                        "web"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.DevicePlatform"
+                        }
+                    ],
+                    "example": "ios"
+                },
+                "token": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "fcm-token-123456"
+                }
+            }
+        },
+        "v1.PutNotificationSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "グループごとの設定。全体の設定より優先する",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GroupNotificationSettings"
+                    }
+                },
+                "kinds": {
+                    "description": "通知の種類ごとに受け取るかどうか。省略した種類は受け取る",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "quiet_hours": {
+                    "description": "省略すると時間帯による制限をなくす",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.QuietHours"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.ReviewArrivalRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/usecase.ArrivalReviewAction"
+                        }
+                    ],
+                    "example": "approve"
+                },
+                "arrival_date_time": {
+                    "type": "string",
+                    "example": "2023-10-01T10:05:00Z"
                 }
             }
         },
@@ -936,7 +5093,11 @@ const docTemplate = `{
                     "example": "user_icon"
                 },
                 "user_name": {
-                    "type": "string",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.UserName"
+                        }
+                    ],
                     "example": "user_name"
                 }
             }
@@ -950,25 +5111,80 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.UpdateLocationSharingRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
         "v1.UpdateUserRequest": {
             "type": "object",
             "properties": {
+                "alias_preference": {
+                    "description": "空文字にすると保持している称号から自動で選ぶ",
+                    "type": "string",
+                    "example": "遅刻王"
+                },
+                "fcm_token": {
+                    "type": "string",
+                    "example": "fcm-token-123456"
+                },
                 "user_icon": {
                     "type": "string",
                     "example": "https://example.com/icon.png"
                 },
                 "user_name": {
-                    "type": "string",
-                    "example": "user_name"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.UserName"
+                        }
+                    ],
+                    "example": "山田太郎"
                 }
             }
         },
         "v1.UpdateUserResponse": {
             "type": "object",
             "properties": {
+                "alias": {
+                    "type": "string",
+                    "example": "遅刻王"
+                },
+                "alias_preference": {
+                    "type": "string",
+                    "example": "遅刻王"
+                },
+                "fcm_token": {
+                    "type": "string",
+                    "example": "fcm-token-123456"
+                },
+                "location_opt_out_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_icon": {
+                    "type": "string",
+                    "example": "https://example.com/icon.png"
+                },
                 "user_id": {
                     "type": "string",
                     "example": "user123"
+                },
+                "user_name": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.UserName"
+                        }
+                    ],
+                    "example": "山田太郎"
                 }
             }
         }
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type AuditLogID string
+type AuditAction string
+
+// AuditActionUserDeleted はユーザーの退会を表す
+const AuditActionUserDeleted AuditAction = "user.deleted"
+
+// AuditLog は取り消せない操作の記録。退会したユーザーの個人情報は含めない
+type AuditLog struct {
+	AuditLogID AuditLogID  `bson:"_id" json:"audit_log_id"`
+	Action     AuditAction `bson:"action" json:"action" example:"user.deleted"`
+	// 操作の対象になったユーザー
+	TargetUserID UserID `bson:"target_user_id" json:"target_user_id"`
+	// 操作した主体。本人の場合は "self"、運用ツールの場合は "chikokuctl"
+	Actor string `bson:"actor" json:"actor" example:"self"`
+	// 操作で変更した件数などの補足
+	Details   map[string]int64 `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt time.Time        `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// time.Time を元にした型は time.Time のメソッドを引き継がないため、
+// JSON では RFC3339 の文字列、BSON では日時型として扱われるように変換を定義する
+
+func marshalDateTimeBSON(t time.Time) (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(t)
+}
+
+func unmarshalDateTimeBSON(bsonType bsontype.Type, data []byte) (time.Time, error) {
+	// 変換を定義する前に空のドキュメントとして保存された値はゼロ値として読み込む
+	if bsonType == bsontype.EmbeddedDocument {
+		return time.Time{}, nil
+	}
+
+	var t time.Time
+	if err := bson.UnmarshalValue(bsonType, data, &t); err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+func (t StartDateTIme) MarshalJSON() ([]byte, error) {
+	return time.Time(t).MarshalJSON()
+}
+
+func (t *StartDateTIme) UnmarshalJSON(data []byte) error {
+	return (*time.Time)(t).UnmarshalJSON(data)
+}
+
+func (t StartDateTIme) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalDateTimeBSON(time.Time(t))
+}
+
+func (t *StartDateTIme) UnmarshalBSONValue(bsonType bsontype.Type, data []byte) error {
+	value, err := unmarshalDateTimeBSON(bsonType, data)
+	*t = StartDateTIme(value)
+	return err
+}
+
+func (t EndDateTime) MarshalJSON() ([]byte, error) {
+	return time.Time(t).MarshalJSON()
+}
+
+func (t *EndDateTime) UnmarshalJSON(data []byte) error {
+	return (*time.Time)(t).UnmarshalJSON(data)
+}
+
+func (t EndDateTime) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalDateTimeBSON(time.Time(t))
+}
+
+func (t *EndDateTime) UnmarshalBSONValue(bsonType bsontype.Type, data []byte) error {
+	value, err := unmarshalDateTimeBSON(bsonType, data)
+	*t = EndDateTime(value)
+	return err
+}
+
+func (t EventClosingDateTime) MarshalJSON() ([]byte, error) {
+	return time.Time(t).MarshalJSON()
+}
+
+func (t *EventClosingDateTime) UnmarshalJSON(data []byte) error {
+	return (*time.Time)(t).UnmarshalJSON(data)
+}
+
+func (t EventClosingDateTime) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalDateTimeBSON(time.Time(t))
+}
+
+func (t *EventClosingDateTime) UnmarshalBSONValue(bsonType bsontype.Type, data []byte) error {
+	value, err := unmarshalDateTimeBSON(bsonType, data)
+	*t = EventClosingDateTime(value)
+	return err
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "strings"
+
+// 退会したユーザーの記録を置き換える ID の接頭辞
+const anonymousUserIDPrefix = "deleted-"
+
+// DeletedUserName は退会したユーザーの代わりに表示する名前
+const DeletedUserName UserName = "退会したユーザー"
+
+// NewAnonymousUserID は退会したユーザーの過去の記録を置き換える ID を返す
+// 元のユーザーと対応付けられないよう、token には推測できない値を渡す
+func NewAnonymousUserID(token string) UserID {
+	return UserID(anonymousUserIDPrefix + token)
+}
+
+// IsAnonymous は退会したユーザーの記録を置き換えた ID かどうかを返す
+func (id UserID) IsAnonymous() bool {
+	return strings.HasPrefix(string(id), anonymousUserIDPrefix)
+}
+
+// DeletedUser は退会したユーザーの代わりに表示するユーザーを返す
+func DeletedUser(userID UserID) *User {
+	return &User{UserID: userID, UserName: DeletedUserName}
+}
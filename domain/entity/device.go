@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type DeviceID string
+type DeviceKey string
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+// IsValid は対応しているプラットフォームかどうかを返す
+func (p DevicePlatform) IsValid() bool {
+	switch p {
+	case DevicePlatformIOS, DevicePlatformAndroid, DevicePlatformWeb:
+		return true
+	}
+	return false
+}
+
+// Device はプッシュ通知を受け取る端末。DeviceID は端末ごとにクライアントが決める
+type Device struct {
+	Key        DeviceKey      `bson:"_id" json:"-"`
+	UserID     UserID         `bson:"user_id" json:"-"`
+	DeviceID   DeviceID       `bson:"device_id" json:"device_id" example:"3f2b8c1e-iphone"`
+	Token      FCMToken       `bson:"token" json:"-"`
+	Platform   DevicePlatform `bson:"platform" json:"platform" example:"ios"`
+	CreatedAt  time.Time      `bson:"created_at" json:"created_at"`
+	LastSeenAt time.Time      `bson:"last_seen_at" json:"last_seen_at"`
+}
+
+// NewDeviceKey はユーザーと端末の組から決まる ID を返す
+func NewDeviceKey(userID UserID, deviceID DeviceID) DeviceKey {
+	return DeviceKey(string(userID) + ":" + string(deviceID))
+}
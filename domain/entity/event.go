@@ -15,11 +15,46 @@ type EndDateTime time.Time
 type EventClosingDateTime time.Time
 type Vote string
 
+// VoteAttend は参加を表す投票オプション
+const VoteAttend Vote = "参加"
+
+type ArrivalReviewStatus string
+
+const (
+	ArrivalReviewPending  ArrivalReviewStatus = "pending"
+	ArrivalReviewApproved ArrivalReviewStatus = "approved"
+	ArrivalReviewRejected ArrivalReviewStatus = "rejected"
+)
+
+// ArrivalFlag は不審な到着報告に付けられ、イベント作成者の確認を待つ
+type ArrivalFlag struct {
+	Status     ArrivalReviewStatus `bson:"status" json:"status"`
+	Reasons    []string            `bson:"reasons" json:"reasons"`
+	FlaggedAt  time.Time           `bson:"flagged_at" json:"flagged_at"`
+	ReviewedBy UserID              `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time          `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+}
+
+// WaitlistEntry は満員のあとに参加と投票したメンバー。投票した順に繰り上がる
+type WaitlistEntry struct {
+	UserID      UserID    `bson:"user_id" json:"user_id"`
+	RequestedAt time.Time `bson:"requested_at" json:"requested_at"`
+}
+
 type VotedMember struct {
-	IsArrival       bool      `bson:"is_arrival" json:"is_arrival"`
-	UserID          UserID    `bson:"user_id" json:"user_id"`
-	Vote            Vote      `bson:"vote" json:"vote"`
-	ArrivalDateTime time.Time `bson:"arrival_date_time" json:"arrival_date_time"`
+	IsArrival       bool         `bson:"is_arrival" json:"is_arrival"`
+	UserID          UserID       `bson:"user_id" json:"user_id"`
+	Vote            Vote         `bson:"vote" json:"vote"`
+	ArrivalDateTime time.Time    `bson:"arrival_date_time" json:"arrival_date_time"`
+	ArrivalFlag     *ArrivalFlag `bson:"arrival_flag,omitempty" json:"arrival_flag,omitempty"`
+}
+
+// HasConfirmedArrival は到着済みで、確認待ちや却下になっていないかを返す
+func (m VotedMember) HasConfirmedArrival() bool {
+	if !m.IsArrival {
+		return false
+	}
+	return m.ArrivalFlag == nil || m.ArrivalFlag.Status == ArrivalReviewApproved
 }
 
 type Event struct {
@@ -36,4 +71,36 @@ type Event struct {
 	EventEndDateTime     EndDateTime          `bson:"event_end_date_time" json:"event_end_date_time"`
 	EventClosingDateTime EventClosingDateTime `bson:"event_closing_date_time" json:"event_closing_date_time"`
 	VotedMembers         []VotedMember        `bson:"voted_members" json:"voted_members"`
+	CheckinSecret        string               `bson:"checkin_secret,omitempty" json:"-"`
+	FinalizedAt          *time.Time           `bson:"finalized_at,omitempty" json:"finalized_at,omitempty"`
+	SeasonID             SeasonID             `bson:"season_id,omitempty" json:"season_id,omitempty"`
+	// 繰り返しイベントの回の場合のみ設定される。RecurrenceID はルール上の本来の開始日時で、この回だけ日時を変えても変わらない
+	SeriesID     EventSeriesID `bson:"series_id,omitempty" json:"series_id,omitempty"`
+	RecurrenceID *time.Time    `bson:"recurrence_id,omitempty" json:"recurrence_id,omitempty"`
+	// iCalendar から取り込んだイベントの UID。同じ予定を二重に取り込まないために使う
+	ImportUID string `bson:"import_uid,omitempty" json:"import_uid,omitempty"`
+	// 費用の精算。費用はイベントの作成者が立て替え、到着した参加者で分担する
+	SurchargeRule *SurchargeRule     `bson:"surcharge_rule,omitempty" json:"surcharge_rule,omitempty"`
+	Payments      map[UserID]Payment `bson:"payments,omitempty" json:"-"`
+	// 参加の上限人数。0 の場合は上限なし
+	Capacity int             `bson:"capacity,omitempty" json:"capacity,omitempty"`
+	Waitlist []WaitlistEntry `bson:"waitlist,omitempty" json:"waitlist,omitempty"`
+	// 同時に投票されたときに上書きしないための版数。投票で更新するたびに 1 増える
+	Version int64 `bson:"version" json:"-"`
+}
+
+// AttendingCount は参加と投票したメンバーの人数を返す。キャンセル待ちのメンバーは含まない
+func (e *Event) AttendingCount() int {
+	count := 0
+	for _, member := range e.VotedMembers {
+		if member.Vote == VoteAttend {
+			count++
+		}
+	}
+	return count
+}
+
+// IsFull は参加の上限人数に達しているかを返す
+func (e *Event) IsFull() bool {
+	return e.Capacity > 0 && e.AttendingCount() >= e.Capacity
 }
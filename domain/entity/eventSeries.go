@@ -0,0 +1,74 @@
+package entity
+
+import "time"
+
+type EventSeriesID string
+
+// DefaultTimeZone は繰り返しの曜日や日付を数えるタイムゾーンの既定値
+const DefaultTimeZone = "Asia/Tokyo"
+
+// EventTemplate は繰り返しイベントの各回に引き継がれる内容
+type EventTemplate struct {
+	EventTitle        EventTitle       `bson:"event_title" json:"event_title"`
+	EventDescription  EventDescription `bson:"event_description" json:"event_description"`
+	EventLocationName LocationName     `bson:"event_location_name" json:"event_location_name"`
+	Cost              Cost             `bson:"cost" json:"cost"`
+	EventMessage      EventMessage     `bson:"event_message" json:"event_message"`
+	EventAuthorID     UserID           `bson:"event_author_id" json:"event_author_id"`
+	Latitude          Latitude         `bson:"latitude" json:"latitude"`
+	Longitude         Longitude        `bson:"longitude" json:"longitude"`
+	Capacity          int              `bson:"capacity,omitempty" json:"capacity,omitempty"`
+}
+
+// EventSeries は繰り返しイベントの定義。各回は通常のイベントとして先の期間まで作成され、投票や順位は回ごとに持つ
+type EventSeries struct {
+	SeriesID EventSeriesID  `bson:"_id" json:"series_id"`
+	GroupID  GroupID        `bson:"group_id" json:"group_id"`
+	Rule     RecurrenceRule `bson:"rule" json:"rule" swaggertype:"string" example:"FREQ=WEEKLY;BYDAY=FR"`
+	TimeZone string         `bson:"time_zone" json:"time_zone" example:"Asia/Tokyo"`
+	// 最初の回の開始日時 (RFC 5545 の DTSTART)
+	StartDateTime time.Time `bson:"start_date_time" json:"start_date_time"`
+	// 各回の長さと、開始の何分前に投票を締め切るか
+	Duration        time.Duration `bson:"duration" json:"-"`
+	ClosingLeadTime time.Duration `bson:"closing_lead_time" json:"-"`
+	Template        EventTemplate `bson:"template" json:"template"`
+	// この日時より前に始まる回は作成済み
+	MaterializedUntil time.Time `bson:"materialized_until" json:"materialized_until"`
+	// ルール上の最後の回まで作成し終えた
+	Completed bool `bson:"completed" json:"completed"`
+}
+
+// Location は繰り返しを数えるタイムゾーンを返す。読み込めない場合は UTC を使う
+func (s *EventSeries) Location() *time.Location {
+	name := s.TimeZone
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Occurrence は start に始まる回のイベントを組み立てる。EventID は保存時に割り当てる
+func (s *EventSeries) Occurrence(start time.Time) Event {
+	recurrenceID := start
+	return Event{
+		EventTitle:           s.Template.EventTitle,
+		EventDescription:     s.Template.EventDescription,
+		EventLocationName:    s.Template.EventLocationName,
+		Cost:                 s.Template.Cost,
+		EventMessage:         s.Template.EventMessage,
+		EventAuthorID:        s.Template.EventAuthorID,
+		Latitude:             s.Template.Latitude,
+		Longitude:            s.Template.Longitude,
+		Capacity:             s.Template.Capacity,
+		EventStartDateTime:   StartDateTIme(start),
+		EventEndDateTime:     EndDateTime(start.Add(s.Duration)),
+		EventClosingDateTime: EventClosingDateTime(start.Add(-s.ClosingLeadTime)),
+		VotedMembers:         []VotedMember{},
+		SeriesID:             s.SeriesID,
+		RecurrenceID:         &recurrenceID,
+	}
+}
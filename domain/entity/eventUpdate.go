@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// EventUpdate はイベントの部分更新の内容。nil のフィールドは変更しない
+// 投票や支払いなど、他の操作で同時に書き換えられる項目は含めない
+type EventUpdate struct {
+	EventTitle           *EventTitle
+	EventDescription     *EventDescription
+	EventLocationName    *LocationName
+	Cost                 *Cost
+	EventMessage         *EventMessage
+	Latitude             *Latitude
+	Longitude            *Longitude
+	EventStartDateTime   *StartDateTIme
+	EventEndDateTime     *EndDateTime
+	EventClosingDateTime *EventClosingDateTime
+	// SeasonID が空文字列の場合はシーズンの割り当てを外す
+	SeasonID      *SeasonID
+	SeriesID      *EventSeriesID
+	RecurrenceID  *time.Time
+	FinalizedAt   *time.Time
+	SurchargeRule *SurchargeRule
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+type ExportJobID string
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+	// ExportJobExpired はダウンロードの期限が過ぎ、アーカイブを削除したことを表す
+	ExportJobExpired ExportJobStatus = "expired"
+)
+
+// ExportJob はユーザーの個人データをまとめたアーカイブの作成状況
+type ExportJob struct {
+	JobID   ExportJobID     `bson:"_id" json:"job_id" example:"job123"`
+	UserID  UserID          `bson:"user_id" json:"user_id" example:"user123"`
+	Status  ExportJobStatus `bson:"status" json:"status" example:"completed"`
+	Error   string          `bson:"error,omitempty" json:"error,omitempty"`
+	BlobKey string          `bson:"blob_key,omitempty" json:"-"`
+	Size    int64           `bson:"size,omitempty" json:"size,omitempty" example:"20480"`
+	// ダウンロード用のリンクに含める秘密の値
+	DownloadToken string     `bson:"download_token" json:"-"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	StartedAt     *time.Time `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	CompletedAt   *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	// この日時を過ぎるとダウンロードできなくなる
+	ExpiresAt *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+}
+
+// IsActive は作成待ちまたは作成中かどうかを返す
+func (j ExportJob) IsActive() bool {
+	return j.Status == ExportJobPending || j.Status == ExportJobRunning
+}
+
+// IsDownloadable は now の時点でダウンロードできるかどうかを返す
+func (j ExportJob) IsDownloadable(now time.Time) bool {
+	return j.Status == ExportJobCompleted && j.ExpiresAt != nil && now.Before(*j.ExpiresAt)
+}
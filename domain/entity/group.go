@@ -15,4 +15,31 @@ type Group struct {
 	GroupDescription GroupDescription `bson:"description" json:"group_description" example:"これはテストグループです"`
 	GroupMembers     GroupMembers     `bson:"members" json:"group_members" example:"[\"user123\",\"user456\"]"`
 	GroupEvents      GroupEvents      `bson:"events" json:"group_events" example:"[\"event123\",\"event456\"]"`
+	ScoringRule      *ScoringRule     `bson:"scoring_rule,omitempty" json:"scoring_rule,omitempty"`
+}
+
+// Scoring はグループのポイント計算の設定を返す。未設定ならデフォルトを使う
+func (g *Group) Scoring() ScoringRule {
+	if g.ScoringRule == nil {
+		return DefaultScoringRule()
+	}
+	return *g.ScoringRule
+}
+
+// ScoringRule はイベントごとのポイント計算の設定。グループごとに変更できる
+type ScoringRule struct {
+	// 開始時刻までに到着したときのボーナス
+	EarlyBonus int `bson:"early_bonus" json:"early_bonus" example:"10"`
+	// 遅刻 1 分あたりの減点
+	LatePenaltyPerMinute int `bson:"late_penalty_per_minute" json:"late_penalty_per_minute" example:"1"`
+	// 参加と投票したのに到着しなかったときの減点
+	NoShowPenalty int `bson:"no_show_penalty" json:"no_show_penalty" example:"30"`
+}
+
+func DefaultScoringRule() ScoringRule {
+	return ScoringRule{
+		EarlyBonus:           10,
+		LatePenaltyPerMinute: 1,
+		NoShowPenalty:        30,
+	}
 }
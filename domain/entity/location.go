@@ -1,10 +1,22 @@
 package entity
 
+import "time"
+
 type Latitude float64
 type Longitude float64
 
 type UserLocation struct {
-	UserID    UserID    `bson:"user_id" json:"user_id"`
-	Latitude  Latitude  `bson:"latitude" json:"latitude"`
-	Longitude Longitude `bson:"longitude" json:"longitude"`
+	UserID     UserID    `bson:"user_id" json:"user_id"`
+	Latitude   Latitude  `bson:"latitude" json:"latitude"`
+	Longitude  Longitude `bson:"longitude" json:"longitude"`
+	RecordedAt time.Time `bson:"recorded_at" json:"recorded_at"`
+}
+
+// LocationTrailPoint はイベントごとに記録される位置履歴の 1 点
+type LocationTrailPoint struct {
+	EventID    EventID   `bson:"event_id" json:"event_id"`
+	UserID     UserID    `bson:"user_id" json:"user_id"`
+	Latitude   Latitude  `bson:"latitude" json:"latitude"`
+	Longitude  Longitude `bson:"longitude" json:"longitude"`
+	RecordedAt time.Time `bson:"recorded_at" json:"recorded_at"`
 }
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+type NotificationKind string
+type QueuedNotificationID string
+
+const (
+	// NotificationWaitlistPromoted はキャンセル待ちから参加に繰り上がったことの通知
+	NotificationWaitlistPromoted NotificationKind = "waitlist_promoted"
+	// NotificationNewEvent は所属しているグループにイベントが作成されたことの通知
+	NotificationNewEvent NotificationKind = "new_event"
+	// NotificationRankingPosted はイベントの順位が確定したことの通知
+	NotificationRankingPosted NotificationKind = "ranking_posted"
+	// NotificationMemberArrived は他のメンバーが会場に到着したことの通知
+	NotificationMemberArrived NotificationKind = "member_arrived"
+)
+
+// NotificationKinds は設定で切り替えられる通知の種類
+var NotificationKinds = []NotificationKind{
+	NotificationNewEvent,
+	NotificationRankingPosted,
+	NotificationMemberArrived,
+	NotificationWaitlistPromoted,
+}
+
+// IsValid は定義されている通知の種類かどうかを返す
+func (k NotificationKind) IsValid() bool {
+	for _, kind := range NotificationKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+type Notification struct {
+	Kind NotificationKind `bson:"kind" json:"kind"`
+	// グループごとの通知設定を適用するため、グループに関する通知では設定する
+	GroupID GroupID           `bson:"group_id,omitempty" json:"group_id,omitempty"`
+	Title   string            `bson:"title" json:"title"`
+	Body    string            `bson:"body" json:"body"`
+	Data    map[string]string `bson:"data,omitempty" json:"data,omitempty"`
+}
+
+// QueuedNotification は通知を受け取らない時間帯に届いたため、時間帯が明けてから送る通知
+type QueuedNotification struct {
+	QueuedNotificationID QueuedNotificationID `bson:"_id"`
+	UserID               UserID               `bson:"user_id"`
+	Notification         Notification         `bson:"notification"`
+	DeliverAt            time.Time            `bson:"deliver_at"`
+	CreatedAt            time.Time            `bson:"created_at"`
+}
@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationSettings はユーザーが受け取る通知の設定。設定していない種類の通知は受け取る
+type NotificationSettings struct {
+	UserID UserID                    `bson:"_id" json:"-"`
+	Kinds  map[NotificationKind]bool `bson:"kinds" json:"kinds"`
+	// グループごとの設定。全体の設定より優先する
+	Groups     []GroupNotificationSettings `bson:"groups" json:"groups"`
+	QuietHours *QuietHours                 `bson:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+	UpdatedAt  time.Time                   `bson:"updated_at" json:"updated_at"`
+}
+
+// GroupNotificationSettings はグループごとの通知の設定
+type GroupNotificationSettings struct {
+	GroupID GroupID `bson:"group_id" json:"group_id"`
+	// Muted のグループからは種類によらず通知を受け取らない
+	Muted bool                      `bson:"muted" json:"muted"`
+	Kinds map[NotificationKind]bool `bson:"kinds,omitempty" json:"kinds,omitempty"`
+}
+
+// QuietHours は通知を受け取らない時間帯。Start より End が前なら日をまたぐ
+type QuietHours struct {
+	Start    string `bson:"start" json:"start" example:"22:00"`
+	End      string `bson:"end" json:"end" example:"07:00"`
+	TimeZone string `bson:"time_zone" json:"time_zone" example:"Asia/Tokyo"`
+}
+
+// DefaultNotificationSettings はまだ設定していないユーザーの設定を返す。すべての通知を受け取る
+func DefaultNotificationSettings(userID UserID) *NotificationSettings {
+	settings := &NotificationSettings{UserID: userID, Groups: []GroupNotificationSettings{}}
+	settings.FillDefaults()
+	return settings
+}
+
+// FillDefaults は設定していない種類の通知を受け取る設定として埋め、今はない種類の設定を取り除く
+func (s *NotificationSettings) FillDefaults() {
+	if s.Kinds == nil {
+		s.Kinds = make(map[NotificationKind]bool, len(NotificationKinds))
+	}
+	for kind := range s.Kinds {
+		if !kind.IsValid() {
+			delete(s.Kinds, kind)
+		}
+	}
+	for _, kind := range NotificationKinds {
+		if _, ok := s.Kinds[kind]; !ok {
+			s.Kinds[kind] = true
+		}
+	}
+	if s.Groups == nil {
+		s.Groups = []GroupNotificationSettings{}
+	}
+}
+
+// Allows は notification を受け取る設定になっているかを返す。通知を受け取らない時間帯は考慮しない
+func (s *NotificationSettings) Allows(notification Notification) bool {
+	if notification.GroupID != "" {
+		for _, group := range s.Groups {
+			if group.GroupID != notification.GroupID {
+				continue
+			}
+			if group.Muted {
+				return false
+			}
+			if enabled, ok := group.Kinds[notification.Kind]; ok {
+				return enabled
+			}
+			break
+		}
+	}
+
+	if enabled, ok := s.Kinds[notification.Kind]; ok {
+		return enabled
+	}
+	return true
+}
+
+// Validate は時刻とタイムゾーンを読み取れるかを確かめる
+func (q *QuietHours) Validate() error {
+	if _, err := parseClock(q.Start); err != nil {
+		return err
+	}
+	if _, err := parseClock(q.End); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %q", q.TimeZone)
+	}
+	return nil
+}
+
+// Contains は now がユーザーのタイムゾーンで通知を受け取らない時間帯に入っているかを返す
+// 設定を読み取れない場合は時間帯に入っていないものとして扱う
+func (q *QuietHours) Contains(now time.Time) bool {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if start <= end {
+		return start <= minute && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// QuietUntil は now が通知を受け取らない時間帯に入っていれば、時間帯が明ける時刻と true を返す
+func (s *NotificationSettings) QuietUntil(now time.Time) (time.Time, bool) {
+	if s.QuietHours == nil || !s.QuietHours.Contains(now) {
+		return time.Time{}, false
+	}
+	return s.QuietHours.endAfter(now), true
+}
+
+// endAfter は now より後で最初に時間帯が明ける時刻を返す。Contains が true を返す設定でのみ呼ぶ
+func (q *QuietHours) endAfter(now time.Time) time.Time {
+	end, _ := parseClock(q.End)
+	location, _ := time.LoadLocation(q.TimeZone)
+
+	local := now.In(location)
+	at := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, location)
+	if !at.After(local) {
+		at = time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, location)
+	}
+	return at
+}
+
+// parseClock は "HH:MM" を 0 時からの分に変換する
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
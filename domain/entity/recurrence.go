@@ -0,0 +1,205 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+// RecurrenceDay は BYDAY の 1 要素。Ordinal は毎月の場合の「第 n」を表し、負の値は月末から数える。0 は毎週
+type RecurrenceDay struct {
+	Ordinal int          `bson:"ordinal,omitempty"`
+	Weekday time.Weekday `bson:"weekday"`
+}
+
+// RecurrenceRule は RFC 5545 の RRULE のうち、毎週・毎月の繰り返しを表す
+// Until と Count はどちらか一方だけを指定でき、どちらもなければ無期限に繰り返す
+// JSON では "FREQ=WEEKLY;BYDAY=FR;COUNT=10" のような RRULE の文字列として扱う
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency `bson:"frequency"`
+	Interval  int                 `bson:"interval"`
+	ByDay     []RecurrenceDay     `bson:"by_day,omitempty"`
+	Count     int                 `bson:"count,omitempty"`
+	Until     *time.Time          `bson:"until,omitempty"`
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var rruleWeekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRecurrenceRule は RRULE の文字列を解釈する。先頭の "RRULE:" は省略できる
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: empty rule", ErrInvalidRecurrenceRule)
+	}
+
+	rule := RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return RecurrenceRule{}, fmt.Errorf("%w: %q", ErrInvalidRecurrenceRule, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(strings.ToUpper(val))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return RecurrenceRule{}, fmt.Errorf("%w: INTERVAL=%s", ErrInvalidRecurrenceRule, val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return RecurrenceRule{}, fmt.Errorf("%w: COUNT=%s", ErrInvalidRecurrenceRule, val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRRuleTime(val)
+			if err != nil {
+				return RecurrenceRule{}, fmt.Errorf("%w: UNTIL=%s", ErrInvalidRecurrenceRule, val)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				parsed, err := parseRecurrenceDay(day)
+				if err != nil {
+					return RecurrenceRule{}, err
+				}
+				rule.ByDay = append(rule.ByDay, parsed)
+			}
+		case "WKST":
+			// 週の始まりは月曜日に固定しているため、月曜日以外は受け付けない
+			if strings.ToUpper(val) != "MO" {
+				return RecurrenceRule{}, fmt.Errorf("%w: WKST=%s", ErrInvalidRecurrenceRule, val)
+			}
+		default:
+			return RecurrenceRule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrenceRule, key)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return rule, nil
+}
+
+func parseRecurrenceDay(value string) (RecurrenceDay, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return RecurrenceDay{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRecurrenceRule, value)
+	}
+
+	weekday, ok := rruleWeekdays[value[len(value)-2:]]
+	if !ok {
+		return RecurrenceDay{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRecurrenceRule, value)
+	}
+
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceDay{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRecurrenceRule, value)
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	// 日付だけの場合はその日の終わりまでを含める
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+// Validate は組み合わせとして扱えないルールを弾く
+func (r RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case RecurrenceWeekly, RecurrenceMonthly:
+	default:
+		return fmt.Errorf("%w: FREQ must be WEEKLY or MONTHLY", ErrInvalidRecurrenceRule)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: INTERVAL must be positive", ErrInvalidRecurrenceRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL cannot be used together", ErrInvalidRecurrenceRule)
+	}
+	for _, day := range r.ByDay {
+		if day.Ordinal != 0 && r.Frequency != RecurrenceMonthly {
+			return fmt.Errorf("%w: ordinal BYDAY is only allowed with MONTHLY", ErrInvalidRecurrenceRule)
+		}
+	}
+	return nil
+}
+
+// String は RRULE の文字列を返す。"RRULE:" は付けない
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			name := rruleWeekdayNames[day.Weekday]
+			if day.Ordinal != 0 {
+				name = strconv.Itoa(day.Ordinal) + name
+			}
+			days = append(days, name)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r RecurrenceRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *RecurrenceRule) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseRecurrenceRule(value)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
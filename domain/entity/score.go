@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type ScoreID string
+type Points int
+
+type ScoreOutcome string
+
+const (
+	ScoreOutcomeOnTime ScoreOutcome = "on_time"
+	ScoreOutcomeLate   ScoreOutcome = "late"
+	ScoreOutcomeNoShow ScoreOutcome = "no_show"
+)
+
+// Score はイベント確定時に計算された参加者ごとの獲得ポイント
+type Score struct {
+	ScoreID     ScoreID      `bson:"_id" json:"score_id"`
+	GroupID     GroupID      `bson:"group_id" json:"group_id"`
+	EventID     EventID      `bson:"event_id" json:"event_id"`
+	SeasonID    SeasonID     `bson:"season_id,omitempty" json:"season_id,omitempty"`
+	UserID      UserID       `bson:"user_id" json:"user_id"`
+	Rank        int          `bson:"rank" json:"rank"`
+	Outcome     ScoreOutcome `bson:"outcome" json:"outcome"`
+	LateMinutes int          `bson:"late_minutes" json:"late_minutes"`
+	Points      Points       `bson:"points" json:"points"`
+	// 期間での集計に使うイベントの開始時刻
+	EventStartDateTime time.Time `bson:"event_start_date_time" json:"event_start_date_time"`
+	FinalizedAt        time.Time `bson:"finalized_at" json:"finalized_at"`
+}
+
+// NewScoreID はイベントと参加者の組から決まる ID を返す。再計算しても同じ ID で上書きされる
+func NewScoreID(eventID EventID, userID UserID) ScoreID {
+	return ScoreID(string(eventID) + ":" + string(userID))
+}
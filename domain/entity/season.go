@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+type SeasonID string
+type SeasonName string
+
+// Season はグループ内で順位を競う期間。開始時刻が期間内のイベントが自動的に割り当てられる
+type Season struct {
+	SeasonID      SeasonID   `bson:"_id" json:"season_id"`
+	GroupID       GroupID    `bson:"group_id" json:"group_id"`
+	Name          SeasonName `bson:"name" json:"name" example:"2024 春シーズン"`
+	StartDateTime time.Time  `bson:"start_date_time" json:"start_date_time"`
+	// 終了時刻ちょうどに始まるイベントは含まない
+	EndDateTime time.Time  `bson:"end_date_time" json:"end_date_time"`
+	ClosedAt    *time.Time `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+	// 締めた時点の順位表。締めた後は変更されない
+	FinalStandings []SeasonStanding `bson:"final_standings,omitempty" json:"final_standings,omitempty"`
+}
+
+type SeasonStanding struct {
+	Rank             int      `bson:"rank" json:"rank"`
+	UserID           UserID   `bson:"user_id" json:"user_id"`
+	UserName         UserName `bson:"user_name" json:"name"`
+	Alias            Alias    `bson:"alias" json:"alias"`
+	Points           Points   `bson:"points" json:"points"`
+	Events           int      `bson:"events" json:"events"`
+	OnTimeCount      int      `bson:"on_time_count" json:"on_time_count"`
+	LateCount        int      `bson:"late_count" json:"late_count"`
+	NoShowCount      int      `bson:"no_show_count" json:"no_show_count"`
+	TotalLateMinutes int      `bson:"total_late_minutes" json:"total_late_minutes"`
+}
+
+func (s *Season) IsClosed() bool {
+	return s.ClosedAt != nil
+}
+
+// Contains は時刻がシーズンの期間内かどうかを返す
+func (s *Season) Contains(t time.Time) bool {
+	return !t.Before(s.StartDateTime) && t.Before(s.EndDateTime)
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// SurchargeRule は遅刻した参加者に上乗せする金額の決め方。上乗せした分は時間通りに着いた参加者の負担から差し引く
+type SurchargeRule struct {
+	// 遅刻 1 分あたりの上乗せ額
+	PerMinute Cost `bson:"per_minute" json:"per_minute" example:"10"`
+	// 1 人あたりの上乗せ額の上限。0 の場合は上限なし
+	Max Cost `bson:"max" json:"max" example:"500"`
+}
+
+// Surcharge は lateMinutes 分遅刻した参加者への上乗せ額を返す
+func (r SurchargeRule) Surcharge(lateMinutes int) Cost {
+	if lateMinutes <= 0 || r.PerMinute <= 0 {
+		return 0
+	}
+	surcharge := r.PerMinute * Cost(lateMinutes)
+	if r.Max > 0 && surcharge > r.Max {
+		return r.Max
+	}
+	return surcharge
+}
+
+// Payment は参加者がイベントの作成者に費用を支払ったことの記録。作成者が支払い済みにしたときに作成される
+type Payment struct {
+	PaidAt   time.Time `bson:"paid_at" json:"paid_at"`
+	MarkedBy UserID    `bson:"marked_by" json:"marked_by"`
+}
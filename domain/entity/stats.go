@@ -0,0 +1,20 @@
+package entity
+
+// UserEventStats はユーザーの投票と到着の記録を集計した値
+// 中央値や連続記録はアプリケーション側で計算するため、到着ごとの値を開始時刻順に保持する
+type UserEventStats struct {
+	UserID           UserID
+	GroupID          GroupID
+	GroupName        GroupName
+	VotedCount       int
+	AttendVoteCount  int
+	ArrivedCount     int
+	OnTimeCount      int
+	NoShowCount      int
+	TotalLateMinutes int
+	WorstLateMinutes int
+	// 到着したイベントごとの遅刻時間。早く着いた場合は 0
+	LateMinutes []int
+	// 結果が確定したイベントごとに時間通りに到着したかどうか
+	OnTimeSequence []bool
+}
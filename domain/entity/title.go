@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+type TitleID string
+
+// Title はグループでの成績から与えられる称号。条件を満たさなくなると RevokedAt が設定される
+type Title struct {
+	TitleID TitleID `bson:"_id" json:"title_id"`
+	UserID  UserID  `bson:"user_id" json:"user_id"`
+	GroupID GroupID `bson:"group_id" json:"group_id"`
+	// 称号を獲得したときのシーズン
+	SeasonID    SeasonID   `bson:"season_id,omitempty" json:"season_id,omitempty"`
+	Name        Alias      `bson:"name" json:"name" example:"遅刻王"`
+	Description string     `bson:"description" json:"description"`
+	AwardedAt   time.Time  `bson:"awarded_at" json:"awarded_at"`
+	RevokedAt   *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// NewTitleID はグループ・ユーザー・称号の組から決まる ID を返す
+func NewTitleID(groupID GroupID, userID UserID, name Alias) TitleID {
+	return TitleID(string(groupID) + ":" + string(userID) + ":" + string(name))
+}
+
+// IsActive は現在も称号を保持しているかを返す
+func (t Title) IsActive() bool {
+	return t.RevokedAt == nil
+}
@@ -7,6 +7,7 @@ type UserName string
 type UserIcon string
 type FCMToken string
 type Alias string
+type CalendarToken string
 
 type User struct {
 	UserID   UserID   `bson:"_id" json:"user_id" example:"user123"`
@@ -15,4 +16,22 @@ type User struct {
 	UserIcon UserIcon `bson:"user_icon" json:"user_icon" example:"https://example.com/icon.png"`
 	FCMToken FCMToken `bson:"fcm_token" json:"fcm_token" example:"fcm-token-123456"`
 	Alias    Alias    `bson:"alias" json:"alias" example:"たろう"`
+	// エイリアスとして表示したい称号。空なら保持している称号から自動で選ぶ
+	AliasPreference Alias `bson:"alias_preference,omitempty" json:"alias_preference,omitempty" example:"遅刻王"`
+	// 位置情報の共有を停止しているグループ
+	LocationOptOutGroups []GroupID `bson:"location_opt_out_groups,omitempty" json:"location_opt_out_groups,omitempty"`
+	// カレンダーアプリから予定を購読するための秘密のトークン
+	CalendarToken CalendarToken `bson:"calendar_token,omitempty" json:"-"`
+	// 退会の処理中に、過去の記録を置き換える ID。やり直した場合も同じ ID を使うよう、最初に保存する
+	AnonymousID UserID `bson:"anonymous_id,omitempty" json:"-"`
+}
+
+// SharesLocationWith はグループ内で位置情報を共有するかどうかを返す
+func (u *User) SharesLocationWith(groupID GroupID) bool {
+	for _, optedOut := range u.LocationOptOutGroups {
+		if optedOut == groupID {
+			return false
+		}
+	}
+	return true
 }
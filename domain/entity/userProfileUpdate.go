@@ -0,0 +1,11 @@
+package entity
+
+// UserProfileUpdate はプロフィールの部分更新の内容。nil のフィールドは変更しない
+type UserProfileUpdate struct {
+	UserName        *UserName
+	UserIcon        *UserIcon
+	FCMToken        *FCMToken
+	AliasPreference *Alias
+	// Alias はエイリアスの希望に合わせて選び直したエイリアス
+	Alias *Alias
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "chikokulympic-api/domain/entity"
+
+type AuditLogRepository interface {
+	// AppendAuditLog は記録を追加する。記録は書き換えない
+	AppendAuditLog(log entity.AuditLog) (*entity.AuditLog, error)
+	FindAuditLogsByTargetUserID(userID entity.UserID) ([]entity.AuditLog, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "chikokulympic-api/domain/entity"
+
+type DeviceRepository interface {
+	// SaveDevice は端末を登録または更新する。同じトークンが他の端末に登録されていれば、そちらを削除する
+	SaveDevice(device entity.Device) (*entity.Device, error)
+	FindDevicesByUserID(userID entity.UserID) ([]entity.Device, error)
+	// DeleteDevice は削除した場合 true を返す
+	DeleteDevice(userID entity.UserID, deviceID entity.DeviceID) (bool, error)
+	DeleteDevicesByTokens(tokens []entity.FCMToken) (int64, error)
+	DeleteDevicesByUserID(userID entity.UserID) (int64, error)
+}
@@ -1,10 +1,51 @@
 package repository
 
-import "chikokulympic-api/domain/entity"
+import (
+	"chikokulympic-api/domain/entity"
+	"errors"
+	"time"
+)
+
+// ErrEventVersionConflict は読み込んだあとに他の更新が入ったため、イベントを更新しなかったことを表す
+var ErrEventVersionConflict = errors.New("event was modified concurrently")
 
 type EventRepository interface {
 	FindEventByEventID(eventID entity.EventID) (*entity.Event, error)
 	CreateEvent(event entity.Event) (*entity.Event, error)
+	// CreateEventInGroup はイベントを作成してグループのイベント一覧に追加する。どちらかに失敗した場合はイベントを残さない
+	CreateEventInGroup(event entity.Event, groupID entity.GroupID) (*entity.Event, error)
 	DeleteEvent(event entity.Event) (*entity.Event, error)
 	UpdateEvent(event entity.Event) (*entity.Event, error)
+	// UpdateEventFields は update で指定した項目だけを書き換えて版数を 1 増やし、更新後のイベントを返す
+	UpdateEventFields(eventID entity.EventID, update entity.EventUpdate) (*entity.Event, error)
+	// SetCheckinSecretIfMissing はチェックイン用シークレットがまだなければ保存する。すでにあれば書き換えず、保存されているイベントを返す
+	SetCheckinSecretIfMissing(eventID entity.EventID, secret string) (*entity.Event, error)
+	// UpdateEventIfVersion は保存されている版数が event.Version と一致する場合のみ投票とキャンセル待ちを書き換えて版数を 1 増やし、更新後のイベントを返す
+	// それ以外の項目は保存しない。一致しない場合は ErrEventVersionConflict を返す
+	UpdateEventIfVersion(event entity.Event) (*entity.Event, error)
+	// UpsertVote はメンバーの投票だけを書き換え、まだ投票していなければ追加する。到着の記録は変えない
+	UpsertVote(eventID entity.EventID, userID entity.UserID, vote entity.Vote) (*entity.Event, error)
+	// UpdateVotedMember は投票済みのメンバー 1 人の記録を置き換える。他のメンバーの記録は変えない
+	UpdateVotedMember(eventID entity.EventID, member entity.VotedMember) error
+	// UpsertOccurrence は繰り返しイベントの回を、同じ回がまだなければ作成する。作成した場合は true を返す
+	UpsertOccurrence(event entity.Event) (*entity.Event, bool, error)
+	// FindEventsBySeriesID は繰り返しイベントの回のうち、本来の開始日時が from 以降のものを古い順に返す
+	FindEventsBySeriesID(seriesID entity.EventSeriesID, from time.Time) ([]entity.Event, error)
+	// FindEventsByImportUIDs は指定したイベントのうち、取り込み元の UID が uids に含まれるものを返す
+	FindEventsByImportUIDs(eventIDs []entity.EventID, uids []string) ([]entity.Event, error)
+	// FindEventsByEventIDs は指定したイベントをまとめて返す。見つからないイベントは含まれない
+	FindEventsByEventIDs(eventIDs []entity.EventID) ([]entity.Event, error)
+	// FindEventsByUserID はユーザーが作成、投票、キャンセル待ちしたイベントを開始日時の古い順に返す
+	FindEventsByUserID(userID entity.UserID) ([]entity.Event, error)
+	// SetPayment は参加者の支払いを記録する。payment が nil の場合は記録を取り消す
+	SetPayment(eventID entity.EventID, userID entity.UserID, payment *entity.Payment) error
+	// RemoveUserFromEvents はユーザーの投票、キャンセル待ち、支払いの記録をすべてのイベントから取り除き、変更したイベントの数を返す
+	RemoveUserFromEvents(userID entity.UserID) (int64, error)
+	// AnonymizeUserInEvents は endedBefore までに終了したイベントの投票と支払い、すべてのイベントの作成者について、ユーザーIDを anonymousID に置き換える
+	// 置き換えたイベントの数を返す
+	AnonymizeUserInEvents(userID entity.UserID, anonymousID entity.UserID, endedBefore time.Time) (int64, error)
+	// AssignSeason は指定したイベントのうち、開始時刻が期間内でシーズン未割り当てのものをシーズンに割り当てる
+	AssignSeason(eventIDs []entity.EventID, seasonID entity.SeasonID, from time.Time, to time.Time) (int64, error)
+	// AggregateUserStats はユーザーの全体とグループごとの成績を集計する。now より前に終了したイベントの欠席を数える
+	AggregateUserStats(userID entity.UserID, now time.Time) (*entity.UserEventStats, []entity.UserEventStats, error)
 }
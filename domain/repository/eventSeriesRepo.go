@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"chikokulympic-api/domain/entity"
+	"time"
+)
+
+type EventSeriesRepository interface {
+	CreateSeries(series entity.EventSeries) (*entity.EventSeries, error)
+	FindSeriesBySeriesID(seriesID entity.EventSeriesID) (*entity.EventSeries, error)
+	// FindSeriesDueForMaterialization は horizon より前の回を作成し終えていない繰り返しを返す
+	FindSeriesDueForMaterialization(horizon time.Time) ([]entity.EventSeries, error)
+	UpdateSeries(series entity.EventSeries) (*entity.EventSeries, error)
+	// SetMaterializedUntil は作成済みの範囲を進める。範囲が戻ることはない
+	SetMaterializedUntil(seriesID entity.EventSeriesID, until time.Time, completed bool) error
+}
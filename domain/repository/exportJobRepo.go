@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"chikokulympic-api/domain/entity"
+	"time"
+)
+
+type ExportJobRepository interface {
+	CreateJob(job entity.ExportJob) (*entity.ExportJob, error)
+	FindJobByJobID(jobID entity.ExportJobID) (*entity.ExportJob, error)
+	// FindLatestJobByUserID はユーザーの最も新しいジョブを返す。なければ nil を返す
+	FindLatestJobByUserID(userID entity.UserID) (*entity.ExportJob, error)
+	FindJobsByStatus(status entity.ExportJobStatus) ([]entity.ExportJob, error)
+	// ClaimJob は作成待ちのジョブを作成中にする。他のインスタンスが先に取得した場合は false を返す
+	ClaimJob(jobID entity.ExportJobID, startedAt time.Time) (bool, error)
+	CompleteJob(jobID entity.ExportJobID, blobKey string, size int64, completedAt time.Time, expiresAt time.Time) error
+	FailJob(jobID entity.ExportJobID, message string) error
+	// FindExpiredJobs は now までにダウンロードの期限が過ぎた完了済みのジョブを返す
+	FindExpiredJobs(now time.Time) ([]entity.ExportJob, error)
+	MarkJobExpired(jobID entity.ExportJobID) error
+}
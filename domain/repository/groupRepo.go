@@ -1,12 +1,29 @@
 package repository
 
-import "chikokulympic-api/domain/entity"
+import (
+	"chikokulympic-api/domain/entity"
+	"errors"
+	"time"
+)
+
+// ErrDuplicateGroupName は同じ名前のグループがすでにあることを表す
+var ErrDuplicateGroupName = errors.New("group name already exists")
 
 type GroupRepository interface {
 	FindGroupByGroupName(groupName entity.GroupName) (*entity.Group, error)
 	FindGroupByGroupID(groupID entity.GroupID) (*entity.Group, error)
 	FindGroupsByUserID(userID entity.UserID) ([]*entity.Group, error)
+	FindGroupByEventID(eventID entity.EventID) (*entity.Group, error)
 	CreateGroup(group entity.Group) (*entity.Group, error)
 	DeleteGroup(group entity.Group) (*entity.Group, error)
 	UpdateGroup(group entity.Group) (*entity.Group, error)
+	UpdateScoringRule(groupID entity.GroupID, rule entity.ScoringRule) error
+	// AddGroupEvents はまだ含まれていないイベントだけをグループのイベント一覧の末尾に追加する。他の更新と同時に呼ばれても追加は失われない
+	AddGroupEvents(groupID entity.GroupID, eventIDs []entity.EventID) error
+	// RemoveMemberFromGroups はユーザーを所属するすべてのグループのメンバーから外し、外したグループの数を返す
+	RemoveMemberFromGroups(userID entity.UserID) (int64, error)
+	// TransferGroupManager は管理者が from のままの場合だけ管理者を to に変更する
+	TransferGroupManager(groupID entity.GroupID, from entity.UserID, to entity.UserID) error
+	// AggregateMemberStats は期間内に開始した確定済みのイベントについて、メンバーごとの成績を集計する
+	AggregateMemberStats(groupID entity.GroupID, from time.Time, to time.Time, now time.Time) ([]entity.UserEventStats, error)
 }
@@ -0,0 +1,11 @@
+package repository
+
+import "chikokulympic-api/domain/entity"
+
+type LocationHistoryRepository interface {
+	AppendLocation(eventID entity.EventID, location entity.UserLocation) error
+	FindTrail(eventID entity.EventID, userID entity.UserID) ([]entity.LocationTrailPoint, error)
+	// FindHistoryByUserID はユーザーのすべてのイベントの位置履歴を古い順に返す
+	FindHistoryByUserID(userID entity.UserID) ([]entity.LocationTrailPoint, error)
+	DeleteHistoryByUserID(userID entity.UserID) (int64, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"chikokulympic-api/domain/entity"
+	"time"
+)
+
+type NotificationQueueRepository interface {
+	EnqueueNotification(queued entity.QueuedNotification) (*entity.QueuedNotification, error)
+	// ClaimDueNotification は送る時刻が now 以前の通知のうち最も早いものをキューから取り出して返す。なければ nil を返す
+	// 取り出しは不可分に行うため、複数のインスタンスが同時に呼んでも同じ通知を二度返さない
+	ClaimDueNotification(now time.Time) (*entity.QueuedNotification, error)
+	DeleteQueuedNotificationsByUserID(userID entity.UserID) error
+}
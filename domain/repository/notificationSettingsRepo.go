@@ -0,0 +1,10 @@
+package repository
+
+import "chikokulympic-api/domain/entity"
+
+type NotificationSettingsRepository interface {
+	// FindSettingsByUserID はまだ設定していない場合 nil を返す
+	FindSettingsByUserID(userID entity.UserID) (*entity.NotificationSettings, error)
+	SaveSettings(settings entity.NotificationSettings) error
+	DeleteSettingsByUserID(userID entity.UserID) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"chikokulympic-api/domain/entity"
+	"time"
+)
+
+type ScoreRepository interface {
+	SaveScores(scores []entity.Score) error
+	// from, to がゼロ値の場合はその側の期間を制限しない
+	FindScoresByGroupID(groupID entity.GroupID, from time.Time, to time.Time) ([]entity.Score, error)
+	FindScoresBySeasonID(seasonID entity.SeasonID) ([]entity.Score, error)
+	// AssignSeason はグループのスコアのうち、イベントの開始時刻が [from, to) にありシーズン未割り当てのものをシーズンに割り当て、割り当てた件数を返す
+	AssignSeason(groupID entity.GroupID, seasonID entity.SeasonID, from time.Time, to time.Time) (int64, error)
+	// ReassignUser はユーザーのスコアを anonymousID のスコアとして保存し直し、置き換えた件数を返す
+	ReassignUser(userID entity.UserID, anonymousID entity.UserID) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"chikokulympic-api/domain/entity"
+	"time"
+)
+
+type SeasonRepository interface {
+	CreateSeason(season entity.Season) (*entity.Season, error)
+	FindSeasonBySeasonID(seasonID entity.SeasonID) (*entity.Season, error)
+	FindSeasonsByGroupID(groupID entity.GroupID) ([]entity.Season, error)
+	// FindSeasonByTime は時刻を含むシーズンを返す。該当するシーズンがなければ nil を返す
+	FindSeasonByTime(groupID entity.GroupID, t time.Time) (*entity.Season, error)
+	// CloseSeason は締めていないシーズンにだけ順位表を保存する
+	CloseSeason(seasonID entity.SeasonID, closedAt time.Time, endDateTime time.Time, standings []entity.SeasonStanding) error
+}
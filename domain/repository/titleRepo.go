@@ -0,0 +1,10 @@
+package repository
+
+import "chikokulympic-api/domain/entity"
+
+type TitleRepository interface {
+	SaveTitle(title entity.Title) error
+	FindTitlesByGroupID(groupID entity.GroupID) ([]entity.Title, error)
+	FindTitlesByUserID(userID entity.UserID) ([]entity.Title, error)
+	DeleteTitlesByUserID(userID entity.UserID) (int64, error)
+}
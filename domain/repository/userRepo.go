@@ -1,6 +1,12 @@
 package repository
 
-import "chikokulympic-api/domain/entity"
+import (
+	"chikokulympic-api/domain/entity"
+	"errors"
+)
+
+// ErrDuplicateAuthID は同じ AuthID のユーザーがすでに登録されていることを表す
+var ErrDuplicateAuthID = errors.New("auth ID already registered")
 
 type UserRepository interface {
 	FindUserByUserID(userID entity.UserID) (*entity.User, error)
@@ -8,4 +14,13 @@ type UserRepository interface {
 	CreateUser(user entity.User) (*entity.User, error)
 	DeleteUser(user entity.User) (*entity.User, error)
 	UpdateUser(user entity.User) (*entity.User, error)
+	// UpdateUserProfile は update で指定されたフィールドだけを変更し、更新後のユーザーを返す。見つからない場合 nil を返す
+	UpdateUserProfile(userID entity.UserID, update entity.UserProfileUpdate) (*entity.User, error)
+	SetLocationSharing(userID entity.UserID, groupID entity.GroupID, enabled bool) error
+	UpdateAlias(userID entity.UserID, alias entity.Alias) error
+	SetCalendarToken(userID entity.UserID, token entity.CalendarToken) error
+	// ReserveAnonymousID はまだ匿名の ID が保存されていなければ anonymousID を保存し、保存されている ID を返す
+	ReserveAnonymousID(userID entity.UserID, anonymousID entity.UserID) (entity.UserID, error)
+	// FindUserByCalendarToken は見つからない場合 nil を返す
+	FindUserByCalendarToken(token entity.CalendarToken) (*entity.User, error)
 }
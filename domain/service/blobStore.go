@@ -0,0 +1,20 @@
+package service
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound は指定したキーのデータが保存されていないことを表す
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore はファイルなどのバイナリデータを保存する
+// 現在はローカルのファイルシステムに保存する実装のみだが、クラウドストレージの実装に差し替える想定
+type BlobStore interface {
+	// Put は key にデータを保存する。同じ key のデータがあれば置き換える
+	Put(key string, data io.Reader) (int64, error)
+	// Open は key のデータを読み出す。見つからない場合は ErrBlobNotFound を返す
+	Open(key string) (io.ReadCloser, error)
+	// Delete は key のデータを削除する。見つからない場合も成功として扱う
+	Delete(key string) error
+}
@@ -0,0 +1,16 @@
+package service
+
+import "chikokulympic-api/domain/entity"
+
+// LocationHub はイベント参加者間で位置情報を配信する
+// 現在はプロセス内の実装のみだが、複数インスタンス構成では Redis Pub/Sub などの実装に差し替える想定
+type LocationHub interface {
+	Subscribe(eventID entity.EventID, userID entity.UserID) (LocationSubscription, error)
+	Publish(eventID entity.EventID, location entity.UserLocation) error
+}
+
+// LocationSubscription は自分以外の参加者から届いた位置情報を受け取る購読
+type LocationSubscription interface {
+	Updates() <-chan entity.UserLocation
+	Close()
+}
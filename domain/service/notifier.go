@@ -0,0 +1,9 @@
+package service
+
+import "chikokulympic-api/domain/entity"
+
+// Notifier はユーザーに通知を送る
+// 現在はログに出力する実装のみだが、プッシュ通知などの実装に差し替える想定
+type Notifier interface {
+	Notify(userID entity.UserID, notification entity.Notification) error
+}
@@ -0,0 +1,332 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"chikokulympic-api/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migrations はアプリケーションが使うマイグレーションの一覧。追加するときは末尾に新しい Version で足し、適用済みのものは変更しない
+var Migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_user_indexes",
+		Up: createIndexes("users",
+			mongo.IndexModel{Keys: bson.D{{Key: "auth_id", Value: 1}}, Options: options.Index().SetName("auth_id_unique").SetUnique(true)},
+			mongo.IndexModel{
+				Keys: bson.D{{Key: "calendar_token", Value: 1}},
+				Options: options.Index().SetName("calendar_token_unique").SetUnique(true).
+					SetPartialFilterExpression(bson.M{"calendar_token": bson.M{"$exists": true}}),
+			},
+		),
+	},
+	{
+		Version: 2,
+		Name:    "create_group_indexes",
+		Up: createIndexes("groups",
+			mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name_unique").SetUnique(true)},
+			mongo.IndexModel{Keys: bson.D{{Key: "members", Value: 1}}, Options: options.Index().SetName("members")},
+			mongo.IndexModel{Keys: bson.D{{Key: "manager_id", Value: 1}}, Options: options.Index().SetName("manager_id")},
+			mongo.IndexModel{Keys: bson.D{{Key: "events", Value: 1}}, Options: options.Index().SetName("events")},
+		),
+	},
+	{
+		Version: 3,
+		Name:    "create_event_indexes",
+		Up: createIndexes("events",
+			mongo.IndexModel{Keys: bson.D{{Key: "event_start_date_time", Value: 1}}, Options: options.Index().SetName("event_start_date_time")},
+			mongo.IndexModel{
+				Keys: bson.D{{Key: "series_id", Value: 1}, {Key: "recurrence_id", Value: 1}},
+				Options: options.Index().SetName("series_recurrence_unique").SetUnique(true).
+					SetPartialFilterExpression(bson.M{"series_id": bson.M{"$exists": true}}),
+			},
+			mongo.IndexModel{
+				Keys: bson.D{{Key: "import_uid", Value: 1}},
+				Options: options.Index().SetName("import_uid").
+					SetPartialFilterExpression(bson.M{"import_uid": bson.M{"$exists": true}}),
+			},
+		),
+	},
+	{
+		Version: 4,
+		Name:    "create_score_season_title_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndexes("scores",
+				mongo.IndexModel{Keys: bson.D{{Key: "group_id", Value: 1}, {Key: "event_start_date_time", Value: 1}}, Options: options.Index().SetName("group_event_start")},
+				mongo.IndexModel{Keys: bson.D{{Key: "season_id", Value: 1}}, Options: options.Index().SetName("season_id")},
+			)(ctx, db); err != nil {
+				return err
+			}
+			if err := createIndexes("seasons",
+				mongo.IndexModel{Keys: bson.D{{Key: "group_id", Value: 1}, {Key: "start_date_time", Value: 1}}, Options: options.Index().SetName("group_start")},
+			)(ctx, db); err != nil {
+				return err
+			}
+			if err := createIndexes("titles",
+				mongo.IndexModel{Keys: bson.D{{Key: "group_id", Value: 1}}, Options: options.Index().SetName("group_id")},
+				mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+			)(ctx, db); err != nil {
+				return err
+			}
+			return createIndexes("event_series",
+				mongo.IndexModel{Keys: bson.D{{Key: "completed", Value: 1}, {Key: "materialized_until", Value: 1}}, Options: options.Index().SetName("due_for_materialization")},
+			)(ctx, db)
+		},
+	},
+	{
+		// 以前は投票のないイベントやメンバーのいないグループの配列が null で保存されることがあり、$push で追加できなかった
+		Version: 5,
+		Name:    "replace_null_arrays",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := replaceNullArray(ctx, db.Collection("events"), "voted_members"); err != nil {
+				return err
+			}
+			if err := replaceNullArray(ctx, db.Collection("groups"), "members"); err != nil {
+				return err
+			}
+			return replaceNullArray(ctx, db.Collection("groups"), "events")
+		},
+	},
+	{
+		// 退会したユーザーの記録を探すためのインデックス
+		Version: 6,
+		Name:    "create_account_deletion_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndexes("audit_logs",
+				mongo.IndexModel{Keys: bson.D{{Key: "target_user_id", Value: 1}, {Key: "created_at", Value: 1}}, Options: options.Index().SetName("target_user_created_at")},
+			)(ctx, db); err != nil {
+				return err
+			}
+			if err := createIndexes("events",
+				mongo.IndexModel{Keys: bson.D{{Key: "voted_members.user_id", Value: 1}}, Options: options.Index().SetName("voted_members_user_id")},
+				mongo.IndexModel{Keys: bson.D{{Key: "event_author_id", Value: 1}}, Options: options.Index().SetName("event_author_id")},
+			)(ctx, db); err != nil {
+				return err
+			}
+			return createIndexes("scores",
+				mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+			)(ctx, db)
+		},
+	},
+	{
+		Version: 7,
+		Name:    "create_export_job_indexes",
+		Up: createIndexes("export_jobs",
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("user_created_at")},
+			mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}, Options: options.Index().SetName("status_created_at")},
+		),
+	},
+	{
+		Version: 8,
+		Name:    "create_device_indexes",
+		Up: createIndexes("devices",
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+			mongo.IndexModel{Keys: bson.D{{Key: "token", Value: 1}}, Options: options.Index().SetName("token")},
+		),
+	},
+	{
+		// 以前はリポジトリの作成時に作っていた。保持期間を後から変えた場合は、起動時に SyncLocationHistoryRetention で合わせる
+		Version: 9,
+		Name:    "create_location_history_collection",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createLocationHistoryCollection(ctx, db, locationHistoryRetention())
+		},
+	},
+	{
+		// 日時の変換を定義する前は、イベントの日時が空のドキュメントとして保存されていた。元の日時は復元できないため削除する
+		Version: 10,
+		Name:    "clear_empty_event_date_times",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, field := range []string{"event_start_date_time", "event_end_date_time", "event_closing_date_time"} {
+				result, err := db.Collection("events").UpdateMany(ctx, bson.M{field: bson.M{}}, bson.M{"$unset": bson.M{field: ""}})
+				if err != nil {
+					return fmt.Errorf("error clearing empty %s: %w", field, err)
+				}
+				if result.ModifiedCount > 0 {
+					log.Printf("Cleared %d events whose %s was saved as an empty document; set it again to use the event", result.ModifiedCount, field)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 11,
+		Name:    "create_notification_queue_indexes",
+		Up: createIndexes("notification_queue",
+			mongo.IndexModel{Keys: bson.D{{Key: "deliver_at", Value: 1}}, Options: options.Index().SetName("deliver_at")},
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+		),
+	},
+}
+
+// DuplicateKeysError は既存のドキュメントに重複があるため、一意インデックスを作成できないことを表す
+// 重複を解消してからマイグレーションを適用し直す
+type DuplicateKeysError struct {
+	Collection string
+	Index      string
+	Duplicates []DuplicateKey
+}
+
+// DuplicateKey は重複している値と、その値を持つドキュメントの ID
+type DuplicateKey struct {
+	Value bson.M        `bson:"_id"`
+	IDs   []interface{} `bson:"ids"`
+}
+
+func (e *DuplicateKeysError) Error() string {
+	values := make([]string, 0, len(e.Duplicates))
+	for _, duplicate := range e.Duplicates {
+		values = append(values, fmt.Sprintf("%v in %v", duplicate.Value, duplicate.IDs))
+	}
+	return fmt.Sprintf("cannot create unique index %s on %s: duplicate values exist (%s)", e.Index, e.Collection, strings.Join(values, ", "))
+}
+
+// 重複の報告に含める値の数の上限
+const maxReportedDuplicates = 20
+
+// findDuplicateKeys は一意インデックスの対象になるドキュメントのうち、キーが重複しているものを探す
+// フィールドがないドキュメントは一意インデックスでは null として扱われるため、同じく null として数える
+func findDuplicateKeys(ctx context.Context, collection *mongo.Collection, model mongo.IndexModel) ([]DuplicateKey, error) {
+	keys, ok := model.Keys.(bson.D)
+	if !ok {
+		return nil, fmt.Errorf("unsupported index keys: %T", model.Keys)
+	}
+
+	group := bson.M{}
+	for _, key := range keys {
+		group[key.Key] = "$" + key.Key
+	}
+
+	var filter interface{} = bson.M{}
+	if model.Options != nil && model.Options.PartialFilterExpression != nil {
+		filter = model.Options.PartialFilterExpression
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": group, "ids": bson.M{"$push": "$_id"}, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$limit", Value: maxReportedDuplicates}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error finding duplicate keys in %s: %w", collection.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	var duplicates []DuplicateKey
+	if err := cursor.All(ctx, &duplicates); err != nil {
+		return nil, fmt.Errorf("error decoding duplicate keys in %s: %w", collection.Name(), err)
+	}
+	return duplicates, nil
+}
+
+const locationHistoryCollection = "location_history"
+
+// locationHistoryRetention は位置履歴を保持する期間。LOCATION_HISTORY_RETENTION で変えられる
+func locationHistoryRetention() time.Duration {
+	return config.GetDurationEnvWithDefault("LOCATION_HISTORY_RETENTION", 30*24*time.Hour)
+}
+
+// SyncLocationHistoryRetention は位置履歴の保持期間を LOCATION_HISTORY_RETENTION に合わせる
+// マイグレーションは一度しか適用しないため、設定を変えても反映されるよう起動のたびに呼ぶ
+// コレクションがまだない場合や、既に同じ期間の場合は何もしない
+func SyncLocationHistoryRetention(ctx context.Context, db *mongo.Database) error {
+	expireAfterSeconds := int64(locationHistoryRetention().Seconds())
+
+	specs, err := db.ListCollectionSpecifications(ctx, bson.M{"name": locationHistoryCollection})
+	if err != nil {
+		return fmt.Errorf("error listing collections: %w", err)
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+	if current, ok := specs[0].Options.Lookup("expireAfterSeconds").AsInt64OK(); ok && current == expireAfterSeconds {
+		return nil
+	}
+
+	return setExpireAfterSeconds(ctx, db, locationHistoryCollection, expireAfterSeconds)
+}
+
+// createLocationHistoryCollection は位置履歴の時系列コレクションを作成する。既にある場合は保持期間だけを合わせる
+func createLocationHistoryCollection(ctx context.Context, db *mongo.Database, retention time.Duration) error {
+	const name = locationHistoryCollection
+	expireAfterSeconds := int64(retention.Seconds())
+
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": name})
+	if err != nil {
+		return fmt.Errorf("error listing collections: %w", err)
+	}
+
+	if len(names) == 0 {
+		opts := options.CreateCollection().
+			SetTimeSeriesOptions(options.TimeSeries().
+				SetTimeField("recorded_at").
+				SetMetaField("meta").
+				SetGranularity("seconds")).
+			SetExpireAfterSeconds(expireAfterSeconds)
+
+		if err := db.CreateCollection(ctx, name, opts); err != nil {
+			return fmt.Errorf("error creating collection %s: %w", name, err)
+		}
+		return nil
+	}
+
+	return setExpireAfterSeconds(ctx, db, name, expireAfterSeconds)
+}
+
+func setExpireAfterSeconds(ctx context.Context, db *mongo.Database, name string, expireAfterSeconds int64) error {
+	command := bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "expireAfterSeconds", Value: expireAfterSeconds},
+	}
+	if err := db.RunCommand(ctx, command).Err(); err != nil {
+		return fmt.Errorf("error updating retention of %s: %w", name, err)
+	}
+	return nil
+}
+
+// createIndexes はインデックスを作成する。一意インデックスは既存のドキュメントに重複がないことを確かめてから作成し、
+// 重複がある場合は何も作成せずに DuplicateKeysError を返す
+func createIndexes(collection string, models ...mongo.IndexModel) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, model := range models {
+			if model.Options == nil || model.Options.Unique == nil || !*model.Options.Unique {
+				continue
+			}
+			duplicates, err := findDuplicateKeys(ctx, db.Collection(collection), model)
+			if err != nil {
+				return err
+			}
+			if len(duplicates) > 0 {
+				index := ""
+				if model.Options.Name != nil {
+					index = *model.Options.Name
+				}
+				return &DuplicateKeysError{Collection: collection, Index: index, Duplicates: duplicates}
+			}
+		}
+
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("error creating indexes on %s: %w", collection, err)
+		}
+		return nil
+	}
+}
+
+// replaceNullArray は field が null または存在しないドキュメントに空の配列を設定する
+func replaceNullArray(ctx context.Context, collection *mongo.Collection, field string) error {
+	_, err := collection.UpdateMany(ctx, bson.M{field: nil}, bson.M{"$set": bson.M{field: bson.A{}}})
+	if err != nil {
+		return fmt.Errorf("error replacing null %s in %s: %w", field, collection.Name(), err)
+	}
+	return nil
+}
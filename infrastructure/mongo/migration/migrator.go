@@ -0,0 +1,218 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	migrationCollection = "schema_migrations"
+	lockID              = "lock"
+	// 実行中に落ちたインスタンスのロックは、この時間が過ぎたら他のインスタンスが引き継ぐ
+	// 実行中のインスタンスは lockRenewInterval ごとにロックを延長するため、適用に時間がかかっても引き継がれない
+	lockTimeout       = 10 * time.Minute
+	lockRenewInterval = lockTimeout / 3
+)
+
+// ErrLocked は他のインスタンスがマイグレーションを実行中であることを表す
+var ErrLocked = errors.New("migrations are being applied by another instance")
+
+// Migration はデータベースのスキーマやデータの変更 1 件分。Version の順に一度だけ適用する
+// 途中で失敗した場合は記録されずに次回やり直すため、Up は何度実行しても同じ結果になるように書く
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// AppliedMigration は適用済みのマイグレーションの記録
+type AppliedMigration struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+func NewMigrator(db *mongo.Database, migrations []Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{
+		db:         db,
+		migrations: sorted,
+	}
+}
+
+// Migrate は未適用のマイグレーションを古い順に適用し、適用したものを返す
+// 複数のインスタンスが同時に起動しても、適用するのはロックを取った 1 つだけ
+func (m *Migrator) Migrate(ctx context.Context) ([]Migration, error) {
+	owner, err := m.acquireLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.releaseLock(owner)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go m.renewLock(owner, stop)
+
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		done[migration.Version] = true
+	}
+
+	collection := m.db.Collection(migrationCollection)
+	var ran []Migration
+	for _, migration := range m.migrations {
+		if done[migration.Version] {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.db); err != nil {
+			return ran, fmt.Errorf("error applying migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		record := AppliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+		if _, err := collection.InsertOne(ctx, record); err != nil {
+			return ran, fmt.Errorf("error recording migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		ran = append(ran, migration)
+	}
+
+	return ran, nil
+}
+
+// MigrateWhenUnlocked は Migrate と同じだが、他のインスタンスがロックを持っている間は pollInterval ごとに待つ
+// 待っている間に他のインスタンスが全て適用し終えた場合は、何も適用せずに返る
+func (m *Migrator) MigrateWhenUnlocked(ctx context.Context, pollInterval time.Duration) ([]Migration, error) {
+	for {
+		ran, err := m.Migrate(ctx)
+		if !errors.Is(err, ErrLocked) {
+			return ran, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error waiting for migration lock: %w", ctx.Err())
+		case <-time.After(pollInterval):
+		}
+
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) == 0 {
+			return nil, nil
+		}
+	}
+}
+
+// Applied は適用済みのマイグレーションを古い順に返す
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	filter := bson.M{"_id": bson.M{"$type": "number"}}
+	cursor, err := m.db.Collection(migrationCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error finding applied migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	applied := []AppliedMigration{}
+	if err := cursor.All(ctx, &applied); err != nil {
+		return nil, fmt.Errorf("error decoding applied migrations: %w", err)
+	}
+
+	sort.Slice(applied, func(i, j int) bool { return applied[i].Version < applied[j].Version })
+	return applied, nil
+}
+
+// Pending は未適用のマイグレーションを古い順に返す
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		done[migration.Version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if !done[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// acquireLock はロックを取り、ロックの持ち主を表す値を返す
+func (m *Migrator) acquireLock(ctx context.Context) (string, error) {
+	collection := m.db.Collection(migrationCollection)
+	now := time.Now()
+	owner := primitive.NewObjectID().Hex()
+
+	_, err := collection.InsertOne(ctx, bson.M{"_id": lockID, "locked_at": now, "owner": owner})
+	if err == nil {
+		return owner, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return "", fmt.Errorf("error acquiring migration lock: %w", err)
+	}
+
+	// 期限切れのロックだけを引き継ぐ
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": lockID, "locked_at": bson.M{"$lt": now.Add(-lockTimeout)}},
+		bson.M{"$set": bson.M{"locked_at": now, "owner": owner}},
+	)
+	if err != nil {
+		return "", fmt.Errorf("error acquiring migration lock: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return "", ErrLocked
+	}
+	return owner, nil
+}
+
+// renewLock は stop が閉じられるまで、定期的にロックの期限を延ばす
+func (m *Migrator) renewLock(owner string, stop <-chan struct{}) {
+	ticker := time.NewTicker(lockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_, _ = m.db.Collection(migrationCollection).UpdateOne(ctx,
+				bson.M{"_id": lockID, "owner": owner},
+				bson.M{"$set": bson.M{"locked_at": time.Now()}},
+			)
+			cancel()
+		}
+	}
+}
+
+// releaseLock は自分のロックだけを外す。期限切れで他のインスタンスに引き継がれたロックは外さない
+func (m *Migrator) releaseLock(owner string) {
+	// 呼び出し元の ctx が切れていてもロックは外す
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, _ = m.db.Collection(migrationCollection).DeleteOne(ctx, bson.M{"_id": lockID, "owner": owner})
+}
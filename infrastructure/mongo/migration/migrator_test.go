@@ -0,0 +1,262 @@
+package migration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+	"chikokulympic-api/infrastructure/mongo/migration"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestMigrator(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("Migrate", func(t *testing.T) {
+		// 配列が null のまま、日時が空のドキュメントのまま保存された古いドキュメント
+		_, err := db.Collection("events").InsertOne(ctx, bson.M{"_id": "legacy-event", "voted_members": nil, "event_start_date_time": bson.M{}})
+		assert.NoError(t, err)
+
+		migrator := migration.NewMigrator(db, migration.Migrations)
+		applied, err := migrator.Migrate(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, applied, len(migration.Migrations))
+
+		records, err := migrator.Applied(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, records, len(migration.Migrations))
+		for i, record := range records {
+			assert.Equal(t, migration.Migrations[i].Version, record.Version)
+		}
+
+		pending, err := migrator.Pending(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+
+		var legacy bson.M
+		err = db.Collection("events").FindOne(ctx, bson.M{"_id": "legacy-event"}).Decode(&legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, bson.A{}, legacy["voted_members"])
+		assert.NotContains(t, legacy, "event_start_date_time")
+
+		// 位置履歴は時系列コレクションとして作成される
+		specs, err := db.ListCollectionSpecifications(ctx, bson.M{"name": "location_history"})
+		assert.NoError(t, err)
+		if assert.Len(t, specs, 1) {
+			assert.Equal(t, "timeseries", specs[0].Type)
+		}
+	})
+
+	t.Run("Migrate_AlreadyApplied", func(t *testing.T) {
+		applied, err := migration.NewMigrator(db, migration.Migrations).Migrate(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("Migrate_OnlyNewMigrations", func(t *testing.T) {
+		called := 0
+		migrations := append(append([]migration.Migration{}, migration.Migrations...), migration.Migration{
+			Version: 1000,
+			Name:    "test_migration",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				called++
+				return nil
+			},
+		})
+
+		applied, err := migration.NewMigrator(db, migrations).Migrate(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+		assert.Equal(t, 1, called)
+	})
+
+	t.Run("Migrate_Locked", func(t *testing.T) {
+		// 他のインスタンスが実行中のロックを再現する
+		_, err := db.Collection("schema_migrations").InsertOne(ctx, bson.M{"_id": "lock"})
+		assert.NoError(t, err)
+		defer db.Collection("schema_migrations").DeleteOne(ctx, bson.M{"_id": "lock"})
+
+		_, err = migration.NewMigrator(db, migration.Migrations).Migrate(ctx)
+		assert.ErrorIs(t, err, migration.ErrLocked)
+	})
+
+	t.Run("MigrateWhenUnlocked", func(t *testing.T) {
+		// 他のインスタンスが実行中のロックを再現し、少し後に外す
+		_, err := db.Collection("schema_migrations").InsertOne(ctx, bson.M{"_id": "lock", "locked_at": time.Now(), "owner": "other-instance"})
+		assert.NoError(t, err)
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_, _ = db.Collection("schema_migrations").DeleteOne(context.Background(), bson.M{"_id": "lock"})
+		}()
+
+		called := 0
+		migrations := append(append([]migration.Migration{}, migration.Migrations...), migration.Migration{
+			Version: 1001,
+			Name:    "test_migration_after_lock",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				called++
+				return nil
+			},
+		})
+
+		// テスト実行
+		applied, err := migration.NewMigrator(db, migrations).MigrateWhenUnlocked(ctx, 50*time.Millisecond)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+		assert.Equal(t, 1, called)
+		count, err := db.Collection("schema_migrations").CountDocuments(ctx, bson.M{"_id": "lock"})
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("MigrateWhenUnlocked_AppliedByOther", func(t *testing.T) {
+		// ロックが残っていても、全て適用済みなら待たずに返る
+		_, err := db.Collection("schema_migrations").InsertOne(ctx, bson.M{"_id": "lock", "locked_at": time.Now(), "owner": "other-instance"})
+		assert.NoError(t, err)
+		defer db.Collection("schema_migrations").DeleteOne(ctx, bson.M{"_id": "lock"})
+
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		applied, err := migration.NewMigrator(db, migration.Migrations).MigrateWhenUnlocked(waitCtx, 50*time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("MigrateWhenUnlocked_Timeout", func(t *testing.T) {
+		_, err := db.Collection("schema_migrations").InsertOne(ctx, bson.M{"_id": "lock", "locked_at": time.Now(), "owner": "other-instance"})
+		assert.NoError(t, err)
+		defer db.Collection("schema_migrations").DeleteOne(ctx, bson.M{"_id": "lock"})
+
+		migrations := append(append([]migration.Migration{}, migration.Migrations...), migration.Migration{
+			Version: 1002,
+			Name:    "test_migration_never_applied",
+			Up:      func(ctx context.Context, db *mongo.Database) error { return nil },
+		})
+		waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+
+		_, err = migration.NewMigrator(db, migrations).MigrateWhenUnlocked(waitCtx, 50*time.Millisecond)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Migrate_DoesNotReleaseTakenOverLock", func(t *testing.T) {
+		// 実行中にロックが期限切れで引き継がれた場合は、引き継いだインスタンスのロックを外さない
+		migrations := append(append([]migration.Migration{}, migration.Migrations...), migration.Migration{
+			Version: 1003,
+			Name:    "test_migration_lock_taken_over",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection("schema_migrations").UpdateOne(ctx, bson.M{"_id": "lock"}, bson.M{"$set": bson.M{"owner": "other-instance"}})
+				return err
+			},
+		})
+
+		_, err := migration.NewMigrator(db, migrations).Migrate(ctx)
+		assert.NoError(t, err)
+
+		count, err := db.Collection("schema_migrations").CountDocuments(ctx, bson.M{"_id": "lock", "owner": "other-instance"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		_, err = db.Collection("schema_migrations").DeleteOne(ctx, bson.M{"_id": "lock"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("SyncLocationHistoryRetention", func(t *testing.T) {
+		t.Setenv("LOCATION_HISTORY_RETENTION", "168h")
+
+		// テスト実行
+		err := migration.SyncLocationHistoryRetention(ctx, db)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		specs, err := db.ListCollectionSpecifications(ctx, bson.M{"name": "location_history"})
+		assert.NoError(t, err)
+		if assert.Len(t, specs, 1) {
+			expireAfterSeconds, ok := specs[0].Options.Lookup("expireAfterSeconds").AsInt64OK()
+			assert.True(t, ok)
+			assert.Equal(t, int64(7*24*60*60), expireAfterSeconds)
+		}
+
+		// 同じ期間のまま呼んでも失敗しない
+		assert.NoError(t, migration.SyncLocationHistoryRetention(ctx, db))
+	})
+
+	t.Run("UniqueIndexes", func(t *testing.T) {
+		groupRepo := repository.NewGroupRepository(db)
+		_, err := groupRepo.CreateGroup(entity.Group{GroupName: "重複グループ", GroupManagerID: "manager-id"})
+		assert.NoError(t, err)
+		_, err = groupRepo.CreateGroup(entity.Group{GroupName: "重複グループ", GroupManagerID: "manager-id"})
+		assert.ErrorIs(t, err, repo.ErrDuplicateGroupName)
+
+		userRepo := repository.NewUserRepository(db)
+		_, err = userRepo.CreateUser(entity.User{AuthID: "duplicate-auth-id", UserName: "user1"})
+		assert.NoError(t, err)
+		_, err = userRepo.CreateUser(entity.User{AuthID: "duplicate-auth-id", UserName: "user2"})
+		assert.ErrorIs(t, err, repo.ErrDuplicateAuthID)
+
+		// 繰り返しイベントの同じ回は直接挿入することもできない
+		recurrenceID := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+		for i, eventID := range []entity.EventID{"occurrence-id-1", "occurrence-id-2"} {
+			_, err := db.Collection("events").InsertOne(ctx, entity.Event{EventID: eventID, SeriesID: "unique-series-id", RecurrenceID: &recurrenceID})
+			assert.Equal(t, i > 0, mongo.IsDuplicateKeyError(err))
+		}
+	})
+}
+
+func TestMigratorDuplicateKeys(t *testing.T) {
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// 一意インデックスを作る前に登録された、同じ認証IDのユーザー
+	_, err := db.Collection("users").InsertMany(ctx, []interface{}{
+		bson.M{"_id": "duplicate-user-1", "auth_id": "shared-auth-id"},
+		bson.M{"_id": "duplicate-user-2", "auth_id": "shared-auth-id"},
+		bson.M{"_id": "unique-user", "auth_id": "unique-auth-id"},
+	})
+	assert.NoError(t, err)
+
+	migrator := migration.NewMigrator(db, migration.Migrations)
+
+	// テスト実行: 重複を報告してインデックスを作らず、以降のマイグレーションも適用しない
+	applied, err := migrator.Migrate(ctx)
+
+	// 結果の検証
+	assert.Empty(t, applied)
+	var duplicateErr *migration.DuplicateKeysError
+	if assert.ErrorAs(t, err, &duplicateErr) {
+		assert.Equal(t, "users", duplicateErr.Collection)
+		assert.Equal(t, "auth_id_unique", duplicateErr.Index)
+		if assert.Len(t, duplicateErr.Duplicates, 1) {
+			assert.Equal(t, "shared-auth-id", duplicateErr.Duplicates[0].Value["auth_id"])
+			assert.ElementsMatch(t, []interface{}{"duplicate-user-1", "duplicate-user-2"}, duplicateErr.Duplicates[0].IDs)
+		}
+	}
+
+	indexes, err := db.Collection("users").Indexes().ListSpecifications(ctx)
+	assert.NoError(t, err)
+	for _, index := range indexes {
+		assert.NotEqual(t, "auth_id_unique", index.Name)
+	}
+
+	// 重複を解消すれば適用できる
+	_, err = db.Collection("users").DeleteOne(ctx, bson.M{"_id": "duplicate-user-2"})
+	assert.NoError(t, err)
+
+	applied, err = migrator.Migrate(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, applied, len(migration.Migrations))
+}
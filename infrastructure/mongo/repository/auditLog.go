@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditLogRepo struct {
+	auditLogCollection *mongo.Collection
+}
+
+func NewAuditLogRepository(db *mongo.Database) repo.AuditLogRepository {
+	return &AuditLogRepo{
+		auditLogCollection: db.Collection("audit_logs"),
+	}
+}
+
+func (ar *AuditLogRepo) AppendAuditLog(log entity.AuditLog) (*entity.AuditLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 常に新しいObjectIDを生成して文字列に変換し、AuditLogIDにセットする
+	log.AuditLogID = entity.AuditLogID(primitive.NewObjectID().Hex())
+
+	_, err := ar.auditLogCollection.InsertOne(ctx, log)
+	if err != nil {
+		return nil, fmt.Errorf("error appending audit log: %w", err)
+	}
+
+	return &log, nil
+}
+
+func (ar *AuditLogRepo) FindAuditLogsByTargetUserID(userID entity.UserID) ([]entity.AuditLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := ar.auditLogCollection.Find(ctx, bson.M{"target_user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding audit logs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	logs := []entity.AuditLog{}
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, fmt.Errorf("error decoding audit logs: %w", err)
+	}
+
+	return logs, nil
+}
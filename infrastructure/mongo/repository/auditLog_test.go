@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewAuditLogRepository(db)
+
+	baseTime := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("AppendAuditLog", func(t *testing.T) {
+		// 追加した順ではなく日時の順に返す
+		second, err := repo.AppendAuditLog(entity.AuditLog{
+			Action:       entity.AuditActionUserDeleted,
+			TargetUserID: "audited-user-id",
+			Actor:        "self",
+			Details:      map[string]int64{"left_groups": 2},
+			CreatedAt:    baseTime.Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		first, err := repo.AppendAuditLog(entity.AuditLog{
+			Action:       entity.AuditActionUserDeleted,
+			TargetUserID: "audited-user-id",
+			Actor:        "chikokuctl",
+			CreatedAt:    baseTime,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, first.AuditLogID)
+		assert.NotEqual(t, first.AuditLogID, second.AuditLogID)
+
+		logs, err := repo.FindAuditLogsByTargetUserID("audited-user-id")
+		assert.NoError(t, err)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, first.AuditLogID, logs[0].AuditLogID)
+		assert.Equal(t, "self", logs[1].Actor)
+		assert.Equal(t, int64(2), logs[1].Details["left_groups"])
+
+		empty, err := repo.FindAuditLogsByTargetUserID("non-existent-user-id")
+		assert.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DeviceRepo struct {
+	deviceCollection *mongo.Collection
+}
+
+func NewDeviceRepository(db *mongo.Database) repo.DeviceRepository {
+	return &DeviceRepo{
+		deviceCollection: db.Collection("devices"),
+	}
+}
+
+func (dr *DeviceRepo) SaveDevice(device entity.Device) (*entity.Device, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	device.Key = entity.NewDeviceKey(device.UserID, device.DeviceID)
+
+	// 端末で別のアカウントにログインし直した場合など、トークンは最後に登録した端末だけが持つ
+	_, err := dr.deviceCollection.DeleteMany(ctx, bson.M{"token": device.Token, "_id": bson.M{"$ne": device.Key}})
+	if err != nil {
+		return nil, fmt.Errorf("error releasing device token: %w", err)
+	}
+
+	filter := bson.M{"_id": device.Key}
+	update := bson.M{
+		"$set": bson.M{
+			"user_id":      device.UserID,
+			"device_id":    device.DeviceID,
+			"token":        device.Token,
+			"platform":     device.Platform,
+			"last_seen_at": device.LastSeenAt,
+		},
+		"$setOnInsert": bson.M{"created_at": device.CreatedAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved entity.Device
+	if err := dr.deviceCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("error saving device: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (dr *DeviceRepo) FindDevicesByUserID(userID entity.UserID) ([]entity.Device, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+	cursor, err := dr.deviceCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding devices: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	devices := []entity.Device{}
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, fmt.Errorf("error decoding devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+func (dr *DeviceRepo) DeleteDevice(userID entity.UserID, deviceID entity.DeviceID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := dr.deviceCollection.DeleteOne(ctx, bson.M{"_id": entity.NewDeviceKey(userID, deviceID)})
+	if err != nil {
+		return false, fmt.Errorf("error deleting device: %w", err)
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+func (dr *DeviceRepo) DeleteDevicesByTokens(tokens []entity.FCMToken) (int64, error) {
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := dr.deviceCollection.DeleteMany(ctx, bson.M{"token": bson.M{"$in": tokens}})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting devices by token: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (dr *DeviceRepo) DeleteDevicesByUserID(userID entity.UserID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := dr.deviceCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting devices: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,128 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewDeviceRepository(db)
+
+	baseTime := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("SaveDevice", func(t *testing.T) {
+		phone, err := repo.SaveDevice(entity.Device{
+			UserID:     "device-user-id",
+			DeviceID:   "phone",
+			Token:      "token-phone-1",
+			Platform:   entity.DevicePlatformIOS,
+			CreatedAt:  baseTime,
+			LastSeenAt: baseTime,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, entity.NewDeviceKey("device-user-id", "phone"), phone.Key)
+
+		_, err = repo.SaveDevice(entity.Device{
+			UserID:     "device-user-id",
+			DeviceID:   "tablet",
+			Token:      "token-tablet",
+			Platform:   entity.DevicePlatformAndroid,
+			CreatedAt:  baseTime.Add(time.Hour),
+			LastSeenAt: baseTime.Add(time.Hour),
+		})
+		assert.NoError(t, err)
+
+		// 同じ端末の再登録ではトークンと最終利用日時だけが変わる
+		refreshed, err := repo.SaveDevice(entity.Device{
+			UserID:     "device-user-id",
+			DeviceID:   "phone",
+			Token:      "token-phone-2",
+			Platform:   entity.DevicePlatformIOS,
+			CreatedAt:  baseTime.Add(2 * time.Hour),
+			LastSeenAt: baseTime.Add(2 * time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, entity.FCMToken("token-phone-2"), refreshed.Token)
+		assert.True(t, refreshed.CreatedAt.Equal(baseTime))
+		assert.True(t, refreshed.LastSeenAt.Equal(baseTime.Add(2*time.Hour)))
+
+		devices, err := repo.FindDevicesByUserID("device-user-id")
+		assert.NoError(t, err)
+		assert.Len(t, devices, 2)
+		assert.Equal(t, entity.DeviceID("phone"), devices[0].DeviceID)
+		assert.Equal(t, entity.DeviceID("tablet"), devices[1].DeviceID)
+
+		t.Run("別のユーザーが同じトークンを登録すると元の登録は削除される", func(t *testing.T) {
+			_, err := repo.SaveDevice(entity.Device{
+				UserID:     "other-device-user-id",
+				DeviceID:   "shared-tablet",
+				Token:      "token-tablet",
+				Platform:   entity.DevicePlatformAndroid,
+				CreatedAt:  baseTime,
+				LastSeenAt: baseTime,
+			})
+			assert.NoError(t, err)
+
+			devices, err := repo.FindDevicesByUserID("device-user-id")
+			assert.NoError(t, err)
+			assert.Len(t, devices, 1)
+			assert.Equal(t, entity.DeviceID("phone"), devices[0].DeviceID)
+		})
+	})
+
+	t.Run("DeleteDevice", func(t *testing.T) {
+		deleted, err := repo.DeleteDevice("device-user-id", "phone")
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		deleted, err = repo.DeleteDevice("device-user-id", "phone")
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+
+	t.Run("DeleteDevicesByTokens", func(t *testing.T) {
+		for _, token := range []entity.FCMToken{"token-a", "token-b", "token-c"} {
+			_, err := repo.SaveDevice(entity.Device{
+				UserID:     "prune-user-id",
+				DeviceID:   entity.DeviceID("device-" + string(token)),
+				Token:      token,
+				Platform:   entity.DevicePlatformWeb,
+				CreatedAt:  baseTime,
+				LastSeenAt: baseTime,
+			})
+			assert.NoError(t, err)
+		}
+
+		deleted, err := repo.DeleteDevicesByTokens([]entity.FCMToken{"token-a", "token-c", "token-unknown"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		devices, err := repo.FindDevicesByUserID("prune-user-id")
+		assert.NoError(t, err)
+		assert.Len(t, devices, 1)
+		assert.Equal(t, entity.FCMToken("token-b"), devices[0].Token)
+
+		deleted, err = repo.DeleteDevicesByTokens(nil)
+		assert.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("DeleteDevicesByUserID", func(t *testing.T) {
+		deleted, err := repo.DeleteDevicesByUserID("other-device-user-id")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		devices, err := repo.FindDevicesByUserID("other-device-user-id")
+		assert.NoError(t, err)
+		assert.Empty(t, devices)
+	})
+}
@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"chikokulympic-api/domain/entity"
@@ -11,15 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type EventRepo struct {
 	eventCollection *mongo.Collection
+	groupCollection *mongo.Collection
+	// レプリカセットでない MongoDB ではトランザクションを使えないため、一度失敗したら以降は補償処理で作成する
+	transactionsUnsupported atomic.Bool
 }
 
 func NewEventRepository(db *mongo.Database) repo.EventRepository {
 	return &EventRepo{
 		eventCollection: db.Collection("events"),
+		groupCollection: db.Collection("groups"),
 	}
 }
 
@@ -46,6 +54,10 @@ func (er *EventRepo) CreateEvent(event entity.Event) (*entity.Event, error) {
 
 	// 常に新しいObjectIDを生成して文字列に変換し、EventIDにセットする
 	event.EventID = entity.EventID(primitive.NewObjectID().Hex())
+	// 投票を $push で追加できるよう、null ではなく空の配列で保存する
+	if event.VotedMembers == nil {
+		event.VotedMembers = []entity.VotedMember{}
+	}
 
 	_, err := er.eventCollection.InsertOne(ctx, event)
 	if err != nil {
@@ -55,6 +67,89 @@ func (er *EventRepo) CreateEvent(event entity.Event) (*entity.Event, error) {
 	return &event, nil
 }
 
+func (er *EventRepo) CreateEventInGroup(event entity.Event, groupID entity.GroupID) (*entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	event.EventID = entity.EventID(primitive.NewObjectID().Hex())
+	// 投票を $push で追加できるよう、null ではなく空の配列で保存する
+	if event.VotedMembers == nil {
+		event.VotedMembers = []entity.VotedMember{}
+	}
+
+	if !er.transactionsUnsupported.Load() {
+		err := er.createEventInGroupWithTransaction(ctx, event, groupID)
+		if !isTransactionUnsupported(err) {
+			if err != nil {
+				return nil, err
+			}
+			return &event, nil
+		}
+		er.transactionsUnsupported.Store(true)
+	}
+
+	if err := er.createEventInGroupWithCompensation(ctx, event, groupID); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (er *EventRepo) createEventInGroupWithTransaction(ctx context.Context, event entity.Event, groupID entity.GroupID) error {
+	session, err := er.eventCollection.Database().Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("error starting session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := er.eventCollection.InsertOne(sc, event); err != nil {
+			return nil, fmt.Errorf("error creating event: %w", err)
+		}
+		return nil, er.pushGroupEvent(sc, groupID, event.EventID)
+	})
+	return err
+}
+
+// createEventInGroupWithCompensation はトランザクションを使わずに作成し、グループに追加できなければ作成したイベントを削除する
+func (er *EventRepo) createEventInGroupWithCompensation(ctx context.Context, event entity.Event, groupID entity.GroupID) error {
+	if _, err := er.eventCollection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("error creating event: %w", err)
+	}
+
+	if err := er.pushGroupEvent(ctx, groupID, event.EventID); err != nil {
+		if _, deleteErr := er.eventCollection.DeleteOne(ctx, bson.M{"_id": event.EventID}); deleteErr != nil {
+			return fmt.Errorf("%w (error deleting orphaned event %s: %v)", err, event.EventID, deleteErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// pushGroupEvent は他のイベントの作成と同時に呼ばれても失われないよう、グループのイベント一覧に $push で追加する
+func (er *EventRepo) pushGroupEvent(ctx context.Context, groupID entity.GroupID, eventID entity.EventID) error {
+	result, err := er.groupCollection.UpdateOne(ctx, bson.M{"_id": groupID}, bson.M{"$push": bson.M{"events": eventID}})
+	if err != nil {
+		return fmt.Errorf("error adding event to group: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("group not found with ID: %s", string(groupID))
+	}
+	return nil
+}
+
+// isTransactionUnsupported はスタンドアロン構成の MongoDB でトランザクションを使おうとしたときのエラーかを返す
+func isTransactionUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == 20 {
+		return true
+	}
+	return strings.Contains(err.Error(), "Transaction numbers are only allowed")
+}
+
 func (er *EventRepo) DeleteEvent(event entity.Event) (*entity.Event, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -82,3 +177,433 @@ func (er *EventRepo) UpdateEvent(event entity.Event) (*entity.Event, error) {
 
 	return &event, nil
 }
+
+func (er *EventRepo) UpdateEventFields(eventID entity.EventID, update entity.EventUpdate) (*entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{}
+	unset := bson.M{}
+	if update.EventTitle != nil {
+		set["event_title"] = *update.EventTitle
+	}
+	if update.EventDescription != nil {
+		set["event_description"] = *update.EventDescription
+	}
+	if update.EventLocationName != nil {
+		set["event_location_name"] = *update.EventLocationName
+	}
+	if update.Cost != nil {
+		set["cost"] = *update.Cost
+	}
+	if update.EventMessage != nil {
+		set["event_message"] = *update.EventMessage
+	}
+	if update.Latitude != nil {
+		set["latitude"] = *update.Latitude
+	}
+	if update.Longitude != nil {
+		set["longitude"] = *update.Longitude
+	}
+	if update.EventStartDateTime != nil {
+		set["event_start_date_time"] = *update.EventStartDateTime
+	}
+	if update.EventEndDateTime != nil {
+		set["event_end_date_time"] = *update.EventEndDateTime
+	}
+	if update.EventClosingDateTime != nil {
+		set["event_closing_date_time"] = *update.EventClosingDateTime
+	}
+	if update.SeasonID != nil {
+		if *update.SeasonID == "" {
+			unset["season_id"] = ""
+		} else {
+			set["season_id"] = *update.SeasonID
+		}
+	}
+	if update.SeriesID != nil {
+		set["series_id"] = *update.SeriesID
+	}
+	if update.RecurrenceID != nil {
+		set["recurrence_id"] = *update.RecurrenceID
+	}
+	if update.FinalizedAt != nil {
+		set["finalized_at"] = *update.FinalizedAt
+	}
+	if update.SurchargeRule != nil {
+		set["surcharge_rule"] = *update.SurchargeRule
+	}
+
+	fields := bson.M{"$inc": bson.M{"version": 1}}
+	if len(set) > 0 {
+		fields["$set"] = set
+	}
+	if len(unset) > 0 {
+		fields["$unset"] = unset
+	}
+
+	var event entity.Event
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := er.eventCollection.FindOneAndUpdate(ctx, bson.M{"_id": eventID}, fields, opts).Decode(&event)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("event not found with ID: %s", eventID)
+		}
+		return nil, fmt.Errorf("error updating event fields: %w", err)
+	}
+
+	return &event, nil
+}
+
+func (er *EventRepo) SetCheckinSecretIfMissing(eventID entity.EventID, secret string) (*entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": eventID, "checkin_secret": bson.M{"$in": bson.A{nil, ""}}}
+	update := bson.M{"$set": bson.M{"checkin_secret": secret}, "$inc": bson.M{"version": 1}}
+
+	var event entity.Event
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := er.eventCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&event)
+	if err == nil {
+		return &event, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("error setting checkin secret: %w", err)
+	}
+
+	// 先に他の呼び出しがシークレットを保存していれば、そのシークレットを返す
+	return er.FindEventByEventID(eventID)
+}
+
+func (er *EventRepo) UpdateEventIfVersion(event entity.Event) (*entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 版数を持たない以前のイベントは 0 として扱う
+	filter := bson.M{"_id": event.EventID, "version": event.Version}
+	if event.Version == 0 {
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	// 支払いやシーズンなど他の操作が書き換える項目を古い内容で上書きしないよう、投票とキャンセル待ちだけを保存する
+	update := bson.M{
+		"$set": bson.M{"voted_members": event.VotedMembers, "waitlist": event.Waitlist},
+		"$inc": bson.M{"version": 1},
+	}
+
+	var updated entity.Event
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := er.eventCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repo.ErrEventVersionConflict
+		}
+		return nil, fmt.Errorf("error updating event: %w", err)
+	}
+
+	return &updated, nil
+}
+
+func (er *EventRepo) UpsertVote(eventID entity.EventID, userID entity.UserID, vote entity.Vote) (*entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	// 投票済みなら投票だけを書き換え、未投票なら追加する。追加の間に同じメンバーが追加された場合は書き換えからやり直す
+	for attempt := 0; attempt < 2; attempt++ {
+		var event entity.Event
+		err := er.eventCollection.FindOneAndUpdate(ctx,
+			bson.M{"_id": eventID, "voted_members.user_id": userID},
+			bson.M{"$set": bson.M{"voted_members.$.vote": vote}, "$inc": bson.M{"version": 1}},
+			opts,
+		).Decode(&event)
+		if err == nil {
+			return &event, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("error updating vote: %w", err)
+		}
+
+		err = er.eventCollection.FindOneAndUpdate(ctx,
+			bson.M{"_id": eventID, "voted_members.user_id": bson.M{"$ne": userID}},
+			bson.M{"$push": bson.M{"voted_members": entity.VotedMember{UserID: userID, Vote: vote}}, "$inc": bson.M{"version": 1}},
+			opts,
+		).Decode(&event)
+		if err == nil {
+			return &event, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("error adding vote: %w", err)
+		}
+	}
+
+	count, err := er.eventCollection.CountDocuments(ctx, bson.M{"_id": eventID})
+	if err != nil {
+		return nil, fmt.Errorf("error finding event by ID: %w", err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("event not found with ID: %s", eventID)
+	}
+	return nil, fmt.Errorf("error updating vote: concurrent updates for user %s", userID)
+}
+
+func (er *EventRepo) UpdateVotedMember(eventID entity.EventID, member entity.VotedMember) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": eventID, "voted_members.user_id": member.UserID}
+	update := bson.M{"$set": bson.M{"voted_members.$": member}, "$inc": bson.M{"version": 1}}
+
+	result, err := er.eventCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error updating voted member: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("voted member %s not found in event %s", member.UserID, eventID)
+	}
+
+	return nil
+}
+
+func (er *EventRepo) AssignSeason(eventIDs []entity.EventID, seasonID entity.SeasonID, from time.Time, to time.Time) (int64, error) {
+	if len(eventIDs) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":                   bson.M{"$in": eventIDs},
+		"event_start_date_time": bson.M{"$gte": from, "$lt": to},
+		"season_id":             bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"season_id": seasonID}, "$inc": bson.M{"version": 1}}
+
+	result, err := er.eventCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("error assigning season: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (er *EventRepo) UpsertOccurrence(event entity.Event) (*entity.Event, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	event.EventID = entity.EventID(primitive.NewObjectID().Hex())
+
+	// 同じ回を二重に作らないよう、繰り返しと本来の開始日時の組で存在しない場合だけ挿入する
+	filter := bson.M{"series_id": event.SeriesID, "recurrence_id": event.RecurrenceID}
+	if event.VotedMembers == nil {
+		event.VotedMembers = []entity.VotedMember{}
+	}
+	update := bson.M{"$setOnInsert": event}
+	opts := options.Update().SetUpsert(true)
+
+	result, err := er.eventCollection.UpdateOne(ctx, filter, update, opts)
+	// 同時に同じ回を挿入した場合は一意インデックスで弾かれるため、先に挿入された回を返す
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return nil, false, fmt.Errorf("error upserting occurrence: %w", err)
+	}
+	if err == nil && result.UpsertedCount > 0 {
+		return &event, true, nil
+	}
+
+	var existing entity.Event
+	if err := er.eventCollection.FindOne(ctx, filter).Decode(&existing); err != nil {
+		return nil, false, fmt.Errorf("error finding occurrence: %w", err)
+	}
+
+	return &existing, false, nil
+}
+
+func (er *EventRepo) FindEventsBySeriesID(seriesID entity.EventSeriesID, from time.Time) ([]entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"series_id":     seriesID,
+		"recurrence_id": bson.M{"$gte": from},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "recurrence_id", Value: 1}})
+
+	cursor, err := er.eventCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding events by series ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []entity.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("error decoding events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (er *EventRepo) FindEventsByEventIDs(eventIDs []entity.EventID) ([]entity.Event, error) {
+	if len(eventIDs) == 0 {
+		return []entity.Event{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := er.eventCollection.Find(ctx, bson.M{"_id": bson.M{"$in": eventIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("error finding events by IDs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []entity.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("error decoding events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (er *EventRepo) FindEventsByUserID(userID entity.UserID) ([]entity.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"event_author_id": userID},
+		bson.M{"voted_members.user_id": userID},
+		bson.M{"waitlist.user_id": userID},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "event_start_date_time", Value: 1}})
+
+	cursor, err := er.eventCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding events by user ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []entity.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("error decoding events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (er *EventRepo) SetPayment(eventID entity.EventID, userID entity.UserID, payment *entity.Payment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 他の参加者の支払いを上書きしないよう、対象の参加者の記録だけを更新する
+	field := "payments." + string(userID)
+	update := bson.M{"$unset": bson.M{field: ""}, "$inc": bson.M{"version": 1}}
+	if payment != nil {
+		update = bson.M{"$set": bson.M{field: payment}, "$inc": bson.M{"version": 1}}
+	}
+
+	result, err := er.eventCollection.UpdateOne(ctx, bson.M{"_id": eventID}, update)
+	if err != nil {
+		return fmt.Errorf("error setting payment: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("event not found with ID: %s", eventID)
+	}
+
+	return nil
+}
+
+func (er *EventRepo) RemoveUserFromEvents(userID entity.UserID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	payment := "payments." + string(userID)
+	filter := bson.M{"$or": bson.A{
+		bson.M{"voted_members.user_id": userID},
+		bson.M{"waitlist.user_id": userID},
+		bson.M{payment: bson.M{"$exists": true}},
+	}}
+	// 取り除いた枠にキャンセル待ちは繰り上げない。並行する投票の更新と競合しないよう版数を進める
+	update := bson.M{
+		"$pull": bson.M{
+			"voted_members": bson.M{"user_id": userID},
+			"waitlist":      bson.M{"user_id": userID},
+		},
+		"$unset": bson.M{payment: ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	result, err := er.eventCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("error removing user from events: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (er *EventRepo) AnonymizeUserInEvents(userID entity.UserID, anonymousID entity.UserID, endedBefore time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	payment := "payments." + string(userID)
+	filter := bson.M{
+		"event_end_date_time": bson.M{"$lte": endedBefore},
+		"$or": bson.A{
+			bson.M{"voted_members.user_id": userID},
+			bson.M{payment: bson.M{"$exists": true}},
+		},
+	}
+	// 到着の記録は残し、ランキングと精算の結果が変わらないようにする
+	update := bson.M{
+		"$set":    bson.M{"voted_members.$[member].user_id": anonymousID},
+		"$rename": bson.M{payment: "payments." + string(anonymousID)},
+		"$inc":    bson.M{"version": 1},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"member.user_id": userID}},
+	})
+
+	result, err := er.eventCollection.UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		return 0, fmt.Errorf("error anonymizing votes: %w", err)
+	}
+	anonymized := result.ModifiedCount
+
+	result, err = er.eventCollection.UpdateMany(ctx,
+		bson.M{"event_author_id": userID},
+		bson.M{"$set": bson.M{"event_author_id": anonymousID}, "$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return anonymized, fmt.Errorf("error anonymizing event authors: %w", err)
+	}
+
+	return anonymized + result.ModifiedCount, nil
+}
+
+func (er *EventRepo) FindEventsByImportUIDs(eventIDs []entity.EventID, uids []string) ([]entity.Event, error) {
+	if len(eventIDs) == 0 || len(uids) == 0 {
+		return []entity.Event{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": eventIDs},
+		"import_uid": bson.M{"$in": uids},
+	}
+
+	cursor, err := er.eventCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error finding events by import UIDs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []entity.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("error decoding events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type EventSeriesRepo struct {
+	seriesCollection *mongo.Collection
+}
+
+func NewEventSeriesRepository(db *mongo.Database) repo.EventSeriesRepository {
+	return &EventSeriesRepo{
+		seriesCollection: db.Collection("event_series"),
+	}
+}
+
+func (sr *EventSeriesRepo) CreateSeries(series entity.EventSeries) (*entity.EventSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 常に新しいObjectIDを生成して文字列に変換し、SeriesIDにセットする
+	series.SeriesID = entity.EventSeriesID(primitive.NewObjectID().Hex())
+
+	_, err := sr.seriesCollection.InsertOne(ctx, series)
+	if err != nil {
+		return nil, fmt.Errorf("error creating event series: %w", err)
+	}
+
+	return &series, nil
+}
+
+func (sr *EventSeriesRepo) FindSeriesBySeriesID(seriesID entity.EventSeriesID) (*entity.EventSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var series entity.EventSeries
+	err := sr.seriesCollection.FindOne(ctx, bson.M{"_id": seriesID}).Decode(&series)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("event series not found with ID: %s", string(seriesID))
+		}
+		return nil, fmt.Errorf("error finding event series by ID: %w", err)
+	}
+
+	return &series, nil
+}
+
+func (sr *EventSeriesRepo) FindSeriesDueForMaterialization(horizon time.Time) ([]entity.EventSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"completed":          false,
+		"materialized_until": bson.M{"$lt": horizon},
+	}
+
+	cursor, err := sr.seriesCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error finding event series to materialize: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	seriesList := []entity.EventSeries{}
+	if err := cursor.All(ctx, &seriesList); err != nil {
+		return nil, fmt.Errorf("error decoding event series: %w", err)
+	}
+
+	return seriesList, nil
+}
+
+func (sr *EventSeriesRepo) UpdateSeries(series entity.EventSeries) (*entity.EventSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": series.SeriesID}
+	update := bson.M{"$set": series}
+
+	result, err := sr.seriesCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("error updating event series: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("event series not found with ID: %s", string(series.SeriesID))
+	}
+
+	return &series, nil
+}
+
+func (sr *EventSeriesRepo) SetMaterializedUntil(seriesID entity.EventSeriesID, until time.Time, completed bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 並行して進められた範囲を巻き戻さないよう、今より先に進める場合だけ更新する
+	filter := bson.M{"_id": seriesID, "materialized_until": bson.M{"$lt": until}}
+	update := bson.M{"$set": bson.M{"materialized_until": until, "completed": completed}}
+
+	_, err := sr.seriesCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error updating materialized range: %w", err)
+	}
+
+	return nil
+}
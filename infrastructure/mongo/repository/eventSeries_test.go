@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventSeriesRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewEventSeriesRepository(db)
+
+	start := time.Date(2024, 8, 9, 10, 0, 0, 0, time.UTC)
+	rule, err := entity.ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=FR;COUNT=10")
+	assert.NoError(t, err)
+
+	var series *entity.EventSeries
+
+	t.Run("CreateSeries", func(t *testing.T) {
+		var err error
+		series, err = repo.CreateSeries(entity.EventSeries{
+			GroupID:         "series-group-id",
+			Rule:            rule,
+			TimeZone:        "Asia/Tokyo",
+			StartDateTime:   start,
+			Duration:        2 * time.Hour,
+			ClosingLeadTime: time.Hour,
+			Template:        entity.EventTemplate{EventTitle: "毎週の集まり", EventAuthorID: "series-author-id"},
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, series.SeriesID)
+
+		found, err := repo.FindSeriesBySeriesID(series.SeriesID)
+		assert.NoError(t, err)
+		assert.Equal(t, rule.String(), found.Rule.String())
+		assert.Equal(t, 2*time.Hour, found.Duration)
+		assert.Equal(t, entity.EventTitle("毎週の集まり"), found.Template.EventTitle)
+		assert.True(t, found.StartDateTime.Equal(start))
+	})
+
+	t.Run("FindSeriesBySeriesID not found", func(t *testing.T) {
+		found, err := repo.FindSeriesBySeriesID("non-existent-series-id")
+		assert.Error(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("SetMaterializedUntil", func(t *testing.T) {
+		horizon := start.AddDate(0, 0, 28)
+
+		due, err := repo.FindSeriesDueForMaterialization(horizon)
+		assert.NoError(t, err)
+		assert.Len(t, due, 1)
+
+		// テスト実行
+		err = repo.SetMaterializedUntil(series.SeriesID, horizon, false)
+		assert.NoError(t, err)
+
+		// 結果の検証
+		due, err = repo.FindSeriesDueForMaterialization(horizon)
+		assert.NoError(t, err)
+		assert.Empty(t, due)
+
+		// 範囲は巻き戻らない
+		err = repo.SetMaterializedUntil(series.SeriesID, start, false)
+		assert.NoError(t, err)
+		found, err := repo.FindSeriesBySeriesID(series.SeriesID)
+		assert.NoError(t, err)
+		assert.True(t, found.MaterializedUntil.Equal(horizon))
+
+		// 最後の回まで作成し終えた繰り返しは対象にならない
+		err = repo.SetMaterializedUntil(series.SeriesID, horizon.AddDate(0, 0, 7), true)
+		assert.NoError(t, err)
+		due, err = repo.FindSeriesDueForMaterialization(horizon.AddDate(1, 0, 0))
+		assert.NoError(t, err)
+		assert.Empty(t, due)
+	})
+
+	t.Run("UpdateSeries", func(t *testing.T) {
+		until := start.AddDate(0, 0, 20)
+		series.Rule.Count = 0
+		series.Rule.Until = &until
+		series.Template.EventTitle = "変更後のタイトル"
+
+		// テスト実行
+		_, err := repo.UpdateSeries(*series)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		found, err := repo.FindSeriesBySeriesID(series.SeriesID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, found.Rule.Count)
+		assert.True(t, found.Rule.Until.Equal(until))
+		assert.Equal(t, entity.EventTitle("変更後のタイトル"), found.Template.EventTitle)
+
+		_, err = repo.UpdateSeries(entity.EventSeries{SeriesID: "non-existent-series-id"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// userStatsDocument の _id には集計の単位に応じてグループ ID かユーザー ID が入る
+type userStatsDocument struct {
+	ID               string           `bson:"_id"`
+	GroupName        entity.GroupName `bson:"group_name"`
+	VotedCount       int              `bson:"voted_count"`
+	AttendVoteCount  int              `bson:"attend_vote_count"`
+	ArrivedCount     int              `bson:"arrived_count"`
+	OnTimeCount      int              `bson:"on_time_count"`
+	NoShowCount      int              `bson:"no_show_count"`
+	TotalLateMinutes int              `bson:"total_late_minutes"`
+	WorstLateMinutes int              `bson:"worst_late_minutes"`
+	LateMinutes      []int            `bson:"late_minutes"`
+	OnTimeSequence   []bool           `bson:"on_time_sequence"`
+}
+
+func (d userStatsDocument) toEntity() entity.UserEventStats {
+	return entity.UserEventStats{
+		GroupName:        d.GroupName,
+		VotedCount:       d.VotedCount,
+		AttendVoteCount:  d.AttendVoteCount,
+		ArrivedCount:     d.ArrivedCount,
+		OnTimeCount:      d.OnTimeCount,
+		NoShowCount:      d.NoShowCount,
+		TotalLateMinutes: d.TotalLateMinutes,
+		WorstLateMinutes: d.WorstLateMinutes,
+		LateMinutes:      d.LateMinutes,
+		OnTimeSequence:   d.OnTimeSequence,
+	}
+}
+
+// userStatsAccumulators は全体とグループ別の集計で共通の $group フィールド
+func userStatsAccumulators(groupKey interface{}) bson.M {
+	countIf := func(condition string) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{"$" + condition, 1, 0}}}
+	}
+
+	return bson.M{
+		"_id":                groupKey,
+		"group_name":         bson.M{"$first": "$group.name"},
+		"voted_count":        bson.M{"$sum": 1},
+		"attend_vote_count":  countIf("attending"),
+		"arrived_count":      countIf("arrived"),
+		"on_time_count":      countIf("on_time"),
+		"no_show_count":      countIf("no_show"),
+		"total_late_minutes": bson.M{"$sum": "$late_minutes"},
+		"worst_late_minutes": bson.M{"$max": "$late_minutes"},
+		// $$REMOVE になった値は配列に追加されない
+		"late_minutes":     bson.M{"$push": bson.M{"$cond": bson.A{"$arrived", "$late_minutes", "$$REMOVE"}}},
+		"on_time_sequence": bson.M{"$push": bson.M{"$cond": bson.A{bson.M{"$or": bson.A{"$arrived", "$no_show"}}, "$on_time", "$$REMOVE"}}},
+	}
+}
+
+// votedMemberOutcomeStages は voted_members を展開したドキュメントに、到着・遅刻・欠席の判定を追加する
+// 遅刻時間は到着ランキングと同じく開始時刻からの経過分を切り捨てで数える
+func votedMemberOutcomeStages(now time.Time) mongo.Pipeline {
+	confirmedArrival := bson.M{"$and": bson.A{
+		"$voted_members.is_arrival",
+		bson.M{"$in": bson.A{
+			bson.M{"$ifNull": bson.A{"$voted_members.arrival_flag.status", string(entity.ArrivalReviewApproved)}},
+			bson.A{string(entity.ArrivalReviewApproved)},
+		}},
+	}}
+	lateMinutes := bson.M{"$max": bson.A{
+		0,
+		bson.M{"$trunc": bson.M{"$divide": bson.A{
+			bson.M{"$subtract": bson.A{"$voted_members.arrival_date_time", "$event_start_date_time"}},
+			60000,
+		}}},
+	}}
+
+	return mongo.Pipeline{
+		{{Key: "$addFields", Value: bson.M{
+			"attending": bson.M{"$eq": bson.A{"$voted_members.vote", string(entity.VoteAttend)}},
+			"arrived":   bson.M{"$and": bson.A{bson.M{"$eq": bson.A{"$voted_members.vote", string(entity.VoteAttend)}}, confirmedArrival}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"late_minutes": bson.M{"$cond": bson.A{"$arrived", lateMinutes, 0}},
+			"no_show": bson.M{"$and": bson.A{
+				"$attending",
+				bson.M{"$not": bson.A{"$arrived"}},
+				bson.M{"$lt": bson.A{"$event_end_date_time", now}},
+			}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"on_time": bson.M{"$and": bson.A{"$arrived", bson.M{"$eq": bson.A{"$late_minutes", 0}}}},
+		}}},
+	}
+}
+
+// AggregateUserStats は Event.VotedMembers からユーザーの成績を集計する
+func (er *EventRepo) AggregateUserStats(userID entity.UserID, now time.Time) (*entity.UserEventStats, []entity.UserEventStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"voted_members.user_id": userID}}},
+		{{Key: "$unwind", Value: "$voted_members"}},
+		{{Key: "$match", Value: bson.M{"voted_members.user_id": userID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "groups",
+			"localField":   "_id",
+			"foreignField": "events",
+			"as":           "group",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$group", "preserveNullAndEmptyArrays": true}}},
+	}
+	pipeline = append(pipeline, votedMemberOutcomeStages(now)...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "event_start_date_time", Value: 1}}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"overall": bson.A{
+				bson.M{"$group": userStatsAccumulators(nil)},
+			},
+			"groups": bson.A{
+				bson.M{"$group": userStatsAccumulators("$group._id")},
+				bson.M{"$sort": bson.M{"voted_count": -1}},
+			},
+		}}},
+	)
+
+	cursor, err := er.eventCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error aggregating user stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Overall []userStatsDocument `bson:"overall"`
+		Groups  []userStatsDocument `bson:"groups"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, nil, fmt.Errorf("error decoding user stats: %w", err)
+	}
+
+	overall := &entity.UserEventStats{}
+	groups := []entity.UserEventStats{}
+	if len(results) == 0 {
+		return overall, groups, nil
+	}
+
+	if len(results[0].Overall) > 0 {
+		stats := results[0].Overall[0].toEntity()
+		stats.UserID = userID
+		stats.GroupName = ""
+		overall = &stats
+	}
+	for _, document := range results[0].Groups {
+		stats := document.toEntity()
+		stats.GroupID = entity.GroupID(document.ID)
+		groups = append(groups, stats)
+	}
+
+	return overall, groups, nil
+}
@@ -0,0 +1,116 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStats(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewEventRepository(db)
+
+	now := time.Now().Truncate(time.Millisecond)
+	userID := entity.UserID("stats-user-id")
+
+	newEvent := func(eventID entity.EventID, start time.Time, member entity.VotedMember) entity.Event {
+		member.UserID = userID
+		return entity.Event{
+			EventID:            eventID,
+			EventTitle:         "Stats Event",
+			EventAuthorID:      "stats-author-id",
+			EventStartDateTime: entity.StartDateTIme(start),
+			EventEndDateTime:   entity.EndDateTime(start.Add(2 * time.Hour)),
+			VotedMembers: []entity.VotedMember{
+				member,
+				{UserID: "other-stats-user-id", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: start.Add(30 * time.Minute)},
+			},
+		}
+	}
+
+	base := now.AddDate(0, 0, -10)
+	events := []entity.Event{
+		// 5 分早く到着
+		newEvent("stats-event-1", base, entity.VotedMember{Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: base.Add(-5 * time.Minute)}),
+		// 10 分遅刻
+		newEvent("stats-event-2", base.AddDate(0, 0, 1), entity.VotedMember{Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: base.AddDate(0, 0, 1).Add(10 * time.Minute)}),
+		// 時間通り
+		newEvent("stats-event-3", base.AddDate(0, 0, 2), entity.VotedMember{Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: base.AddDate(0, 0, 2)}),
+		// 欠席
+		newEvent("stats-event-4", base.AddDate(0, 0, 3), entity.VotedMember{Vote: entity.VoteAttend}),
+		// 確認待ちの到着は到着として数えない
+		newEvent("stats-event-5", base.AddDate(0, 0, 4), entity.VotedMember{
+			Vote:            entity.VoteAttend,
+			IsArrival:       true,
+			ArrivalDateTime: base.AddDate(0, 0, 4),
+			ArrivalFlag:     &entity.ArrivalFlag{Status: entity.ArrivalReviewPending},
+		}),
+		// 不参加
+		newEvent("stats-event-6", base.AddDate(0, 0, 5), entity.VotedMember{Vote: "不参加"}),
+		// まだ終わっていないイベントは欠席に数えない
+		newEvent("stats-event-7", now.Add(time.Hour), entity.VotedMember{Vote: entity.VoteAttend}),
+	}
+	for _, event := range events {
+		_, err := db.Collection("events").InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+	}
+
+	groups := []entity.Group{
+		{GroupID: "stats-group-1", GroupName: "StatsGroup1", GroupEvents: []entity.EventID{"stats-event-1", "stats-event-2", "stats-event-3", "stats-event-4"}},
+		{GroupID: "stats-group-2", GroupName: "StatsGroup2", GroupEvents: []entity.EventID{"stats-event-5", "stats-event-6", "stats-event-7"}},
+	}
+	for _, group := range groups {
+		_, err := db.Collection("groups").InsertOne(context.Background(), group)
+		assert.NoError(t, err)
+	}
+
+	t.Run("AggregateUserStats", func(t *testing.T) {
+		// テスト実行
+		overall, byGroup, err := repo.AggregateUserStats(userID, now)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, 7, overall.VotedCount)
+		assert.Equal(t, 6, overall.AttendVoteCount)
+		assert.Equal(t, 3, overall.ArrivedCount)
+		assert.Equal(t, 2, overall.OnTimeCount)
+		assert.Equal(t, 2, overall.NoShowCount)
+		assert.Equal(t, 10, overall.TotalLateMinutes)
+		assert.Equal(t, 10, overall.WorstLateMinutes)
+		assert.Equal(t, []int{0, 10, 0}, overall.LateMinutes)
+		assert.Equal(t, []bool{true, false, true, false, false}, overall.OnTimeSequence)
+
+		assert.Len(t, byGroup, 2)
+		for _, stats := range byGroup {
+			switch stats.GroupID {
+			case "stats-group-1":
+				assert.Equal(t, entity.GroupName("StatsGroup1"), stats.GroupName)
+				assert.Equal(t, 4, stats.VotedCount)
+				assert.Equal(t, 3, stats.ArrivedCount)
+				assert.Equal(t, 1, stats.NoShowCount)
+			case "stats-group-2":
+				assert.Equal(t, 3, stats.VotedCount)
+				assert.Equal(t, 0, stats.ArrivedCount)
+				assert.Equal(t, 1, stats.NoShowCount)
+			default:
+				t.Errorf("unexpected group: %s", stats.GroupID)
+			}
+		}
+	})
+
+	t.Run("AggregateUserStats with no events", func(t *testing.T) {
+		overall, byGroup, err := repo.AggregateUserStats("no-events-user-id", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, overall.VotedCount)
+		assert.Empty(t, byGroup)
+	})
+}
@@ -2,10 +2,14 @@ package repository_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"chikokulympic-api/domain/entity"
+	domainRepository "chikokulympic-api/domain/repository"
 	"chikokulympic-api/infrastructure/mongo/repository"
 	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
 
@@ -330,4 +334,607 @@ func TestEventRepository(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("AssignSeason", func(t *testing.T) {
+		seasonStart := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+		seasonEnd := seasonStart.AddDate(0, 3, 0)
+
+		events := []entity.Event{
+			{EventID: "assign-season-event-1", EventStartDateTime: entity.StartDateTIme(seasonStart)},
+			{EventID: "assign-season-event-2", EventStartDateTime: entity.StartDateTIme(seasonEnd)},
+			{EventID: "assign-season-event-3", EventStartDateTime: entity.StartDateTIme(seasonStart.AddDate(0, 1, 0)), SeasonID: "already-assigned-season-id"},
+			{EventID: "assign-season-event-4", EventStartDateTime: entity.StartDateTIme(seasonStart.AddDate(0, 1, 0))},
+		}
+		for _, event := range events {
+			_, err := db.Collection("events").InsertOne(context.Background(), event)
+			assert.NoError(t, err)
+		}
+
+		// テスト実行
+		// assign-season-event-4 は対象に含めない
+		assigned, err := repo.AssignSeason([]entity.EventID{"assign-season-event-1", "assign-season-event-2", "assign-season-event-3"}, "assign-season-id", seasonStart, seasonEnd)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), assigned)
+
+		expected := map[entity.EventID]entity.SeasonID{
+			"assign-season-event-1": "assign-season-id",
+			"assign-season-event-2": "",
+			"assign-season-event-3": "already-assigned-season-id",
+			"assign-season-event-4": "",
+		}
+		for eventID, seasonID := range expected {
+			event, err := repo.FindEventByEventID(eventID)
+			assert.NoError(t, err)
+			assert.Equal(t, seasonID, event.SeasonID)
+		}
+		// 割り当てたイベントは版数が進む
+		event, err := repo.FindEventByEventID("assign-season-event-1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), event.Version)
+
+		assigned, err = repo.AssignSeason(nil, "assign-season-id", seasonStart, seasonEnd)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), assigned)
+	})
+
+	t.Run("UpsertOccurrence", func(t *testing.T) {
+		recurrenceID := time.Date(2024, 8, 9, 10, 0, 0, 0, time.UTC)
+		occurrence := entity.Event{
+			EventTitle:         "毎週の集まり",
+			EventStartDateTime: entity.StartDateTIme(recurrenceID),
+			SeriesID:           "upsert-series-id",
+			RecurrenceID:       &recurrenceID,
+		}
+
+		// テスト実行
+		created, isNew, err := repo.UpsertOccurrence(occurrence)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.True(t, isNew)
+		assert.NotEmpty(t, created.EventID)
+
+		// 同じ回は作り直さず、既存のイベントを返す
+		occurrence.EventTitle = "別のタイトル"
+		existing, isNew, err := repo.UpsertOccurrence(occurrence)
+		assert.NoError(t, err)
+		assert.False(t, isNew)
+		assert.Equal(t, created.EventID, existing.EventID)
+		assert.Equal(t, entity.EventTitle("毎週の集まり"), existing.EventTitle)
+
+		count, err := db.Collection("events").CountDocuments(context.Background(), bson.M{"series_id": "upsert-series-id"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("FindEventsBySeriesID", func(t *testing.T) {
+		first := time.Date(2024, 9, 6, 10, 0, 0, 0, time.UTC)
+		for i := 2; i >= 0; i-- {
+			recurrenceID := first.AddDate(0, 0, 7*i)
+			_, _, err := repo.UpsertOccurrence(entity.Event{
+				EventStartDateTime: entity.StartDateTIme(recurrenceID),
+				SeriesID:           "find-series-id",
+				RecurrenceID:       &recurrenceID,
+			})
+			assert.NoError(t, err)
+		}
+		otherID := first
+		_, _, err := repo.UpsertOccurrence(entity.Event{SeriesID: "other-series-id", RecurrenceID: &otherID})
+		assert.NoError(t, err)
+
+		// テスト実行
+		events, err := repo.FindEventsBySeriesID("find-series-id", first.AddDate(0, 0, 7))
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		// 本来の開始日時の順に返る
+		assert.True(t, events[0].RecurrenceID.Equal(first.AddDate(0, 0, 7)))
+		assert.True(t, events[1].RecurrenceID.Equal(first.AddDate(0, 0, 14)))
+
+		events, err = repo.FindEventsBySeriesID("non-existent-series-id", first)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("FindEventsByImportUIDs", func(t *testing.T) {
+		events := []entity.Event{
+			{EventID: "import-uid-event-1", ImportUID: "uid-1@example.com"},
+			{EventID: "import-uid-event-2", ImportUID: "uid-2@example.com"},
+			{EventID: "import-uid-event-3"},
+			// 別のグループに同じ UID で取り込まれたイベント
+			{EventID: "import-uid-event-4", ImportUID: "uid-1@example.com"},
+		}
+		for _, event := range events {
+			_, err := db.Collection("events").InsertOne(context.Background(), event)
+			assert.NoError(t, err)
+		}
+
+		// テスト実行
+		found, err := repo.FindEventsByImportUIDs(
+			[]entity.EventID{"import-uid-event-1", "import-uid-event-2", "import-uid-event-3"},
+			[]string{"uid-1@example.com", "uid-3@example.com"},
+		)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+		assert.Equal(t, entity.EventID("import-uid-event-1"), found[0].EventID)
+
+		found, err = repo.FindEventsByImportUIDs(nil, []string{"uid-1@example.com"})
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("FindEventsByEventIDs", func(t *testing.T) {
+		for _, eventID := range []entity.EventID{"batch-event-1", "batch-event-2", "batch-event-3"} {
+			_, err := db.Collection("events").InsertOne(context.Background(), entity.Event{EventID: eventID})
+			assert.NoError(t, err)
+		}
+
+		// テスト実行
+		events, err := repo.FindEventsByEventIDs([]entity.EventID{"batch-event-1", "batch-event-3", "non-existent-event-id"})
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+
+		events, err = repo.FindEventsByEventIDs(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("SetPayment", func(t *testing.T) {
+		_, err := db.Collection("events").InsertOne(context.Background(), entity.Event{EventID: "payment-event", Cost: 3000})
+		assert.NoError(t, err)
+
+		paidAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		assert.NoError(t, repo.SetPayment("payment-event", "payment-user-1", &entity.Payment{PaidAt: paidAt, MarkedBy: "payment-author"}))
+		assert.NoError(t, repo.SetPayment("payment-event", "payment-user-2", &entity.Payment{PaidAt: paidAt, MarkedBy: "payment-author"}))
+
+		event, err := repo.FindEventByEventID("payment-event")
+		assert.NoError(t, err)
+		assert.Len(t, event.Payments, 2)
+		assert.True(t, event.Payments["payment-user-1"].PaidAt.Equal(paidAt))
+		assert.Equal(t, entity.UserID("payment-author"), event.Payments["payment-user-1"].MarkedBy)
+		assert.Equal(t, int64(2), event.Version)
+
+		// 取り消しは他の参加者の記録に影響しない
+		assert.NoError(t, repo.SetPayment("payment-event", "payment-user-1", nil))
+
+		event, err = repo.FindEventByEventID("payment-event")
+		assert.NoError(t, err)
+		assert.Len(t, event.Payments, 1)
+		assert.Contains(t, event.Payments, entity.UserID("payment-user-2"))
+
+		err = repo.SetPayment("non-existent-event-id", "payment-user-1", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateEventFields", func(t *testing.T) {
+		arrivedAt := time.Date(2026, 1, 1, 10, 3, 0, 0, time.UTC)
+		_, err := db.Collection("events").InsertOne(context.Background(), entity.Event{
+			EventID:    "fields-event",
+			EventTitle: "Before",
+			Cost:       1000,
+			SeasonID:   "fields-season",
+			VotedMembers: []entity.VotedMember{
+				{UserID: "fields-user", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: arrivedAt},
+			},
+			Version: 3,
+		})
+		assert.NoError(t, err)
+
+		// 読み込んだあとに投票が追加されても、指定した項目だけを書き換えるので失われない
+		_, err = repo.UpsertVote("fields-event", "late-voter", entity.VoteAttend)
+		assert.NoError(t, err)
+
+		title := entity.EventTitle("After")
+		finalizedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		noSeason := entity.SeasonID("")
+
+		// テスト実行
+		updated, err := repo.UpdateEventFields("fields-event", entity.EventUpdate{EventTitle: &title, FinalizedAt: &finalizedAt, SeasonID: &noSeason})
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, title, updated.EventTitle)
+		assert.Equal(t, entity.Cost(1000), updated.Cost)
+		assert.True(t, finalizedAt.Equal(*updated.FinalizedAt))
+		assert.Empty(t, updated.SeasonID)
+		assert.Len(t, updated.VotedMembers, 2)
+		assert.Equal(t, int64(5), updated.Version)
+
+		_, err = repo.UpdateEventFields("non-existent-event-id", entity.EventUpdate{EventTitle: &title})
+		assert.Error(t, err)
+	})
+
+	t.Run("SetCheckinSecretIfMissing", func(t *testing.T) {
+		_, err := db.Collection("events").InsertOne(context.Background(), entity.Event{EventID: "secret-event", Version: 1})
+		assert.NoError(t, err)
+
+		// テスト実行
+		first, err := repo.SetCheckinSecretIfMissing("secret-event", "first-secret")
+		assert.NoError(t, err)
+		second, err := repo.SetCheckinSecretIfMissing("secret-event", "second-secret")
+		assert.NoError(t, err)
+
+		// 結果の検証: 後から保存しようとしたシークレットで上書きしない
+		assert.Equal(t, "first-secret", first.CheckinSecret)
+		assert.Equal(t, "first-secret", second.CheckinSecret)
+		assert.Equal(t, int64(2), second.Version)
+
+		_, err = repo.SetCheckinSecretIfMissing("non-existent-event-id", "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateEventIfVersion", func(t *testing.T) {
+		// 版数を持たない以前のイベント
+		_, err := db.Collection("events").InsertOne(context.Background(), bson.M{"_id": "versioned-event", "capacity": 1})
+		assert.NoError(t, err)
+		paidAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		event, err := repo.FindEventByEventID("versioned-event")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), event.Version)
+
+		// 同じ版数から同時に最後の 1 席を取ろうとしても、更新できるのは 1 件だけ
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			succeeded int
+			conflicts int
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				attempt := *event
+				attempt.VotedMembers = []entity.VotedMember{{UserID: entity.UserID(fmt.Sprintf("versioned-user-%d", i)), Vote: entity.VoteAttend}}
+				_, err := repo.UpdateEventIfVersion(attempt)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err == nil {
+					succeeded++
+				} else if errors.Is(err, domainRepository.ErrEventVersionConflict) {
+					conflicts++
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, succeeded)
+		assert.Equal(t, 4, conflicts)
+
+		updated, err := repo.FindEventByEventID("versioned-event")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), updated.Version)
+		assert.Len(t, updated.VotedMembers, 1)
+
+		// 支払いの記録やシーズンの割り当ても版数を進めるため、読み込んだ後に書き換えられた版数では更新できない
+		stale := *updated
+		assert.NoError(t, repo.SetPayment("versioned-event", "versioned-user-paid", &entity.Payment{PaidAt: paidAt, MarkedBy: "versioned-author"}))
+		_, err = repo.UpdateEventIfVersion(stale)
+		assert.ErrorIs(t, err, domainRepository.ErrEventVersionConflict)
+
+		// 最新の版数からは更新でき、投票とキャンセル待ち以外の項目は上書きしない
+		latest, err := repo.FindEventByEventID("versioned-event")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), latest.Version)
+		latest.Waitlist = []entity.WaitlistEntry{{UserID: "versioned-user-waiting", RequestedAt: time.Now()}}
+		latest.Payments = nil
+		latest.Capacity = 5
+		result, err := repo.UpdateEventIfVersion(*latest)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.Version)
+		assert.Len(t, result.Waitlist, 1)
+		assert.Contains(t, result.Payments, entity.UserID("versioned-user-paid"))
+		assert.Equal(t, 1, result.Capacity)
+
+		_, err = repo.UpdateEventIfVersion(*latest)
+		assert.ErrorIs(t, err, domainRepository.ErrEventVersionConflict)
+	})
+
+	t.Run("UpsertVote", func(t *testing.T) {
+		arrivedAt := time.Date(2026, 1, 1, 10, 3, 0, 0, time.UTC)
+		_, err := db.Collection("events").InsertOne(context.Background(), entity.Event{
+			EventID: "vote-event",
+			VotedMembers: []entity.VotedMember{
+				{UserID: "vote-arrived-user", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: arrivedAt},
+			},
+		})
+		assert.NoError(t, err)
+
+		// 投票を変えても到着の記録は残る
+		event, err := repo.UpsertVote("vote-event", "vote-arrived-user", "不参加")
+		assert.NoError(t, err)
+		assert.Len(t, event.VotedMembers, 1)
+		assert.Equal(t, entity.Vote("不参加"), event.VotedMembers[0].Vote)
+		assert.True(t, event.VotedMembers[0].IsArrival)
+		assert.True(t, event.VotedMembers[0].ArrivalDateTime.Equal(arrivedAt))
+		assert.Equal(t, int64(1), event.Version)
+
+		// 並行して投票しても失われない
+		const voters = 50
+		var wg sync.WaitGroup
+		errs := make(chan error, voters*2)
+		for i := 0; i < voters; i++ {
+			wg.Add(2)
+			userID := entity.UserID(fmt.Sprintf("vote-user-%d", i))
+			go func() {
+				defer wg.Done()
+				_, err := repo.UpsertVote("vote-event", userID, "不参加")
+				errs <- err
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := repo.UpsertVote("vote-event", userID, entity.VoteAttend)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+
+		event, err = repo.FindEventByEventID("vote-event")
+		assert.NoError(t, err)
+		assert.Len(t, event.VotedMembers, voters+1)
+
+		seen := make(map[entity.UserID]int)
+		for _, member := range event.VotedMembers {
+			seen[member.UserID]++
+		}
+		for i := 0; i < voters; i++ {
+			assert.Equal(t, 1, seen[entity.UserID(fmt.Sprintf("vote-user-%d", i))])
+		}
+		assert.Equal(t, int64(1+voters*2), event.Version)
+
+		_, err = repo.UpsertVote("non-existent-event-id", "vote-user-0", entity.VoteAttend)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpsertVote: 作成したばかりのイベント", func(t *testing.T) {
+		// 投票のないイベントも作成の経路で保存すれば投票を追加できる
+		created, err := repo.CreateEvent(entity.Event{EventTitle: "投票のないイベント"})
+		assert.NoError(t, err)
+
+		event, err := repo.UpsertVote(created.EventID, "first-vote-user", entity.VoteAttend)
+		assert.NoError(t, err)
+		assert.Len(t, event.VotedMembers, 1)
+		assert.Equal(t, entity.UserID("first-vote-user"), event.VotedMembers[0].UserID)
+
+		recurrenceID := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+		occurrence, _, err := repo.UpsertOccurrence(entity.Event{SeriesID: "vote-series-id", RecurrenceID: &recurrenceID})
+		assert.NoError(t, err)
+
+		event, err = repo.UpsertVote(occurrence.EventID, "first-vote-user", entity.VoteAttend)
+		assert.NoError(t, err)
+		assert.Len(t, event.VotedMembers, 1)
+	})
+
+	t.Run("UpdateVotedMember", func(t *testing.T) {
+		_, err := db.Collection("events").InsertOne(context.Background(), entity.Event{
+			EventID: "member-event",
+			VotedMembers: []entity.VotedMember{
+				{UserID: "member-user-1", Vote: entity.VoteAttend},
+				{UserID: "member-user-2", Vote: entity.VoteAttend},
+			},
+		})
+		assert.NoError(t, err)
+
+		arrivedAt := time.Date(2026, 1, 1, 9, 58, 0, 0, time.UTC)
+		err = repo.UpdateVotedMember("member-event", entity.VotedMember{UserID: "member-user-2", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: arrivedAt})
+		assert.NoError(t, err)
+
+		event, err := repo.FindEventByEventID("member-event")
+		assert.NoError(t, err)
+		assert.False(t, event.VotedMembers[0].IsArrival)
+		assert.True(t, event.VotedMembers[1].IsArrival)
+		assert.True(t, event.VotedMembers[1].ArrivalDateTime.Equal(arrivedAt))
+
+		err = repo.UpdateVotedMember("member-event", entity.VotedMember{UserID: "member-user-3", Vote: entity.VoteAttend})
+		assert.Error(t, err)
+	})
+
+	t.Run("CreateEventInGroup", func(t *testing.T) {
+		_, err := db.Collection("groups").InsertOne(context.Background(), entity.Group{GroupID: "create-in-group-id", GroupEvents: []entity.EventID{}})
+		assert.NoError(t, err)
+
+		// テスト実行
+		created, err := repo.CreateEventInGroup(entity.Event{EventTitle: "Grouped Event"}, "create-in-group-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.EventID)
+
+		var group entity.Group
+		err = db.Collection("groups").FindOne(context.Background(), bson.M{"_id": "create-in-group-id"}).Decode(&group)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.EventID{created.EventID}, []entity.EventID(group.GroupEvents))
+
+		// 作成したばかりのイベントにも投票を追加できる
+		voted, err := repo.UpsertVote(created.EventID, "grouped-vote-user", entity.VoteAttend)
+		assert.NoError(t, err)
+		assert.Len(t, voted.VotedMembers, 1)
+
+		// グループがなければイベントも残らない
+		before, err := db.Collection("events").CountDocuments(context.Background(), bson.M{})
+		assert.NoError(t, err)
+
+		_, err = repo.CreateEventInGroup(entity.Event{EventTitle: "Orphan Event"}, "non-existent-group-id")
+		assert.Error(t, err)
+
+		after, err := db.Collection("events").CountDocuments(context.Background(), bson.M{})
+		assert.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("RemoveUserFromEvents", func(t *testing.T) {
+		events := []interface{}{
+			entity.Event{
+				EventID: "remove-user-event-1",
+				VotedMembers: []entity.VotedMember{
+					{UserID: "removed-user-id", Vote: entity.VoteAttend, IsArrival: true},
+					{UserID: "kept-user-id", Vote: entity.VoteAttend},
+				},
+				Payments: map[entity.UserID]entity.Payment{
+					"removed-user-id": {MarkedBy: "author-id"},
+					"kept-user-id":    {MarkedBy: "author-id"},
+				},
+			},
+			entity.Event{
+				EventID:      "remove-user-event-2",
+				VotedMembers: []entity.VotedMember{{UserID: "kept-user-id", Vote: entity.VoteAttend}},
+				Waitlist:     []entity.WaitlistEntry{{UserID: "removed-user-id"}},
+			},
+			entity.Event{
+				EventID:      "remove-user-event-3",
+				VotedMembers: []entity.VotedMember{{UserID: "kept-user-id", Vote: entity.VoteAttend}},
+			},
+		}
+		_, err := db.Collection("events").InsertMany(context.Background(), events)
+		assert.NoError(t, err)
+
+		// テスト実行
+		updated, err := repo.RemoveUserFromEvents("removed-user-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+
+		first, err := repo.FindEventByEventID("remove-user-event-1")
+		assert.NoError(t, err)
+		assert.Len(t, first.VotedMembers, 1)
+		assert.Equal(t, entity.UserID("kept-user-id"), first.VotedMembers[0].UserID)
+		assert.NotContains(t, first.Payments, entity.UserID("removed-user-id"))
+		assert.Contains(t, first.Payments, entity.UserID("kept-user-id"))
+		assert.Equal(t, int64(1), first.Version)
+
+		second, err := repo.FindEventByEventID("remove-user-event-2")
+		assert.NoError(t, err)
+		assert.Empty(t, second.Waitlist)
+		assert.Len(t, second.VotedMembers, 1)
+
+		third, err := repo.FindEventByEventID("remove-user-event-3")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), third.Version)
+	})
+
+	t.Run("AnonymizeUserInEvents", func(t *testing.T) {
+		now := time.Now()
+		events := []interface{}{
+			entity.Event{
+				EventID:          "anonymize-ended-event",
+				EventAuthorID:    "anonymized-user-id",
+				EventEndDateTime: entity.EndDateTime(now.Add(-time.Hour)),
+				VotedMembers: []entity.VotedMember{
+					{UserID: "anonymized-user-id", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: now.Add(-2 * time.Hour)},
+					{UserID: "other-user-id", Vote: entity.VoteAttend},
+				},
+				Payments: map[entity.UserID]entity.Payment{"anonymized-user-id": {MarkedBy: "author-id"}},
+			},
+			entity.Event{
+				EventID:          "anonymize-upcoming-event",
+				EventAuthorID:    "author-id",
+				EventEndDateTime: entity.EndDateTime(now.Add(time.Hour)),
+				VotedMembers:     []entity.VotedMember{{UserID: "anonymized-user-id", Vote: entity.VoteAttend}},
+			},
+		}
+		_, err := db.Collection("events").InsertMany(context.Background(), events)
+		assert.NoError(t, err)
+
+		// テスト実行
+		updated, err := repo.AnonymizeUserInEvents("anonymized-user-id", "deleted-anonymous-id", now)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+
+		ended, err := repo.FindEventByEventID("anonymize-ended-event")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserID("deleted-anonymous-id"), ended.EventAuthorID)
+		assert.Equal(t, entity.UserID("deleted-anonymous-id"), ended.VotedMembers[0].UserID)
+		assert.True(t, ended.VotedMembers[0].IsArrival)
+		assert.Equal(t, entity.UserID("other-user-id"), ended.VotedMembers[1].UserID)
+		assert.Contains(t, ended.Payments, entity.UserID("deleted-anonymous-id"))
+		assert.NotContains(t, ended.Payments, entity.UserID("anonymized-user-id"))
+
+		// 終了していないイベントの投票は置き換えない
+		upcoming, err := repo.FindEventByEventID("anonymize-upcoming-event")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserID("anonymized-user-id"), upcoming.VotedMembers[0].UserID)
+	})
+
+	t.Run("FindEventsByUserID", func(t *testing.T) {
+		baseTime := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+		events := []interface{}{
+			entity.Event{EventID: "user-events-voted", EventStartDateTime: entity.StartDateTIme(baseTime.Add(2 * time.Hour)), VotedMembers: []entity.VotedMember{{UserID: "user-events-user-id", Vote: entity.VoteAttend}}},
+			entity.Event{EventID: "user-events-authored", EventStartDateTime: entity.StartDateTIme(baseTime), EventAuthorID: "user-events-user-id", VotedMembers: []entity.VotedMember{}},
+			entity.Event{EventID: "user-events-waitlisted", EventStartDateTime: entity.StartDateTIme(baseTime.Add(time.Hour)), VotedMembers: []entity.VotedMember{}, Waitlist: []entity.WaitlistEntry{{UserID: "user-events-user-id"}}},
+			entity.Event{EventID: "user-events-other", EventStartDateTime: entity.StartDateTIme(baseTime), VotedMembers: []entity.VotedMember{{UserID: "other-user-id", Vote: entity.VoteAttend}}},
+		}
+		_, err := db.Collection("events").InsertMany(context.Background(), events)
+		assert.NoError(t, err)
+
+		// テスト実行
+		found, err := repo.FindEventsByUserID("user-events-user-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		ids := make([]entity.EventID, 0, len(found))
+		for _, event := range found {
+			ids = append(ids, event.EventID)
+		}
+		assert.Equal(t, []entity.EventID{"user-events-authored", "user-events-waitlisted", "user-events-voted"}, ids)
+	})
+}
+
+func TestEventRepositoryDateTimes(t *testing.T) {
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+
+	t.Run("日時はBSONの日時型として保存される", func(t *testing.T) {
+		repo := repository.NewEventRepository(db)
+		start := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		event := &entity.Event{
+			EventID:              "test-date-event-id",
+			EventStartDateTime:   entity.StartDateTIme(start),
+			EventEndDateTime:     entity.EndDateTime(start.Add(2 * time.Hour)),
+			EventClosingDateTime: entity.EventClosingDateTime(start.Add(-1 * time.Hour)),
+		}
+		_, err := repo.CreateEvent(*event)
+		assert.NoError(t, err)
+
+		var raw bson.Raw
+		err = db.Collection("events").FindOne(context.Background(), bson.M{"_id": event.EventID}).Decode(&raw)
+		assert.NoError(t, err)
+		assert.Equal(t, bson.TypeDateTime, raw.Lookup("event_start_date_time").Type)
+		assert.Equal(t, bson.TypeDateTime, raw.Lookup("event_end_date_time").Type)
+		assert.Equal(t, bson.TypeDateTime, raw.Lookup("event_closing_date_time").Type)
+
+		found, err := repo.FindEventByEventID(event.EventID)
+		assert.NoError(t, err)
+		assert.True(t, start.Equal(time.Time(found.EventStartDateTime)))
+	})
+	t.Run("空のドキュメントとして保存された日時はゼロ値として読み込む", func(t *testing.T) {
+		repo := repository.NewEventRepository(db)
+		_, err := db.Collection("events").InsertOne(context.Background(), bson.M{
+			"_id":                     "test-empty-date-event-id",
+			"event_start_date_time":   bson.M{},
+			"event_end_date_time":     bson.M{},
+			"event_closing_date_time": bson.M{},
+		})
+		assert.NoError(t, err)
+
+		found, err := repo.FindEventByEventID("test-empty-date-event-id")
+		assert.NoError(t, err)
+		assert.True(t, time.Time(found.EventStartDateTime).IsZero())
+		assert.True(t, time.Time(found.EventEndDateTime).IsZero())
+		assert.True(t, time.Time(found.EventClosingDateTime).IsZero())
+	})
 }
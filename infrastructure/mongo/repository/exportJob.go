@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ExportJobRepo struct {
+	jobCollection *mongo.Collection
+}
+
+func NewExportJobRepository(db *mongo.Database) repo.ExportJobRepository {
+	return &ExportJobRepo{
+		jobCollection: db.Collection("export_jobs"),
+	}
+}
+
+func (jr *ExportJobRepo) CreateJob(job entity.ExportJob) (*entity.ExportJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 常に新しいObjectIDを生成して文字列に変換し、JobIDにセットする
+	job.JobID = entity.ExportJobID(primitive.NewObjectID().Hex())
+
+	_, err := jr.jobCollection.InsertOne(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("error creating export job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (jr *ExportJobRepo) FindJobByJobID(jobID entity.ExportJobID) (*entity.ExportJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var job entity.ExportJob
+	err := jr.jobCollection.FindOne(ctx, bson.M{"_id": jobID}).Decode(&job)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("export job not found with ID: %s", string(jobID))
+		}
+		return nil, fmt.Errorf("error finding export job by ID: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (jr *ExportJobRepo) FindLatestJobByUserID(userID entity.UserID) (*entity.ExportJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var job entity.ExportJob
+	err := jr.jobCollection.FindOne(ctx, bson.M{"user_id": userID}, opts).Decode(&job)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding export job by user ID: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (jr *ExportJobRepo) FindJobsByStatus(status entity.ExportJobStatus) ([]entity.ExportJob, error) {
+	return jr.find(bson.M{"status": status})
+}
+
+func (jr *ExportJobRepo) ClaimJob(jobID entity.ExportJobID, startedAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": jobID, "status": entity.ExportJobPending}
+	update := bson.M{"$set": bson.M{"status": entity.ExportJobRunning, "started_at": startedAt}}
+
+	result, err := jr.jobCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("error claiming export job: %w", err)
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (jr *ExportJobRepo) CompleteJob(jobID entity.ExportJobID, blobKey string, size int64, completedAt time.Time, expiresAt time.Time) error {
+	return jr.update(jobID, bson.M{
+		"status":       entity.ExportJobCompleted,
+		"blob_key":     blobKey,
+		"size":         size,
+		"completed_at": completedAt,
+		"expires_at":   expiresAt,
+	})
+}
+
+func (jr *ExportJobRepo) FailJob(jobID entity.ExportJobID, message string) error {
+	return jr.update(jobID, bson.M{"status": entity.ExportJobFailed, "error": message})
+}
+
+func (jr *ExportJobRepo) FindExpiredJobs(now time.Time) ([]entity.ExportJob, error) {
+	return jr.find(bson.M{"status": entity.ExportJobCompleted, "expires_at": bson.M{"$lte": now}})
+}
+
+func (jr *ExportJobRepo) MarkJobExpired(jobID entity.ExportJobID) error {
+	return jr.update(jobID, bson.M{"status": entity.ExportJobExpired})
+}
+
+func (jr *ExportJobRepo) update(jobID entity.ExportJobID, fields bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := jr.jobCollection.UpdateOne(ctx, bson.M{"_id": jobID}, bson.M{"$set": fields})
+	if err != nil {
+		return fmt.Errorf("error updating export job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("export job not found with ID: %s", string(jobID))
+	}
+
+	return nil
+}
+
+func (jr *ExportJobRepo) find(filter bson.M) ([]entity.ExportJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := jr.jobCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding export jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []entity.ExportJob{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("error decoding export jobs: %w", err)
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportJobRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewExportJobRepository(db)
+
+	baseTime := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	var job *entity.ExportJob
+
+	t.Run("CreateJob", func(t *testing.T) {
+		var err error
+		_, err = repo.CreateJob(entity.ExportJob{UserID: "export-user-id", Status: entity.ExportJobFailed, DownloadToken: "old-token", CreatedAt: baseTime})
+		assert.NoError(t, err)
+		job, err = repo.CreateJob(entity.ExportJob{UserID: "export-user-id", Status: entity.ExportJobPending, DownloadToken: "token", CreatedAt: baseTime.Add(time.Hour)})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, job.JobID)
+
+		found, err := repo.FindJobByJobID(job.JobID)
+		assert.NoError(t, err)
+		assert.Equal(t, "token", found.DownloadToken)
+
+		latest, err := repo.FindLatestJobByUserID("export-user-id")
+		assert.NoError(t, err)
+		assert.Equal(t, job.JobID, latest.JobID)
+
+		none, err := repo.FindLatestJobByUserID("non-existent-user-id")
+		assert.NoError(t, err)
+		assert.Nil(t, none)
+
+		_, err = repo.FindJobByJobID("non-existent-job-id")
+		assert.Error(t, err)
+	})
+
+	t.Run("ClaimJob", func(t *testing.T) {
+		pending, err := repo.FindJobsByStatus(entity.ExportJobPending)
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+
+		claimed, err := repo.ClaimJob(job.JobID, baseTime.Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+
+		// 2 回目は他のインスタンスが取得した扱いになる
+		claimed, err = repo.ClaimJob(job.JobID, baseTime.Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+
+		found, err := repo.FindJobByJobID(job.JobID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ExportJobRunning, found.Status)
+		assert.NotNil(t, found.StartedAt)
+	})
+
+	t.Run("CompleteJob and expiry", func(t *testing.T) {
+		completedAt := baseTime.Add(3 * time.Hour)
+		expiresAt := completedAt.Add(24 * time.Hour)
+		err := repo.CompleteJob(job.JobID, "exports/export-user-id/archive.zip", 1024, completedAt, expiresAt)
+		assert.NoError(t, err)
+
+		found, err := repo.FindJobByJobID(job.JobID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ExportJobCompleted, found.Status)
+		assert.Equal(t, int64(1024), found.Size)
+		assert.True(t, found.IsDownloadable(completedAt))
+		assert.False(t, found.IsDownloadable(expiresAt))
+
+		expired, err := repo.FindExpiredJobs(expiresAt.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, expired)
+
+		expired, err = repo.FindExpiredJobs(expiresAt)
+		assert.NoError(t, err)
+		assert.Len(t, expired, 1)
+
+		assert.NoError(t, repo.MarkJobExpired(job.JobID))
+		expired, err = repo.FindExpiredJobs(expiresAt)
+		assert.NoError(t, err)
+		assert.Empty(t, expired)
+	})
+
+	t.Run("FailJob", func(t *testing.T) {
+		failing, err := repo.CreateJob(entity.ExportJob{UserID: "failing-user-id", Status: entity.ExportJobRunning, CreatedAt: baseTime})
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.FailJob(failing.JobID, "boom"))
+		found, err := repo.FindJobByJobID(failing.JobID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ExportJobFailed, found.Status)
+		assert.Equal(t, "boom", found.Error)
+
+		assert.Error(t, repo.FailJob("non-existent-job-id", "boom"))
+	})
+}
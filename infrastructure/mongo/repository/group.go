@@ -69,6 +69,23 @@ func (gr *GroupRepo) FindGroupsByUserID(userID entity.UserID) ([]*entity.Group,
 	return groups, nil
 }
 
+func (gr *GroupRepo) FindGroupByEventID(eventID entity.EventID) (*entity.Group, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var group entity.Group
+	filter := bson.M{"events": eventID}
+	err := gr.groupCollection.FindOne(ctx, filter).Decode(&group)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("group not found with event ID: %s", string(eventID))
+		}
+		return nil, fmt.Errorf("error finding group by event ID: %w", err)
+	}
+
+	return &group, nil
+}
+
 func (gr *GroupRepo) CreateGroup(group entity.Group) (*entity.Group, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -77,6 +94,9 @@ func (gr *GroupRepo) CreateGroup(group entity.Group) (*entity.Group, error) {
 	group.GroupID = entity.GroupID(primitive.NewObjectID().Hex())
 
 	_, err := gr.groupCollection.InsertOne(ctx, group)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, repo.ErrDuplicateGroupName
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error creating group: %w", err)
 	}
@@ -88,7 +108,7 @@ func (gr *GroupRepo) UpdateGroup(group entity.Group) (*entity.Group, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"group_id": group.GroupID}
+	filter := bson.M{"_id": group.GroupID}
 	update := bson.M{"$set": group}
 
 	_, err := gr.groupCollection.UpdateOne(ctx, filter, update)
@@ -103,7 +123,7 @@ func (gr *GroupRepo) DeleteGroup(group entity.Group) (*entity.Group, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"group_id": group.GroupID}
+	filter := bson.M{"_id": group.GroupID}
 
 	_, err := gr.groupCollection.DeleteOne(ctx, filter)
 	if err != nil {
@@ -118,7 +138,7 @@ func (gr *GroupRepo) FindGroupByGroupID(groupID entity.GroupID) (*entity.Group,
 	defer cancel()
 
 	var group entity.Group
-	filter := bson.M{"group_id": groupID}
+	filter := bson.M{"_id": groupID}
 	err := gr.groupCollection.FindOne(ctx, filter).Decode(&group)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -129,3 +149,76 @@ func (gr *GroupRepo) FindGroupByGroupID(groupID entity.GroupID) (*entity.Group,
 
 	return &group, nil
 }
+
+func (gr *GroupRepo) UpdateScoringRule(groupID entity.GroupID, rule entity.ScoringRule) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": groupID}
+	update := bson.M{"$set": bson.M{"scoring_rule": rule}}
+
+	result, err := gr.groupCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error updating scoring rule: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("group not found with ID: %s", string(groupID))
+	}
+
+	return nil
+}
+
+func (gr *GroupRepo) AddGroupEvents(groupID entity.GroupID, eventIDs []entity.EventID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": groupID}
+	update := bson.M{"$addToSet": bson.M{"events": bson.M{"$each": eventIDs}}}
+
+	result, err := gr.groupCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error adding group events: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("group not found with ID: %s", string(groupID))
+	}
+
+	return nil
+}
+
+func (gr *GroupRepo) RemoveMemberFromGroups(userID entity.UserID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"members": userID}
+	update := bson.M{"$pull": bson.M{"members": userID}}
+
+	result, err := gr.groupCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("error removing group member: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (gr *GroupRepo) TransferGroupManager(groupID entity.GroupID, from entity.UserID, to entity.UserID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": groupID, "manager_id": from}
+	update := bson.M{"$set": bson.M{"manager_id": to}}
+
+	result, err := gr.groupCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error transferring group manager: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("group not found with ID %s and manager %s", string(groupID), string(from))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AggregateMemberStats はグループのイベントを展開し、投票したメンバーごとに成績を集計する
+// 結果が確定していないイベントは到着の確認が終わっていない可能性があるため含めない
+func (gr *GroupRepo) AggregateMemberStats(groupID entity.GroupID, from time.Time, to time.Time, now time.Time) ([]entity.UserEventStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	eventFilter := bson.M{"finalized_at": bson.M{"$exists": true}}
+	period := bson.M{}
+	if !from.IsZero() {
+		period["$gte"] = from
+	}
+	if !to.IsZero() {
+		period["$lt"] = to
+	}
+	if len(period) > 0 {
+		eventFilter["event_start_date_time"] = period
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": groupID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "events",
+			"localField":   "events",
+			"foreignField": "_id",
+			"as":           "event",
+		}}},
+		{{Key: "$unwind", Value: "$event"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$event"}}},
+		{{Key: "$match", Value: eventFilter}},
+		{{Key: "$unwind", Value: "$voted_members"}},
+	}
+	pipeline = append(pipeline, votedMemberOutcomeStages(now)...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "event_start_date_time", Value: 1}}}},
+		bson.D{{Key: "$group", Value: userStatsAccumulators("$voted_members.user_id")}},
+	)
+
+	cursor, err := gr.groupCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating member stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var documents []userStatsDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("error decoding member stats: %w", err)
+	}
+
+	stats := make([]entity.UserEventStats, 0, len(documents))
+	for _, document := range documents {
+		memberStats := document.toEntity()
+		memberStats.UserID = entity.UserID(document.ID)
+		memberStats.GroupID = groupID
+		stats = append(stats, memberStats)
+	}
+
+	return stats, nil
+}
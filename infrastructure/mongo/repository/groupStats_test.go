@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupStats(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewGroupRepository(db)
+
+	now := time.Now().Truncate(time.Millisecond)
+	base := now.AddDate(0, 0, -20)
+
+	newEvent := func(eventID entity.EventID, start time.Time, finalized bool, members ...entity.VotedMember) entity.Event {
+		event := entity.Event{
+			EventID:            eventID,
+			EventTitle:         "Group Stats Event",
+			EventAuthorID:      "group-stats-author-id",
+			EventStartDateTime: entity.StartDateTIme(start),
+			EventEndDateTime:   entity.EndDateTime(start.Add(2 * time.Hour)),
+			VotedMembers:       members,
+		}
+		if finalized {
+			finalizedAt := start.Add(3 * time.Hour)
+			event.FinalizedAt = &finalizedAt
+		}
+		return event
+	}
+	arrived := func(userID entity.UserID, at time.Time) entity.VotedMember {
+		return entity.VotedMember{UserID: userID, Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: at}
+	}
+
+	events := []entity.Event{
+		newEvent("group-stats-event-1", base, true,
+			arrived("group-stats-user-1", base.Add(15*time.Minute)),
+			arrived("group-stats-user-2", base.Add(-time.Minute)),
+		),
+		newEvent("group-stats-event-2", base.AddDate(0, 0, 7), true,
+			arrived("group-stats-user-1", base.AddDate(0, 0, 7).Add(5*time.Minute)),
+			entity.VotedMember{UserID: "group-stats-user-2", Vote: entity.VoteAttend},
+		),
+		// 確定していないイベントは集計しない
+		newEvent("group-stats-event-3", base.AddDate(0, 0, 14), false,
+			arrived("group-stats-user-1", base.AddDate(0, 0, 14).Add(60*time.Minute)),
+		),
+		// 別グループのイベント
+		newEvent("group-stats-event-4", base, true,
+			arrived("group-stats-user-1", base.Add(60*time.Minute)),
+		),
+	}
+	for _, event := range events {
+		_, err := db.Collection("events").InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+	}
+
+	group := entity.Group{
+		GroupID:     "group-stats-group-id",
+		GroupName:   "GroupStats",
+		GroupEvents: []entity.EventID{"group-stats-event-1", "group-stats-event-2", "group-stats-event-3"},
+	}
+	_, err := db.Collection("groups").InsertOne(context.Background(), group)
+	assert.NoError(t, err)
+
+	t.Run("AggregateMemberStats", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			from     time.Time
+			to       time.Time
+			expected map[entity.UserID][2]int // 遅刻の合計時間、欠席数
+		}{
+			{
+				name: "正常系: 期間を指定しない",
+				expected: map[entity.UserID][2]int{
+					"group-stats-user-1": {20, 0},
+					"group-stats-user-2": {0, 1},
+				},
+			},
+			{
+				name: "正常系: 期間を指定する",
+				from: base.AddDate(0, 0, 1),
+				to:   now,
+				expected: map[entity.UserID][2]int{
+					"group-stats-user-1": {5, 0},
+					"group-stats-user-2": {0, 1},
+				},
+			},
+			{
+				name:     "正常系: 期間内にイベントがない",
+				from:     now,
+				expected: map[entity.UserID][2]int{},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テスト実行
+				stats, err := repo.AggregateMemberStats(group.GroupID, tc.from, tc.to, now)
+
+				// 結果の検証
+				assert.NoError(t, err)
+				assert.Len(t, stats, len(tc.expected))
+				for _, memberStats := range stats {
+					expected, ok := tc.expected[memberStats.UserID]
+					assert.True(t, ok)
+					assert.Equal(t, group.GroupID, memberStats.GroupID)
+					assert.Equal(t, expected[0], memberStats.TotalLateMinutes)
+					assert.Equal(t, expected[1], memberStats.NoShowCount)
+				}
+			})
+		}
+	})
+}
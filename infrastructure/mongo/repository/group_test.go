@@ -2,6 +2,8 @@ package repository_test
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"chikokulympic-api/domain/entity"
@@ -125,14 +127,14 @@ func TestGroupRepository(t *testing.T) {
 
 					// DBに保存されていることを確認
 					var savedGroup entity.Group
-					err = db.Collection("groups").FindOne(context.Background(), bson.M{"group_id": createdGroup.GroupID}).Decode(&savedGroup)
+					err = db.Collection("groups").FindOne(context.Background(), bson.M{"_id": createdGroup.GroupID}).Decode(&savedGroup)
 					assert.NoError(t, err)
 					assert.Equal(t, tc.group.GroupName, savedGroup.GroupName)
 					assert.Equal(t, tc.group.GroupDescription, savedGroup.GroupDescription)
 				}
 
 				// クリーンアップ
-				_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"group_id": createdGroup.GroupID})
+				_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"_id": createdGroup.GroupID})
 				assert.NoError(t, err)
 			})
 		}
@@ -190,14 +192,14 @@ func TestGroupRepository(t *testing.T) {
 
 					// DBから直接取得して確認
 					var savedGroup entity.Group
-					err = db.Collection("groups").FindOne(context.Background(), bson.M{"group_id": tc.initialGroup.GroupID}).Decode(&savedGroup)
+					err = db.Collection("groups").FindOne(context.Background(), bson.M{"_id": tc.initialGroup.GroupID}).Decode(&savedGroup)
 					assert.NoError(t, err)
 					assert.Equal(t, tc.updatedGroup.GroupDescription, savedGroup.GroupDescription)
 					assert.Equal(t, len(tc.updatedGroup.GroupMembers), len(savedGroup.GroupMembers))
 				}
 
 				// クリーンアップ
-				_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"group_id": tc.initialGroup.GroupID})
+				_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"_id": tc.initialGroup.GroupID})
 				assert.NoError(t, err)
 			})
 		}
@@ -244,7 +246,7 @@ func TestGroupRepository(t *testing.T) {
 
 					// DBから削除されたことを確認
 					var count int64
-					count, err = db.Collection("groups").CountDocuments(context.Background(), bson.M{"group_id": tc.group.GroupID})
+					count, err = db.Collection("groups").CountDocuments(context.Background(), bson.M{"_id": tc.group.GroupID})
 					assert.NoError(t, err)
 					assert.Equal(t, int64(0), count)
 				}
@@ -360,7 +362,7 @@ func TestGroupRepository(t *testing.T) {
 		}
 
 		// クリーンアップ
-		_, err := db.Collection("groups").DeleteMany(context.Background(), bson.M{"group_id": bson.M{"$in": []string{"multi-group-id-1", "multi-group-id-2", "multi-group-id-3"}}})
+		_, err := db.Collection("groups").DeleteMany(context.Background(), bson.M{"_id": bson.M{"$in": []string{"multi-group-id-1", "multi-group-id-2", "multi-group-id-3"}}})
 		assert.NoError(t, err)
 	})
 
@@ -423,10 +425,188 @@ func TestGroupRepository(t *testing.T) {
 
 				// クリーンアップ
 				if tc.group != nil {
-					_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"group_id": tc.group.GroupID})
+					_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"_id": tc.group.GroupID})
 					assert.NoError(t, err)
 				}
 			})
 		}
 	})
+
+	t.Run("FindGroupByEventID", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			group       *entity.Group
+			eventID     entity.EventID
+			expectedID  entity.GroupID
+			shouldError bool
+		}{
+			{
+				name: "正常系: イベントを持つグループを検索",
+				group: &entity.Group{
+					GroupID:          "test-group-id-for-event-search",
+					GroupName:        "TestGroupEventSearch",
+					GroupPassword:    "password123",
+					GroupManagerID:   "manager-user-id-1",
+					GroupDescription: "Test group description for event search",
+					GroupMembers:     []entity.UserID{"member1-id"},
+					GroupEvents:      []entity.EventID{"search-event-id-1", "search-event-id-2"},
+				},
+				eventID:     "search-event-id-2",
+				expectedID:  "test-group-id-for-event-search",
+				shouldError: false,
+			},
+			{
+				name:        "異常系: どのグループにも属さないイベント",
+				group:       nil,
+				eventID:     "orphan-event-id",
+				shouldError: true,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テストデータのセットアップ
+				if tc.group != nil {
+					_, err := db.Collection("groups").InsertOne(context.Background(), tc.group)
+					assert.NoError(t, err)
+				}
+
+				// テスト実行
+				foundGroup, err := repo.FindGroupByEventID(tc.eventID)
+
+				// 結果の検証
+				if tc.shouldError {
+					assert.Error(t, err)
+					assert.Nil(t, foundGroup)
+				} else {
+					assert.NoError(t, err)
+					assert.NotNil(t, foundGroup)
+					assert.Equal(t, tc.expectedID, foundGroup.GroupID)
+				}
+
+				// クリーンアップ
+				if tc.group != nil {
+					_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"_id": tc.group.GroupID})
+					assert.NoError(t, err)
+				}
+			})
+		}
+	})
+
+	t.Run("UpdateScoringRule", func(t *testing.T) {
+		group := &entity.Group{
+			GroupID:        "scoring-rule-group-id",
+			GroupName:      "ScoringRuleGroup",
+			GroupPassword:  "password123",
+			GroupManagerID: "manager-user-id-1",
+		}
+		_, err := db.Collection("groups").InsertOne(context.Background(), group)
+		assert.NoError(t, err)
+
+		rule := entity.ScoringRule{EarlyBonus: 5, LatePenaltyPerMinute: 2, NoShowPenalty: 50}
+
+		// テスト実行
+		err = repo.UpdateScoringRule(group.GroupID, rule)
+
+		// 結果の検証
+		assert.NoError(t, err)
+
+		var savedGroup entity.Group
+		err = db.Collection("groups").FindOne(context.Background(), bson.M{"_id": group.GroupID}).Decode(&savedGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, rule, savedGroup.Scoring())
+
+		t.Run("異常系: 存在しないグループ", func(t *testing.T) {
+			err := repo.UpdateScoringRule("non-existent-group-id", rule)
+			assert.Error(t, err)
+		})
+
+		// クリーンアップ
+		_, err = db.Collection("groups").DeleteMany(context.Background(), bson.M{"_id": group.GroupID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("AddGroupEvents", func(t *testing.T) {
+		group := &entity.Group{
+			GroupID:     "add-events-group-id",
+			GroupName:   "AddEventsGroup",
+			GroupEvents: []entity.EventID{"existing-event-id"},
+		}
+		_, err := db.Collection("groups").InsertOne(context.Background(), group)
+		assert.NoError(t, err)
+
+		// 同時に追加しても失われない
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				err := repo.AddGroupEvents(group.GroupID, []entity.EventID{entity.EventID(fmt.Sprintf("added-event-id-%d", i))})
+				assert.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+
+		found, err := repo.FindGroupByGroupID(group.GroupID)
+		assert.NoError(t, err)
+		assert.Len(t, found.GroupEvents, 21)
+		assert.Equal(t, entity.EventID("existing-event-id"), found.GroupEvents[0])
+
+		err = repo.AddGroupEvents("non-existent-group-id", []entity.EventID{"added-event-id"})
+		assert.Error(t, err)
+
+		// 既に含まれているイベントは重複して追加されない
+		err = repo.AddGroupEvents(group.GroupID, []entity.EventID{"existing-event-id", "added-event-id-0"})
+		assert.NoError(t, err)
+
+		found, err = repo.FindGroupByGroupID(group.GroupID)
+		assert.NoError(t, err)
+		assert.Len(t, found.GroupEvents, 21)
+	})
+
+	t.Run("RemoveMemberFromGroups", func(t *testing.T) {
+		groups := []interface{}{
+			entity.Group{GroupID: "remove-member-group-1", GroupName: "RemoveMember1", GroupMembers: []entity.UserID{"leaving-user-id", "staying-user-id"}},
+			entity.Group{GroupID: "remove-member-group-2", GroupName: "RemoveMember2", GroupMembers: []entity.UserID{"staying-user-id", "leaving-user-id"}},
+			entity.Group{GroupID: "remove-member-group-3", GroupName: "RemoveMember3", GroupMembers: []entity.UserID{"staying-user-id"}},
+		}
+		_, err := db.Collection("groups").InsertMany(context.Background(), groups)
+		assert.NoError(t, err)
+
+		// テスト実行
+		removed, err := repo.RemoveMemberFromGroups("leaving-user-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), removed)
+
+		for _, groupID := range []entity.GroupID{"remove-member-group-1", "remove-member-group-2", "remove-member-group-3"} {
+			found, err := repo.FindGroupByGroupID(groupID)
+			assert.NoError(t, err)
+			assert.Equal(t, entity.GroupMembers{"staying-user-id"}, found.GroupMembers)
+		}
+
+		removed, err = repo.RemoveMemberFromGroups("leaving-user-id")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), removed)
+	})
+
+	t.Run("TransferGroupManager", func(t *testing.T) {
+		group := entity.Group{GroupID: "transfer-group-id", GroupName: "TransferGroup", GroupManagerID: "old-manager-id", GroupMembers: []entity.UserID{"old-manager-id", "new-manager-id"}}
+		_, err := db.Collection("groups").InsertOne(context.Background(), group)
+		assert.NoError(t, err)
+
+		// テスト実行
+		err = repo.TransferGroupManager(group.GroupID, "old-manager-id", "new-manager-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		found, err := repo.FindGroupByGroupID(group.GroupID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserID("new-manager-id"), found.GroupManagerID)
+
+		// 管理者がすでに変わっている場合は変更しない
+		err = repo.TransferGroupManager(group.GroupID, "old-manager-id", "other-user-id")
+		assert.Error(t, err)
+	})
 }
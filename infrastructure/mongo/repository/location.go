@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LocationRepo はユーザーごとの最新位置のみを保持する
+type LocationRepo struct {
+	locationCollection *mongo.Collection
+}
+
+func NewLocationRepository(db *mongo.Database) repo.LocationRepository {
+	return &LocationRepo{
+		locationCollection: db.Collection("locations"),
+	}
+}
+
+func (lr *LocationRepo) FindLocationByUserID(userID entity.UserID) (*entity.UserLocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var location entity.UserLocation
+	err := lr.locationCollection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&location)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding location by user ID: %w", err)
+	}
+
+	return &location, nil
+}
+
+func (lr *LocationRepo) CreateLocation(location entity.UserLocation) (*entity.UserLocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := lr.locationCollection.InsertOne(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("error creating location: %w", err)
+	}
+
+	return &location, nil
+}
+
+func (lr *LocationRepo) DeleteLocation(location entity.UserLocation) (*entity.UserLocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := lr.locationCollection.DeleteMany(ctx, bson.M{"user_id": location.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("error deleting location: %w", err)
+	}
+
+	return &location, nil
+}
+
+// UpdateLocation は最新位置を上書きする。まだ位置がなければ作成する
+func (lr *LocationRepo) UpdateLocation(location entity.UserLocation) (*entity.UserLocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": location.UserID}
+	update := bson.M{"$set": location}
+
+	_, err := lr.locationCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, fmt.Errorf("error updating location: %w", err)
+	}
+
+	return &location, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const locationHistoryCollectionName = "location_history"
+
+// locationHistoryDocument は時系列コレクションに保存する形式。meta フィールド単位でバケット化される
+type locationHistoryDocument struct {
+	Meta       locationHistoryMeta `bson:"meta"`
+	Latitude   entity.Latitude     `bson:"latitude"`
+	Longitude  entity.Longitude    `bson:"longitude"`
+	RecordedAt time.Time           `bson:"recorded_at"`
+}
+
+type locationHistoryMeta struct {
+	EventID entity.EventID `bson:"event_id"`
+	UserID  entity.UserID  `bson:"user_id"`
+}
+
+type LocationHistoryRepo struct {
+	historyCollection *mongo.Collection
+}
+
+// NewLocationHistoryRepository は位置履歴を時系列コレクションに保存するリポジトリを返す
+// コレクションはマイグレーションで作成され、保持期間を過ぎた履歴は MongoDB が自動的に削除する
+func NewLocationHistoryRepository(db *mongo.Database) repo.LocationHistoryRepository {
+	return &LocationHistoryRepo{
+		historyCollection: db.Collection(locationHistoryCollectionName),
+	}
+}
+
+func (lhr *LocationHistoryRepo) AppendLocation(eventID entity.EventID, location entity.UserLocation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	document := locationHistoryDocument{
+		Meta: locationHistoryMeta{
+			EventID: eventID,
+			UserID:  location.UserID,
+		},
+		Latitude:   location.Latitude,
+		Longitude:  location.Longitude,
+		RecordedAt: location.RecordedAt,
+	}
+
+	_, err := lhr.historyCollection.InsertOne(ctx, document)
+	if err != nil {
+		return fmt.Errorf("error appending location history: %w", err)
+	}
+
+	return nil
+}
+
+func (lhr *LocationHistoryRepo) FindTrail(eventID entity.EventID, userID entity.UserID) ([]entity.LocationTrailPoint, error) {
+	return lhr.find(bson.M{"meta.event_id": eventID, "meta.user_id": userID}, 5*time.Second)
+}
+
+func (lhr *LocationHistoryRepo) FindHistoryByUserID(userID entity.UserID) ([]entity.LocationTrailPoint, error) {
+	return lhr.find(bson.M{"meta.user_id": userID}, 30*time.Second)
+}
+
+func (lhr *LocationHistoryRepo) find(filter bson.M, timeout time.Duration) ([]entity.LocationTrailPoint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "recorded_at", Value: 1}})
+
+	cursor, err := lhr.historyCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding location trail: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var documents []locationHistoryDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("error decoding location trail: %w", err)
+	}
+
+	trail := make([]entity.LocationTrailPoint, 0, len(documents))
+	for _, document := range documents {
+		trail = append(trail, entity.LocationTrailPoint{
+			EventID:    document.Meta.EventID,
+			UserID:     document.Meta.UserID,
+			Latitude:   document.Latitude,
+			Longitude:  document.Longitude,
+			RecordedAt: document.RecordedAt,
+		})
+	}
+
+	return trail, nil
+}
+
+func (lhr *LocationHistoryRepo) DeleteHistoryByUserID(userID entity.UserID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 時系列コレクションでは meta フィールドのみを条件にした削除がサポートされている
+	result, err := lhr.historyCollection.DeleteMany(ctx, bson.M{"meta.user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting location history: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
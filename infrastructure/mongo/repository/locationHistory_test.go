@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationHistoryRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewLocationHistoryRepository(db)
+
+	baseTime := time.Now().Add(-10 * time.Minute).Truncate(time.Millisecond)
+
+	t.Run("AppendLocation and FindTrail", func(t *testing.T) {
+		eventID := entity.EventID("trail-event-id")
+		userID := entity.UserID("trail-user-id")
+
+		// 記録順と時刻順を入れ替えても時刻順で返ることを確認する
+		locations := []entity.UserLocation{
+			{UserID: userID, Latitude: 35.002, Longitude: 139.002, RecordedAt: baseTime.Add(2 * time.Minute)},
+			{UserID: userID, Latitude: 35.000, Longitude: 139.000, RecordedAt: baseTime},
+			{UserID: userID, Latitude: 35.001, Longitude: 139.001, RecordedAt: baseTime.Add(1 * time.Minute)},
+			{UserID: "other-trail-user-id", Latitude: 34.0, Longitude: 135.0, RecordedAt: baseTime},
+		}
+		for _, location := range locations {
+			assert.NoError(t, repo.AppendLocation(eventID, location))
+		}
+		assert.NoError(t, repo.AppendLocation("other-trail-event-id", locations[0]))
+
+		// テスト実行
+		trail, err := repo.FindTrail(eventID, userID)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, trail, 3)
+		for i, point := range trail {
+			assert.Equal(t, eventID, point.EventID)
+			assert.Equal(t, userID, point.UserID)
+			assert.True(t, point.RecordedAt.Equal(baseTime.Add(time.Duration(i)*time.Minute)))
+		}
+	})
+
+	t.Run("FindHistoryByUserID", func(t *testing.T) {
+		userID := entity.UserID("history-user-id")
+
+		// イベントをまたいで、記録した時刻の順に返す
+		assert.NoError(t, repo.AppendLocation("history-event-2", entity.UserLocation{UserID: userID, Latitude: 35.1, Longitude: 139.1, RecordedAt: baseTime.Add(time.Hour)}))
+		assert.NoError(t, repo.AppendLocation("history-event-1", entity.UserLocation{UserID: userID, Latitude: 35.0, Longitude: 139.0, RecordedAt: baseTime}))
+		assert.NoError(t, repo.AppendLocation("history-event-1", entity.UserLocation{UserID: "other-history-user-id", Latitude: 35.0, Longitude: 139.0, RecordedAt: baseTime}))
+
+		// テスト実行
+		history, err := repo.FindHistoryByUserID(userID)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		assert.Equal(t, entity.EventID("history-event-1"), history[0].EventID)
+		assert.Equal(t, entity.EventID("history-event-2"), history[1].EventID)
+	})
+
+	t.Run("DeleteHistoryByUserID", func(t *testing.T) {
+		userID := entity.UserID("delete-trail-user-id")
+
+		for i := 0; i < 3; i++ {
+			location := entity.UserLocation{UserID: userID, Latitude: 35.0, Longitude: 139.0, RecordedAt: baseTime.Add(time.Duration(i) * time.Minute)}
+			assert.NoError(t, repo.AppendLocation(entity.EventID("delete-trail-event-id"), location))
+		}
+
+		// テスト実行
+		deleted, err := repo.DeleteHistoryByUserID(userID)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+
+		trail, err := repo.FindTrail("delete-trail-event-id", userID)
+		assert.NoError(t, err)
+		assert.Empty(t, trail)
+	})
+}
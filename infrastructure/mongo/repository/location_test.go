@@ -0,0 +1,164 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestLocationRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewLocationRepository(db)
+
+	t.Run("FindLocationByUserID", func(t *testing.T) {
+		testTime := time.Now().Truncate(time.Millisecond)
+
+		testCases := []struct {
+			name     string
+			location *entity.UserLocation
+			userID   entity.UserID
+			isFound  bool
+		}{
+			{
+				name: "正常系: 位置情報のあるユーザーで検索",
+				location: &entity.UserLocation{
+					UserID:     "location-user-id-1",
+					Latitude:   35.6812,
+					Longitude:  139.7671,
+					RecordedAt: testTime,
+				},
+				userID:  "location-user-id-1",
+				isFound: true,
+			},
+			{
+				name:     "正常系: 位置情報のないユーザーで検索",
+				location: nil,
+				userID:   "no-location-user-id",
+				isFound:  false,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テストデータのセットアップ
+				if tc.location != nil {
+					_, err := db.Collection("locations").InsertOne(context.Background(), tc.location)
+					assert.NoError(t, err)
+				}
+
+				// テスト実行
+				foundLocation, err := repo.FindLocationByUserID(tc.userID)
+
+				// 結果の検証
+				assert.NoError(t, err)
+				if tc.isFound {
+					assert.NotNil(t, foundLocation)
+					assert.Equal(t, tc.location.Latitude, foundLocation.Latitude)
+					assert.Equal(t, tc.location.Longitude, foundLocation.Longitude)
+					assert.True(t, tc.location.RecordedAt.Equal(foundLocation.RecordedAt))
+				} else {
+					assert.Nil(t, foundLocation)
+				}
+
+				// クリーンアップ
+				_, err = db.Collection("locations").DeleteMany(context.Background(), bson.M{"user_id": tc.userID})
+				assert.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("UpdateLocation", func(t *testing.T) {
+		testTime := time.Now().Truncate(time.Millisecond)
+
+		testCases := []struct {
+			name     string
+			initial  *entity.UserLocation
+			location entity.UserLocation
+		}{
+			{
+				name: "正常系: 既存の位置情報を上書き",
+				initial: &entity.UserLocation{
+					UserID:     "update-location-user-id",
+					Latitude:   35.0,
+					Longitude:  139.0,
+					RecordedAt: testTime.Add(-1 * time.Minute),
+				},
+				location: entity.UserLocation{
+					UserID:     "update-location-user-id",
+					Latitude:   35.5,
+					Longitude:  139.5,
+					RecordedAt: testTime,
+				},
+			},
+			{
+				name:    "正常系: 位置情報がなければ作成",
+				initial: nil,
+				location: entity.UserLocation{
+					UserID:     "upsert-location-user-id",
+					Latitude:   34.7,
+					Longitude:  135.5,
+					RecordedAt: testTime,
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テストデータのセットアップ
+				if tc.initial != nil {
+					_, err := db.Collection("locations").InsertOne(context.Background(), tc.initial)
+					assert.NoError(t, err)
+				}
+
+				// テスト実行
+				_, err := repo.UpdateLocation(tc.location)
+				assert.NoError(t, err)
+
+				// 最新位置が 1 件だけ保存されていることを確認
+				count, err := db.Collection("locations").CountDocuments(context.Background(), bson.M{"user_id": tc.location.UserID})
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), count)
+
+				var savedLocation entity.UserLocation
+				err = db.Collection("locations").FindOne(context.Background(), bson.M{"user_id": tc.location.UserID}).Decode(&savedLocation)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.location.Latitude, savedLocation.Latitude)
+				assert.Equal(t, tc.location.Longitude, savedLocation.Longitude)
+
+				// クリーンアップ
+				_, err = db.Collection("locations").DeleteMany(context.Background(), bson.M{"user_id": tc.location.UserID})
+				assert.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("DeleteLocation", func(t *testing.T) {
+		location := entity.UserLocation{
+			UserID:     "delete-location-user-id",
+			Latitude:   35.0,
+			Longitude:  139.0,
+			RecordedAt: time.Now(),
+		}
+
+		_, err := db.Collection("locations").InsertOne(context.Background(), location)
+		assert.NoError(t, err)
+
+		// テスト実行
+		_, err = repo.DeleteLocation(location)
+		assert.NoError(t, err)
+
+		// DBから削除されたことを確認
+		count, err := db.Collection("locations").CountDocuments(context.Background(), bson.M{"user_id": location.UserID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationQueueRepo struct {
+	queueCollection *mongo.Collection
+}
+
+func NewNotificationQueueRepository(db *mongo.Database) repo.NotificationQueueRepository {
+	return &NotificationQueueRepo{
+		queueCollection: db.Collection("notification_queue"),
+	}
+}
+
+func (nr *NotificationQueueRepo) EnqueueNotification(queued entity.QueuedNotification) (*entity.QueuedNotification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	queued.QueuedNotificationID = entity.QueuedNotificationID(primitive.NewObjectID().Hex())
+
+	_, err := nr.queueCollection.InsertOne(ctx, queued)
+	if err != nil {
+		return nil, fmt.Errorf("error enqueueing notification: %w", err)
+	}
+
+	return &queued, nil
+}
+
+func (nr *NotificationQueueRepo) ClaimDueNotification(now time.Time) (*entity.QueuedNotification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.FindOneAndDelete().SetSort(bson.D{{Key: "deliver_at", Value: 1}})
+
+	var queued entity.QueuedNotification
+	err := nr.queueCollection.FindOneAndDelete(ctx, bson.M{"deliver_at": bson.M{"$lte": now}}, opts).Decode(&queued)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming queued notification: %w", err)
+	}
+
+	return &queued, nil
+}
+
+func (nr *NotificationQueueRepo) DeleteQueuedNotificationsByUserID(userID entity.UserID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := nr.queueCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return fmt.Errorf("error deleting queued notifications: %w", err)
+	}
+
+	return nil
+}
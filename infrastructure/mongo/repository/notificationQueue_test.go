@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationQueueRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewNotificationQueueRepository(db)
+
+	baseTime := time.Date(2024, 4, 1, 22, 0, 0, 0, time.UTC)
+
+	enqueue := func(userID entity.UserID, deliverAt time.Time) *entity.QueuedNotification {
+		queued, err := repo.EnqueueNotification(entity.QueuedNotification{
+			UserID: userID,
+			Notification: entity.Notification{
+				Kind:    entity.NotificationNewEvent,
+				GroupID: "queue-group-id",
+				Title:   "新しいイベント",
+				Data:    map[string]string{"event_id": "queue-event-id"},
+			},
+			DeliverAt: deliverAt,
+			CreatedAt: baseTime,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, queued.QueuedNotificationID)
+		return queued
+	}
+
+	later := enqueue("queue-user-1", baseTime.Add(9*time.Hour))
+	sooner := enqueue("queue-user-2", baseTime.Add(8*time.Hour))
+	enqueue("queue-user-1", baseTime.Add(24*time.Hour))
+
+	t.Run("ClaimDueNotification", func(t *testing.T) {
+		// テスト実行
+		first, err := repo.ClaimDueNotification(baseTime.Add(9 * time.Hour))
+
+		// 結果の検証: 送る時刻の早いものから取り出す
+		assert.NoError(t, err)
+		assert.Equal(t, sooner.QueuedNotificationID, first.QueuedNotificationID)
+
+		second, err := repo.ClaimDueNotification(baseTime.Add(9 * time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, later.QueuedNotificationID, second.QueuedNotificationID)
+		assert.Equal(t, entity.GroupID("queue-group-id"), second.Notification.GroupID)
+		assert.Equal(t, "queue-event-id", second.Notification.Data["event_id"])
+
+		// 取り出した通知は残らず、時刻が来ていない通知は取り出さない
+		none, err := repo.ClaimDueNotification(baseTime.Add(9 * time.Hour))
+		assert.NoError(t, err)
+		assert.Nil(t, none)
+	})
+
+	t.Run("ClaimDueNotification_Concurrent", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			enqueue(entity.UserID(fmt.Sprintf("queue-concurrent-user-%d", i)), baseTime.Add(time.Hour))
+		}
+
+		// 同時に取り出しても、同じ通知を二度取り出さない
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			claimed = map[entity.QueuedNotificationID]int{}
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					queued, err := repo.ClaimDueNotification(baseTime.Add(2 * time.Hour))
+					if err != nil || queued == nil {
+						assert.NoError(t, err)
+						return
+					}
+					mu.Lock()
+					claimed[queued.QueuedNotificationID]++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, claimed, 10)
+		for _, count := range claimed {
+			assert.Equal(t, 1, count)
+		}
+	})
+
+	t.Run("DeleteQueuedNotificationsByUserID", func(t *testing.T) {
+		err := repo.DeleteQueuedNotificationsByUserID("queue-user-1")
+		assert.NoError(t, err)
+
+		queued, err := repo.ClaimDueNotification(baseTime.Add(48 * time.Hour))
+		assert.NoError(t, err)
+		assert.Nil(t, queued)
+	})
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationSettingsRepo struct {
+	settingsCollection *mongo.Collection
+}
+
+func NewNotificationSettingsRepository(db *mongo.Database) repo.NotificationSettingsRepository {
+	return &NotificationSettingsRepo{
+		settingsCollection: db.Collection("notification_settings"),
+	}
+}
+
+func (nr *NotificationSettingsRepo) FindSettingsByUserID(userID entity.UserID) (*entity.NotificationSettings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var settings entity.NotificationSettings
+	err := nr.settingsCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&settings)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding notification settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// SaveSettings は設定を丸ごと置き換える
+func (nr *NotificationSettingsRepo) SaveSettings(settings entity.NotificationSettings) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": settings.UserID}
+	_, err := nr.settingsCollection.ReplaceOne(ctx, filter, settings, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("error saving notification settings: %w", err)
+	}
+
+	return nil
+}
+
+func (nr *NotificationSettingsRepo) DeleteSettingsByUserID(userID entity.UserID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := nr.settingsCollection.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return fmt.Errorf("error deleting notification settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationSettingsRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewNotificationSettingsRepository(db)
+
+	baseTime := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("SaveSettings", func(t *testing.T) {
+		found, err := repo.FindSettingsByUserID("settings-user-id")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		err = repo.SaveSettings(entity.NotificationSettings{
+			UserID: "settings-user-id",
+			Kinds:  map[entity.NotificationKind]bool{entity.NotificationMemberArrived: false},
+			Groups: []entity.GroupNotificationSettings{
+				{GroupID: "noisy-group-id", Muted: true},
+			},
+			QuietHours: &entity.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Asia/Tokyo"},
+			UpdatedAt:  baseTime,
+		})
+		assert.NoError(t, err)
+
+		found, err = repo.FindSettingsByUserID("settings-user-id")
+		assert.NoError(t, err)
+		assert.NotNil(t, found)
+		assert.False(t, found.Kinds[entity.NotificationMemberArrived])
+		assert.Len(t, found.Groups, 1)
+		assert.True(t, found.Groups[0].Muted)
+		assert.Equal(t, "22:00", found.QuietHours.Start)
+
+		// 保存し直すと丸ごと置き換わる
+		err = repo.SaveSettings(entity.NotificationSettings{
+			UserID:    "settings-user-id",
+			Kinds:     map[entity.NotificationKind]bool{entity.NotificationNewEvent: false},
+			Groups:    []entity.GroupNotificationSettings{},
+			UpdatedAt: baseTime.Add(time.Hour),
+		})
+		assert.NoError(t, err)
+
+		found, err = repo.FindSettingsByUserID("settings-user-id")
+		assert.NoError(t, err)
+		assert.Len(t, found.Kinds, 1)
+		assert.Empty(t, found.Groups)
+		assert.Nil(t, found.QuietHours)
+	})
+
+	t.Run("DeleteSettingsByUserID", func(t *testing.T) {
+		err := repo.DeleteSettingsByUserID("settings-user-id")
+		assert.NoError(t, err)
+
+		found, err := repo.FindSettingsByUserID("settings-user-id")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		// 設定していないユーザーでも失敗しない
+		err = repo.DeleteSettingsByUserID("non-existent-user-id")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScoreRepo struct {
+	scoreCollection *mongo.Collection
+}
+
+func NewScoreRepository(db *mongo.Database) repo.ScoreRepository {
+	return &ScoreRepo{
+		scoreCollection: db.Collection("scores"),
+	}
+}
+
+// SaveScores はイベントと参加者の組ごとにスコアを上書き保存する
+func (sr *ScoreRepo) SaveScores(scores []entity.Score) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	models := make([]mongo.WriteModel, 0, len(scores))
+	for _, score := range scores {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": score.ScoreID}).
+			SetReplacement(score).
+			SetUpsert(true))
+	}
+
+	_, err := sr.scoreCollection.BulkWrite(ctx, models)
+	if err != nil {
+		return fmt.Errorf("error saving scores: %w", err)
+	}
+
+	return nil
+}
+
+func (sr *ScoreRepo) FindScoresByGroupID(groupID entity.GroupID, from time.Time, to time.Time) ([]entity.Score, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"group_id": groupID}
+	period := bson.M{}
+	if !from.IsZero() {
+		period["$gte"] = from
+	}
+	if !to.IsZero() {
+		period["$lt"] = to
+	}
+	if len(period) > 0 {
+		filter["event_start_date_time"] = period
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "event_start_date_time", Value: 1}})
+
+	cursor, err := sr.scoreCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding scores by group ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	scores := []entity.Score{}
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, fmt.Errorf("error decoding scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+func (sr *ScoreRepo) ReassignUser(userID entity.UserID, anonymousID entity.UserID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := sr.scoreCollection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("error finding scores by user ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	scores := []entity.Score{}
+	if err := cursor.All(ctx, &scores); err != nil {
+		return 0, fmt.Errorf("error decoding scores: %w", err)
+	}
+	if len(scores) == 0 {
+		return 0, nil
+	}
+
+	// ID はイベントと参加者の組から決まるため、置き換えた ID で保存し直してから元のスコアを消す
+	models := make([]mongo.WriteModel, 0, len(scores)*2)
+	for _, score := range scores {
+		oldID := score.ScoreID
+		score.ScoreID = entity.NewScoreID(score.EventID, anonymousID)
+		score.UserID = anonymousID
+		models = append(models,
+			mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": score.ScoreID}).SetReplacement(score).SetUpsert(true),
+			mongo.NewDeleteOneModel().SetFilter(bson.M{"_id": oldID}),
+		)
+	}
+
+	if _, err := sr.scoreCollection.BulkWrite(ctx, models); err != nil {
+		return 0, fmt.Errorf("error reassigning scores: %w", err)
+	}
+
+	return int64(len(scores)), nil
+}
+
+func (sr *ScoreRepo) FindScoresBySeasonID(seasonID entity.SeasonID) ([]entity.Score, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "event_start_date_time", Value: 1}})
+
+	cursor, err := sr.scoreCollection.Find(ctx, bson.M{"season_id": seasonID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding scores by season ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	scores := []entity.Score{}
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, fmt.Errorf("error decoding scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+func (sr *ScoreRepo) AssignSeason(groupID entity.GroupID, seasonID entity.SeasonID, from time.Time, to time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"group_id":              groupID,
+		"event_start_date_time": bson.M{"$gte": from, "$lt": to},
+		"season_id":             bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"season_id": seasonID}}
+
+	result, err := sr.scoreCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("error assigning season to scores: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,177 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewScoreRepository(db)
+
+	baseTime := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	groupID := entity.GroupID("score-group-id")
+
+	newScore := func(eventID entity.EventID, userID entity.UserID, points entity.Points, start time.Time) entity.Score {
+		return entity.Score{
+			ScoreID:            entity.NewScoreID(eventID, userID),
+			GroupID:            groupID,
+			EventID:            eventID,
+			UserID:             userID,
+			Outcome:            entity.ScoreOutcomeOnTime,
+			Points:             points,
+			EventStartDateTime: start,
+			FinalizedAt:        start.Add(2 * time.Hour),
+		}
+	}
+
+	t.Run("SaveScores", func(t *testing.T) {
+		scores := []entity.Score{
+			newScore("score-event-1", "score-user-1", 10, baseTime),
+			newScore("score-event-1", "score-user-2", -5, baseTime),
+		}
+		assert.NoError(t, repo.SaveScores(scores))
+
+		// 同じイベントを再計算したときは上書きされることを確認する
+		scores[1].Points = 3
+		assert.NoError(t, repo.SaveScores(scores))
+
+		found, err := repo.FindScoresByGroupID(groupID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		assert.Len(t, found, 2)
+		for _, score := range found {
+			if score.UserID == "score-user-2" {
+				assert.Equal(t, entity.Points(3), score.Points)
+			}
+		}
+
+		assert.NoError(t, repo.SaveScores(nil))
+	})
+
+	t.Run("FindScoresByGroupID", func(t *testing.T) {
+		scores := []entity.Score{
+			newScore("score-event-2", "score-user-1", 10, baseTime.AddDate(0, 1, 0)),
+			newScore("score-event-3", "score-user-1", 10, baseTime.AddDate(1, 0, 0)),
+		}
+		other := newScore("score-event-4", "score-user-1", 10, baseTime)
+		other.GroupID = "other-score-group-id"
+		assert.NoError(t, repo.SaveScores(append(scores, other)))
+
+		testCases := []struct {
+			name     string
+			from     time.Time
+			to       time.Time
+			expected int
+		}{
+			{
+				name:     "正常系: 期間を指定しない",
+				expected: 4,
+			},
+			{
+				name:     "正常系: 期間を指定する",
+				from:     baseTime,
+				to:       baseTime.AddDate(1, 0, 0),
+				expected: 3,
+			},
+			{
+				name:     "正常系: 開始のみ指定する",
+				from:     baseTime.AddDate(0, 6, 0),
+				expected: 1,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テスト実行
+				found, err := repo.FindScoresByGroupID(groupID, tc.from, tc.to)
+
+				// 結果の検証
+				assert.NoError(t, err)
+				assert.Len(t, found, tc.expected)
+				for i := 1; i < len(found); i++ {
+					assert.False(t, found[i].EventStartDateTime.Before(found[i-1].EventStartDateTime))
+				}
+			})
+		}
+	})
+
+	t.Run("FindScoresBySeasonID", func(t *testing.T) {
+		inSeason := newScore("season-score-event-1", "score-user-1", 10, baseTime)
+		inSeason.SeasonID = "score-season-id"
+		otherSeason := newScore("season-score-event-2", "score-user-1", 10, baseTime)
+		otherSeason.SeasonID = "other-score-season-id"
+		assert.NoError(t, repo.SaveScores([]entity.Score{inSeason, otherSeason}))
+
+		// テスト実行
+		found, err := repo.FindScoresBySeasonID("score-season-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+		assert.Equal(t, inSeason.ScoreID, found[0].ScoreID)
+	})
+
+	t.Run("AssignSeason", func(t *testing.T) {
+		assignGroupID := entity.GroupID("assign-score-group-id")
+		inRange := newScore("assign-score-event-1", "score-user-1", 10, baseTime)
+		inRange.GroupID = assignGroupID
+		outOfRange := newScore("assign-score-event-2", "score-user-1", 10, baseTime.AddDate(0, 1, 0))
+		outOfRange.GroupID = assignGroupID
+		assigned := newScore("assign-score-event-3", "score-user-1", 10, baseTime)
+		assigned.GroupID = assignGroupID
+		assigned.SeasonID = "previous-season-id"
+		otherGroup := newScore("assign-score-event-4", "score-user-1", 10, baseTime)
+		otherGroup.GroupID = "other-assign-score-group-id"
+		assert.NoError(t, repo.SaveScores([]entity.Score{inRange, outOfRange, assigned, otherGroup}))
+
+		// テスト実行
+		modified, err := repo.AssignSeason(assignGroupID, "assign-season-id", baseTime.AddDate(0, 0, -1), baseTime.AddDate(0, 0, 1))
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), modified)
+		found, err := repo.FindScoresBySeasonID("assign-season-id")
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+		assert.Equal(t, inRange.ScoreID, found[0].ScoreID)
+	})
+
+	t.Run("ReassignUser", func(t *testing.T) {
+		err := repo.SaveScores([]entity.Score{
+			newScore("reassign-event-1", "reassign-user-id", 10, baseTime),
+			newScore("reassign-event-2", "reassign-user-id", -3, baseTime.AddDate(0, 0, 7)),
+			newScore("reassign-event-1", "other-user-id", 5, baseTime),
+		})
+		assert.NoError(t, err)
+
+		// テスト実行
+		reassigned, err := repo.ReassignUser("reassign-user-id", "deleted-anonymous-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), reassigned)
+
+		found, err := repo.FindScoresByGroupID(groupID, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		points := map[entity.ScoreID]entity.Points{}
+		for _, score := range found {
+			assert.NotEqual(t, entity.UserID("reassign-user-id"), score.UserID)
+			points[score.ScoreID] = score.Points
+		}
+		assert.Equal(t, entity.Points(10), points[entity.NewScoreID("reassign-event-1", "deleted-anonymous-id")])
+		assert.Equal(t, entity.Points(-3), points[entity.NewScoreID("reassign-event-2", "deleted-anonymous-id")])
+		assert.Equal(t, entity.Points(5), points[entity.NewScoreID("reassign-event-1", "other-user-id")])
+
+		reassigned, err = repo.ReassignUser("reassign-user-id", "deleted-anonymous-id")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), reassigned)
+	})
+}
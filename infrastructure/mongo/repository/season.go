@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SeasonRepo struct {
+	seasonCollection *mongo.Collection
+}
+
+func NewSeasonRepository(db *mongo.Database) repo.SeasonRepository {
+	return &SeasonRepo{
+		seasonCollection: db.Collection("seasons"),
+	}
+}
+
+func (sr *SeasonRepo) CreateSeason(season entity.Season) (*entity.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 常に新しいObjectIDを生成して文字列に変換し、SeasonIDにセットする
+	season.SeasonID = entity.SeasonID(primitive.NewObjectID().Hex())
+
+	_, err := sr.seasonCollection.InsertOne(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("error creating season: %w", err)
+	}
+
+	return &season, nil
+}
+
+func (sr *SeasonRepo) FindSeasonBySeasonID(seasonID entity.SeasonID) (*entity.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var season entity.Season
+	err := sr.seasonCollection.FindOne(ctx, bson.M{"_id": seasonID}).Decode(&season)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("season not found with ID: %s", string(seasonID))
+		}
+		return nil, fmt.Errorf("error finding season by ID: %w", err)
+	}
+
+	return &season, nil
+}
+
+func (sr *SeasonRepo) FindSeasonsByGroupID(groupID entity.GroupID) ([]entity.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "start_date_time", Value: 1}})
+
+	cursor, err := sr.seasonCollection.Find(ctx, bson.M{"group_id": groupID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding seasons by group ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	seasons := []entity.Season{}
+	if err := cursor.All(ctx, &seasons); err != nil {
+		return nil, fmt.Errorf("error decoding seasons: %w", err)
+	}
+
+	return seasons, nil
+}
+
+func (sr *SeasonRepo) FindSeasonByTime(groupID entity.GroupID, t time.Time) (*entity.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"group_id":        groupID,
+		"start_date_time": bson.M{"$lte": t},
+		"end_date_time":   bson.M{"$gt": t},
+	}
+
+	var season entity.Season
+	err := sr.seasonCollection.FindOne(ctx, filter).Decode(&season)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding season by time: %w", err)
+	}
+
+	return &season, nil
+}
+
+func (sr *SeasonRepo) CloseSeason(seasonID entity.SeasonID, closedAt time.Time, endDateTime time.Time, standings []entity.SeasonStanding) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 締めたシーズンを上書きしないよう、closed_at がないことを条件にする
+	filter := bson.M{"_id": seasonID, "closed_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{
+		"closed_at":       closedAt,
+		"end_date_time":   endDateTime,
+		"final_standings": standings,
+	}}
+
+	result, err := sr.seasonCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error closing season: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("season not found or already closed with ID: %s", string(seasonID))
+	}
+
+	return nil
+}
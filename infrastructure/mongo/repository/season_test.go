@@ -0,0 +1,134 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeasonRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewSeasonRepository(db)
+
+	groupID := entity.GroupID("season-group-id")
+	spring := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	summer := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	autumn := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var springSeason, summerSeason *entity.Season
+
+	t.Run("CreateSeason", func(t *testing.T) {
+		var err error
+		// 作成順と期間の順を入れ替えておく
+		summerSeason, err = repo.CreateSeason(entity.Season{GroupID: groupID, Name: "夏", StartDateTime: summer, EndDateTime: autumn})
+		assert.NoError(t, err)
+		springSeason, err = repo.CreateSeason(entity.Season{GroupID: groupID, Name: "春", StartDateTime: spring, EndDateTime: summer})
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, springSeason.SeasonID)
+		assert.NotEqual(t, springSeason.SeasonID, summerSeason.SeasonID)
+
+		found, err := repo.FindSeasonBySeasonID(springSeason.SeasonID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.SeasonName("春"), found.Name)
+		assert.True(t, found.StartDateTime.Equal(spring))
+	})
+
+	t.Run("FindSeasonBySeasonID not found", func(t *testing.T) {
+		found, err := repo.FindSeasonBySeasonID("non-existent-season-id")
+		assert.Error(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("FindSeasonsByGroupID", func(t *testing.T) {
+		seasons, err := repo.FindSeasonsByGroupID(groupID)
+
+		assert.NoError(t, err)
+		assert.Len(t, seasons, 2)
+		// 開始時刻の順に返る
+		assert.Equal(t, springSeason.SeasonID, seasons[0].SeasonID)
+		assert.Equal(t, summerSeason.SeasonID, seasons[1].SeasonID)
+	})
+
+	t.Run("FindSeasonByTime", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			groupID  entity.GroupID
+			t        time.Time
+			expected *entity.Season
+		}{
+			{
+				name:     "正常系: 期間内",
+				groupID:  groupID,
+				t:        spring.AddDate(0, 1, 0),
+				expected: springSeason,
+			},
+			{
+				name:     "正常系: 終了時刻ちょうどは次のシーズン",
+				groupID:  groupID,
+				t:        summer,
+				expected: summerSeason,
+			},
+			{
+				name:    "正常系: どのシーズンにも含まれない",
+				groupID: groupID,
+				t:       autumn,
+			},
+			{
+				name:    "正常系: 別のグループ",
+				groupID: "other-season-group-id",
+				t:       spring,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テスト実行
+				found, err := repo.FindSeasonByTime(tc.groupID, tc.t)
+
+				// 結果の検証
+				assert.NoError(t, err)
+				if tc.expected == nil {
+					assert.Nil(t, found)
+				} else {
+					assert.NotNil(t, found)
+					assert.Equal(t, tc.expected.SeasonID, found.SeasonID)
+				}
+			})
+		}
+	})
+
+	t.Run("CloseSeason", func(t *testing.T) {
+		closedAt := summer.Add(time.Hour)
+		standings := []entity.SeasonStanding{
+			{Rank: 1, UserID: "season-user-1", UserName: "User 1", Points: 30},
+			{Rank: 2, UserID: "season-user-2", UserName: "User 2", Points: -5},
+		}
+
+		// テスト実行
+		err := repo.CloseSeason(springSeason.SeasonID, closedAt, summer, standings)
+
+		// 結果の検証
+		assert.NoError(t, err)
+
+		found, err := repo.FindSeasonBySeasonID(springSeason.SeasonID)
+		assert.NoError(t, err)
+		assert.True(t, found.IsClosed())
+		assert.Equal(t, standings, found.FinalStandings)
+
+		// 締めたシーズンは上書きできない
+		err = repo.CloseSeason(springSeason.SeasonID, closedAt, summer, nil)
+		assert.Error(t, err)
+
+		found, err = repo.FindSeasonBySeasonID(springSeason.SeasonID)
+		assert.NoError(t, err)
+		assert.Len(t, found.FinalStandings, 2)
+	})
+}
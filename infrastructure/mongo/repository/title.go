@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	repo "chikokulympic-api/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TitleRepo struct {
+	titleCollection *mongo.Collection
+}
+
+func NewTitleRepository(db *mongo.Database) repo.TitleRepository {
+	return &TitleRepo{
+		titleCollection: db.Collection("titles"),
+	}
+}
+
+// SaveTitle は称号を保存する。同じ称号を再度獲得した場合は上書きする
+func (tr *TitleRepo) SaveTitle(title entity.Title) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": title.TitleID}
+	_, err := tr.titleCollection.ReplaceOne(ctx, filter, title, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("error saving title: %w", err)
+	}
+
+	return nil
+}
+
+func (tr *TitleRepo) FindTitlesByGroupID(groupID entity.GroupID) ([]entity.Title, error) {
+	return tr.find(bson.M{"group_id": groupID})
+}
+
+func (tr *TitleRepo) FindTitlesByUserID(userID entity.UserID) ([]entity.Title, error) {
+	return tr.find(bson.M{"user_id": userID})
+}
+
+func (tr *TitleRepo) DeleteTitlesByUserID(userID entity.UserID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := tr.titleCollection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting titles: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (tr *TitleRepo) find(filter bson.M) ([]entity.Title, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "awarded_at", Value: -1}})
+
+	cursor, err := tr.titleCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error finding titles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	titles := []entity.Title{}
+	if err := cursor.All(ctx, &titles); err != nil {
+		return nil, fmt.Errorf("error decoding titles: %w", err)
+	}
+
+	return titles, nil
+}
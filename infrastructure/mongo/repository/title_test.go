@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/infrastructure/mongo/repository"
+	"chikokulympic-api/infrastructure/mongo/repository/testUtils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitleRepository(t *testing.T) {
+	// 各テストで共通のセットアップ処理
+	db, cleanup := testUtils.SetupTestDB(t)
+	defer cleanup()
+	repo := repository.NewTitleRepository(db)
+
+	awardedAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	newTitle := func(groupID entity.GroupID, userID entity.UserID, name entity.Alias, awardedAt time.Time) entity.Title {
+		return entity.Title{
+			TitleID:   entity.NewTitleID(groupID, userID, name),
+			UserID:    userID,
+			GroupID:   groupID,
+			Name:      name,
+			AwardedAt: awardedAt,
+		}
+	}
+
+	t.Run("SaveTitle", func(t *testing.T) {
+		title := newTitle("title-group-id", "title-user-id", "遅刻王", awardedAt)
+		assert.NoError(t, repo.SaveTitle(title))
+
+		// 剥奪した称号を上書きできることを確認する
+		revokedAt := awardedAt.Add(30 * time.Minute)
+		title.RevokedAt = &revokedAt
+		assert.NoError(t, repo.SaveTitle(title))
+
+		titles, err := repo.FindTitlesByUserID("title-user-id")
+		assert.NoError(t, err)
+		assert.Len(t, titles, 1)
+		assert.False(t, titles[0].IsActive())
+		assert.True(t, titles[0].RevokedAt.Equal(revokedAt))
+	})
+
+	t.Run("FindTitlesByGroupID and FindTitlesByUserID", func(t *testing.T) {
+		titles := []entity.Title{
+			newTitle("find-title-group-id", "find-title-user-1", "皆勤賞", awardedAt),
+			newTitle("find-title-group-id", "find-title-user-2", "ドタキャン常習犯", awardedAt.Add(time.Minute)),
+			newTitle("other-title-group-id", "find-title-user-1", "遅刻王", awardedAt.Add(2*time.Minute)),
+		}
+		for _, title := range titles {
+			assert.NoError(t, repo.SaveTitle(title))
+		}
+
+		// テスト実行
+		groupTitles, err := repo.FindTitlesByGroupID("find-title-group-id")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, groupTitles, 2)
+
+		userTitles, err := repo.FindTitlesByUserID("find-title-user-1")
+		assert.NoError(t, err)
+		assert.Len(t, userTitles, 2)
+		// 新しく獲得した順に返る
+		assert.Equal(t, entity.Alias("遅刻王"), userTitles[0].Name)
+
+		empty, err := repo.FindTitlesByUserID("no-title-user-id")
+		assert.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+}
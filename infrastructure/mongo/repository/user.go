@@ -11,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type userRepository struct {
@@ -25,7 +26,7 @@ func NewUserRepository(db *mongo.Database) repo.UserRepository {
 
 func (r *userRepository) FindUserByUserID(userID entity.UserID) (*entity.User, error) {
 	var user entity.User
-	err := r.userCollection.FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&user)
+	err := r.userCollection.FindOne(context.Background(), bson.M{"_id": userID}).Decode(&user)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
@@ -52,6 +53,9 @@ func (r *userRepository) CreateUser(user entity.User) (*entity.User, error) {
 	user.UserID = entity.UserID(primitive.NewObjectID().Hex())
 
 	result, err := r.userCollection.InsertOne(context.Background(), user)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, repo.ErrDuplicateAuthID
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +69,7 @@ func (r *userRepository) CreateUser(user entity.User) (*entity.User, error) {
 
 func (r *userRepository) DeleteUser(user entity.User) (*entity.User, error) {
 	var deletedUser entity.User
-	filter := bson.M{"user_id": user.UserID}
+	filter := bson.M{"_id": user.UserID}
 
 	err := r.userCollection.FindOneAndDelete(context.Background(), filter).Decode(&deletedUser)
 	if err != nil {
@@ -76,7 +80,7 @@ func (r *userRepository) DeleteUser(user entity.User) (*entity.User, error) {
 }
 
 func (r *userRepository) UpdateUser(user entity.User) (*entity.User, error) {
-	filter := bson.M{"user_id": user.UserID}
+	filter := bson.M{"_id": user.UserID}
 	update := bson.M{"$set": user}
 
 	_, err := r.userCollection.UpdateOne(context.Background(), filter, update)
@@ -91,3 +95,123 @@ func (r *userRepository) UpdateUser(user entity.User) (*entity.User, error) {
 
 	return updatedUser, nil
 }
+
+func (r *userRepository) UpdateUserProfile(userID entity.UserID, update entity.UserProfileUpdate) (*entity.User, error) {
+	fields := bson.M{}
+	if update.UserName != nil {
+		fields["user_name"] = *update.UserName
+	}
+	if update.UserIcon != nil {
+		fields["user_icon"] = *update.UserIcon
+	}
+	if update.FCMToken != nil {
+		fields["fcm_token"] = *update.FCMToken
+	}
+	if update.AliasPreference != nil {
+		fields["alias_preference"] = *update.AliasPreference
+	}
+	if update.Alias != nil {
+		fields["alias"] = *update.Alias
+	}
+	if len(fields) == 0 {
+		return r.FindUserByUserID(userID)
+	}
+
+	var user entity.User
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.userCollection.FindOneAndUpdate(context.Background(), bson.M{"_id": userID}, bson.M{"$set": fields}, opts).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) SetLocationSharing(userID entity.UserID, groupID entity.GroupID, enabled bool) error {
+	filter := bson.M{"_id": userID}
+
+	var update bson.M
+	if enabled {
+		update = bson.M{"$pull": bson.M{"location_opt_out_groups": groupID}}
+	} else {
+		update = bson.M{"$addToSet": bson.M{"location_opt_out_groups": groupID}}
+	}
+
+	result, err := r.userCollection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("user not found with ID: %s", string(userID))
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateAlias(userID entity.UserID, alias entity.Alias) error {
+	filter := bson.M{"_id": userID}
+	update := bson.M{"$set": bson.M{"alias": alias}}
+
+	result, err := r.userCollection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("user not found with ID: %s", string(userID))
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetCalendarToken(userID entity.UserID, token entity.CalendarToken) error {
+	filter := bson.M{"_id": userID}
+	update := bson.M{"$set": bson.M{"calendar_token": token}}
+
+	result, err := r.userCollection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("user not found with ID: %s", string(userID))
+	}
+
+	return nil
+}
+
+func (r *userRepository) ReserveAnonymousID(userID entity.UserID, anonymousID entity.UserID) (entity.UserID, error) {
+	filter := bson.M{"_id": userID, "anonymous_id": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"anonymous_id": anonymousID}}
+
+	if _, err := r.userCollection.UpdateOne(context.Background(), filter, update); err != nil {
+		return "", err
+	}
+
+	// 先に保存されていた場合はその ID を使う
+	user, err := r.FindUserByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", fmt.Errorf("user not found with ID: %s", string(userID))
+	}
+
+	return user.AnonymousID, nil
+}
+
+func (r *userRepository) FindUserByCalendarToken(token entity.CalendarToken) (*entity.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	var user entity.User
+	err := r.userCollection.FindOne(context.Background(), bson.M{"calendar_token": token}).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
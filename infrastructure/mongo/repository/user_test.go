@@ -74,7 +74,7 @@ func TestUserRepository(t *testing.T) {
 
 				// クリーンアップ
 				if tc.user != nil {
-					_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"user_id": tc.user.UserID})
+					_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": tc.user.UserID})
 					assert.NoError(t, err)
 				}
 			})
@@ -179,13 +179,13 @@ func TestUserRepository(t *testing.T) {
 
 					// DBに保存されていることを確認
 					var savedUser entity.User
-					err = db.Collection("users").FindOne(context.Background(), bson.M{"user_id": createdUser.UserID}).Decode(&savedUser)
+					err = db.Collection("users").FindOne(context.Background(), bson.M{"_id": createdUser.UserID}).Decode(&savedUser)
 					assert.NoError(t, err)
 					assert.Equal(t, tc.user.UserName, savedUser.UserName)
 				}
 
 				// クリーンアップ
-				_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"user_id": createdUser.UserID})
+				_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": createdUser.UserID})
 				assert.NoError(t, err)
 			})
 		}
@@ -239,14 +239,14 @@ func TestUserRepository(t *testing.T) {
 
 					// DBが更新されたことを確認
 					var savedUser entity.User
-					err = db.Collection("users").FindOne(context.Background(), bson.M{"user_id": tc.initialUser.UserID}).Decode(&savedUser)
+					err = db.Collection("users").FindOne(context.Background(), bson.M{"_id": tc.initialUser.UserID}).Decode(&savedUser)
 					assert.NoError(t, err)
 					assert.Equal(t, tc.updatedUser.UserName, savedUser.UserName)
 					assert.Equal(t, tc.updatedUser.Alias, savedUser.Alias)
 				}
 
 				// クリーンアップ
-				_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"user_id": tc.initialUser.UserID})
+				_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": tc.initialUser.UserID})
 				assert.NoError(t, err)
 			})
 		}
@@ -291,11 +291,227 @@ func TestUserRepository(t *testing.T) {
 
 					// DBから削除されたことを確認
 					var count int64
-					count, err = db.Collection("users").CountDocuments(context.Background(), bson.M{"user_id": tc.user.UserID})
+					count, err = db.Collection("users").CountDocuments(context.Background(), bson.M{"_id": tc.user.UserID})
 					assert.NoError(t, err)
 					assert.Equal(t, int64(0), count)
 				}
 			})
 		}
 	})
+
+	t.Run("SetLocationSharing", func(t *testing.T) {
+		user := &entity.User{
+			UserID:   "location-sharing-user-id",
+			AuthID:   "location-sharing-auth-id",
+			UserName: "Location Sharing User",
+		}
+		_, err := db.Collection("users").InsertOne(context.Background(), user)
+		assert.NoError(t, err)
+
+		testCases := []struct {
+			name     string
+			enabled  bool
+			expected []entity.GroupID
+		}{
+			{
+				name:     "正常系: 共有を停止",
+				enabled:  false,
+				expected: []entity.GroupID{"opt-out-group-id"},
+			},
+			{
+				name:     "正常系: 停止済みのグループを再度停止しても重複しない",
+				enabled:  false,
+				expected: []entity.GroupID{"opt-out-group-id"},
+			},
+			{
+				name:     "正常系: 共有を再開",
+				enabled:  true,
+				expected: nil,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// テスト実行
+				err := repo.SetLocationSharing(user.UserID, "opt-out-group-id", tc.enabled)
+				assert.NoError(t, err)
+
+				// DBが更新されたことを確認
+				var savedUser entity.User
+				err = db.Collection("users").FindOne(context.Background(), bson.M{"_id": user.UserID}).Decode(&savedUser)
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, tc.expected, savedUser.LocationOptOutGroups)
+			})
+		}
+
+		t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+			err := repo.SetLocationSharing("non-existent-id", "opt-out-group-id", false)
+			assert.Error(t, err)
+		})
+
+		// クリーンアップ
+		_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": user.UserID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateAlias", func(t *testing.T) {
+		user := &entity.User{
+			UserID:   "update-alias-user-id",
+			AuthID:   "update-alias-auth-id",
+			UserName: "Update Alias User",
+			FCMToken: "update-alias-fcm-token",
+		}
+		_, err := db.Collection("users").InsertOne(context.Background(), user)
+		assert.NoError(t, err)
+
+		// テスト実行
+		err = repo.UpdateAlias(user.UserID, "遅刻王")
+
+		// 結果の検証
+		assert.NoError(t, err)
+
+		var savedUser entity.User
+		err = db.Collection("users").FindOne(context.Background(), bson.M{"_id": user.UserID}).Decode(&savedUser)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Alias("遅刻王"), savedUser.Alias)
+		// 他のフィールドは変更されない
+		assert.Equal(t, user.FCMToken, savedUser.FCMToken)
+
+		t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+			err := repo.UpdateAlias("non-existent-id", "遅刻王")
+			assert.Error(t, err)
+		})
+
+		// クリーンアップ
+		_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": user.UserID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("SetCalendarToken", func(t *testing.T) {
+		user := &entity.User{
+			UserID:   "calendar-token-user-id",
+			AuthID:   "calendar-token-auth-id",
+			UserName: "Calendar Token User",
+		}
+		_, err := db.Collection("users").InsertOne(context.Background(), user)
+		assert.NoError(t, err)
+
+		// テスト実行
+		err = repo.SetCalendarToken(user.UserID, "calendar-token-1")
+
+		// 結果の検証
+		assert.NoError(t, err)
+
+		found, err := repo.FindUserByCalendarToken("calendar-token-1")
+		assert.NoError(t, err)
+		assert.NotNil(t, found)
+		assert.Equal(t, user.UserID, found.UserID)
+
+		// 再発行すると古いトークンでは見つからない
+		err = repo.SetCalendarToken(user.UserID, "calendar-token-2")
+		assert.NoError(t, err)
+		found, err = repo.FindUserByCalendarToken("calendar-token-1")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		found, err = repo.FindUserByCalendarToken("")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+			err := repo.SetCalendarToken("non-existent-id", "calendar-token-3")
+			assert.Error(t, err)
+		})
+
+		// クリーンアップ
+		_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": user.UserID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ReserveAnonymousID", func(t *testing.T) {
+		user := &entity.User{
+			UserID:   "anonymous-id-user-id",
+			AuthID:   "anonymous-id-auth-id",
+			UserName: "Anonymous ID User",
+		}
+		_, err := db.Collection("users").InsertOne(context.Background(), user)
+		assert.NoError(t, err)
+
+		// テスト実行
+		reserved, err := repo.ReserveAnonymousID(user.UserID, "anonymous-1")
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserID("anonymous-1"), reserved)
+
+		// やり直した場合は最初に保存した ID が返る
+		reserved, err = repo.ReserveAnonymousID(user.UserID, "anonymous-2")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserID("anonymous-1"), reserved)
+
+		found, err := repo.FindUserByUserID(user.UserID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserID("anonymous-1"), found.AnonymousID)
+
+		t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+			_, err := repo.ReserveAnonymousID("non-existent-id", "anonymous-3")
+			assert.Error(t, err)
+		})
+
+		// クリーンアップ
+		_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": user.UserID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateUserProfile", func(t *testing.T) {
+		// テストデータのセットアップ
+		user := &entity.User{
+			UserID:   "profile-user-id",
+			AuthID:   "profile-auth-id",
+			UserName: "Profile User",
+			UserIcon: "https://example.com/old.png",
+			FCMToken: "fcm-token-profile",
+			Alias:    "遅刻王",
+		}
+		_, err := db.Collection("users").InsertOne(context.Background(), user)
+		assert.NoError(t, err)
+
+		// テスト実行
+		name := entity.UserName("Renamed User")
+		updated, err := repo.UpdateUserProfile(user.UserID, entity.UserProfileUpdate{UserName: &name})
+
+		// 結果の検証: 指定していないフィールドは変わらない
+		assert.NoError(t, err)
+		assert.NotNil(t, updated)
+		assert.Equal(t, name, updated.UserName)
+		assert.Equal(t, user.AuthID, updated.AuthID)
+		assert.Equal(t, user.UserIcon, updated.UserIcon)
+		assert.Equal(t, user.FCMToken, updated.FCMToken)
+		assert.Equal(t, user.Alias, updated.Alias)
+
+		token := entity.FCMToken("fcm-token-refreshed")
+		preference := entity.Alias("")
+		alias := entity.Alias("")
+		updated, err = repo.UpdateUserProfile(user.UserID, entity.UserProfileUpdate{FCMToken: &token, AliasPreference: &preference, Alias: &alias})
+		assert.NoError(t, err)
+		assert.Equal(t, token, updated.FCMToken)
+		assert.Equal(t, entity.Alias(""), updated.Alias)
+		assert.Equal(t, name, updated.UserName)
+
+		t.Run("変更がない場合は現在のユーザーを返す", func(t *testing.T) {
+			found, err := repo.UpdateUserProfile(user.UserID, entity.UserProfileUpdate{})
+			assert.NoError(t, err)
+			assert.Equal(t, name, found.UserName)
+		})
+
+		t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+			found, err := repo.UpdateUserProfile("non-existent-id", entity.UserProfileUpdate{UserName: &name})
+			assert.NoError(t, err)
+			assert.Nil(t, found)
+		})
+
+		// クリーンアップ
+		_, err = db.Collection("users").DeleteMany(context.Background(), bson.M{"_id": user.UserID})
+		assert.NoError(t, err)
+	})
 }
@@ -0,0 +1,231 @@
+package notification
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+)
+
+const (
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	// アクセストークンの期限が切れる少し前に取り直す
+	fcmTokenRefreshMargin = time.Minute
+)
+
+// serviceAccount は Firebase のサービスアカウントの鍵ファイルのうち、使う項目
+type serviceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMClient は Firebase Cloud Messaging の HTTP v1 API で通知を送る
+type FCMClient struct {
+	account    serviceAccount
+	key        *rsa.PrivateKey
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCMClientFromFile はサービスアカウントの鍵ファイルからクライアントを作る
+func NewFCMClientFromFile(path string) (*FCMClient, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading service account: %w", err)
+	}
+
+	var account serviceAccount
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return nil, fmt.Errorf("error parsing service account: %w", err)
+	}
+	if account.ProjectID == "" || account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, fmt.Errorf("service account is missing project_id, client_email or token_uri")
+	}
+
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("service account private key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing service account private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("service account private key is not RSA")
+	}
+
+	return &FCMClient{
+		account:    account,
+		key:        key,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type fcmMessage struct {
+	Message fcmMessageBody `json:"message"`
+}
+
+type fcmMessageBody struct {
+	Token        entity.FCMToken   `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			Type      string `json:"@type"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (c *FCMClient) Send(token entity.FCMToken, notification entity.Notification) error {
+	accessToken, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	// data の値は文字列でなければならないため、種類も data に入れてクライアントで振り分けられるようにする
+	data := map[string]string{"kind": string(notification.Kind)}
+	for k, v := range notification.Data {
+		data[k] = v
+	}
+	body, err := json.Marshal(fcmMessage{Message: fcmMessageBody{
+		Token:        token,
+		Notification: fcmNotification{Title: notification.Title, Body: notification.Body},
+		Data:         data,
+	}})
+	if err != nil {
+		return fmt.Errorf("error encoding FCM message: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(fcmEndpoint, c.account.ProjectID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating FCM request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending FCM message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var fcmErr fcmErrorResponse
+	if err := json.Unmarshal(respBody, &fcmErr); err != nil {
+		return fmt.Errorf("FCM returned status %d", resp.StatusCode)
+	}
+	for _, detail := range fcmErr.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return ErrUnregisteredToken
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrUnregisteredToken
+	}
+	return fmt.Errorf("FCM returned status %d (%s): %s", resp.StatusCode, fcmErr.Error.Status, fcmErr.Error.Message)
+}
+
+// token はキャッシュしたアクセストークンを返し、期限が近ければサービスアカウントの鍵で取り直す
+func (c *FCMClient) token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.accessToken != "" && now.Add(fcmTokenRefreshMargin).Before(c.expiresAt) {
+		return c.accessToken, nil
+	}
+
+	assertion, err := c.signAssertion(now)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	resp, err := c.httpClient.Post(c.account.TokenURI, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error requesting FCM access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FCM access token request returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error decoding FCM access token: %w", err)
+	}
+
+	c.accessToken = result.AccessToken
+	c.expiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return c.accessToken, nil
+}
+
+// signAssertion はアクセストークンと交換する JWT をサービスアカウントの鍵で署名する
+func (c *FCMClient) signAssertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   c.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   c.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing FCM assertion: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,20 @@
+package notification
+
+import (
+	"log"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/service"
+)
+
+// LogNotifier は通知を送らずにログへ出力する。プッシュ通知の設定がない環境で使う
+type LogNotifier struct{}
+
+func NewLogNotifier() service.Notifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(userID entity.UserID, notification entity.Notification) error {
+	log.Printf("Notification to %s [%s]: %s - %s", userID, notification.Kind, notification.Title, notification.Body)
+	return nil
+}
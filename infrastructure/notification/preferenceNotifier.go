@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+)
+
+// PreferenceNotifier はユーザーの通知の設定で許可されている通知だけを next に渡す
+// 通知を受け取らない時間帯に届いた通知は queueRepo に保留し、DeliverDue で時間帯が明けてから送る
+type PreferenceNotifier struct {
+	settingsRepo repository.NotificationSettingsRepository
+	queueRepo    repository.NotificationQueueRepository
+	next         service.Notifier
+}
+
+func NewPreferenceNotifier(settingsRepo repository.NotificationSettingsRepository, queueRepo repository.NotificationQueueRepository, next service.Notifier) *PreferenceNotifier {
+	return &PreferenceNotifier{
+		settingsRepo: settingsRepo,
+		queueRepo:    queueRepo,
+		next:         next,
+	}
+}
+
+func (n *PreferenceNotifier) Notify(userID entity.UserID, notification entity.Notification) error {
+	settings, err := n.settingsRepo.FindSettingsByUserID(userID)
+	if err != nil {
+		// 設定を読めないときに通知が届かなくなるより、送ってしまうほうを選ぶ
+		log.Printf("WARN: Failed to load notification settings of %s, sending anyway: %v", userID, err)
+		return n.next.Notify(userID, notification)
+	}
+	if settings == nil {
+		return n.next.Notify(userID, notification)
+	}
+
+	if !settings.Allows(notification) {
+		log.Printf("Skipped notification to %s [%s]: disabled by settings", userID, notification.Kind)
+		return nil
+	}
+
+	now := time.Now()
+	if until, quiet := settings.QuietUntil(now); quiet {
+		_, err := n.queueRepo.EnqueueNotification(entity.QueuedNotification{
+			UserID:       userID,
+			Notification: notification,
+			DeliverAt:    until,
+			CreatedAt:    now,
+		})
+		if err != nil {
+			return fmt.Errorf("通知の保留に失敗しました: %w", err)
+		}
+		log.Printf("Queued notification to %s [%s] until %s: quiet hours", userID, notification.Kind, until.Format(time.RFC3339))
+		return nil
+	}
+
+	return n.next.Notify(userID, notification)
+}
+
+// DeliverDue は送る時刻が来た保留中の通知を送る
+// 保留している間に設定が変わっていることがあるため、送る前に設定を確かめ直す
+// 通知は 1 件ずつキューから取り出してから送るため、複数のインスタンスで同時に呼んでも二重には送らない
+func (n *PreferenceNotifier) DeliverDue(now time.Time) error {
+	for {
+		queued, err := n.queueRepo.ClaimDueNotification(now)
+		if err != nil {
+			return err
+		}
+		if queued == nil {
+			return nil
+		}
+
+		// 送れなかった通知は保留し直さない。すぐに送る通知と同じく、失敗してもやり直さない
+		if err := n.Notify(queued.UserID, queued.Notification); err != nil {
+			log.Printf("WARN: Failed to deliver queued notification to %s: %v", queued.UserID, err)
+		}
+	}
+}
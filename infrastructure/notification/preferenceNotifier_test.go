@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type settingsRepoStub struct {
+	settings map[entity.UserID]*entity.NotificationSettings
+}
+
+func (r *settingsRepoStub) FindSettingsByUserID(userID entity.UserID) (*entity.NotificationSettings, error) {
+	return r.settings[userID], nil
+}
+
+func (r *settingsRepoStub) SaveSettings(settings entity.NotificationSettings) error {
+	r.settings[settings.UserID] = &settings
+	return nil
+}
+
+func (r *settingsRepoStub) DeleteSettingsByUserID(userID entity.UserID) error {
+	delete(r.settings, userID)
+	return nil
+}
+
+type queueRepoStub struct {
+	queued []entity.QueuedNotification
+}
+
+func (r *queueRepoStub) EnqueueNotification(queued entity.QueuedNotification) (*entity.QueuedNotification, error) {
+	queued.QueuedNotificationID = entity.QueuedNotificationID(queued.UserID) + entity.QueuedNotificationID(queued.DeliverAt.Format(time.RFC3339))
+	r.queued = append(r.queued, queued)
+	return &queued, nil
+}
+
+func (r *queueRepoStub) ClaimDueNotification(now time.Time) (*entity.QueuedNotification, error) {
+	for i, queued := range r.queued {
+		if !queued.DeliverAt.After(now) {
+			r.queued = append(r.queued[:i], r.queued[i+1:]...)
+			return &queued, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *queueRepoStub) DeleteQueuedNotificationsByUserID(userID entity.UserID) error {
+	return nil
+}
+
+type recordingNotifier struct {
+	sent []entity.Notification
+}
+
+func (n *recordingNotifier) Notify(userID entity.UserID, notification entity.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestPreferenceNotifier(t *testing.T) {
+	notification := entity.Notification{Kind: entity.NotificationNewEvent, GroupID: "group-id", Title: "新しいイベント"}
+
+	newNotifier := func(settings *entity.NotificationSettings) (*PreferenceNotifier, *queueRepoStub, *recordingNotifier) {
+		settingsRepo := &settingsRepoStub{settings: map[entity.UserID]*entity.NotificationSettings{}}
+		if settings != nil {
+			settingsRepo.settings[settings.UserID] = settings
+		}
+		queueRepo := &queueRepoStub{}
+		next := &recordingNotifier{}
+		return NewPreferenceNotifier(settingsRepo, queueRepo, next), queueRepo, next
+	}
+
+	t.Run("正常系: 設定がなければそのまま送る", func(t *testing.T) {
+		notifier, queueRepo, next := newNotifier(nil)
+
+		assert.NoError(t, notifier.Notify("user-id", notification))
+		assert.Len(t, next.sent, 1)
+		assert.Empty(t, queueRepo.queued)
+	})
+
+	t.Run("正常系: 無効にした種類は送らず、保留もしない", func(t *testing.T) {
+		settings := entity.DefaultNotificationSettings("user-id")
+		settings.Kinds[entity.NotificationNewEvent] = false
+		notifier, queueRepo, next := newNotifier(settings)
+
+		assert.NoError(t, notifier.Notify("user-id", notification))
+		assert.Empty(t, next.sent)
+		assert.Empty(t, queueRepo.queued)
+	})
+
+	t.Run("正常系: 時間帯の中の通知は時間帯が明けてから送る", func(t *testing.T) {
+		// 常に時間帯に入るよう、今の時刻の前後を時間帯にする
+		now := time.Now().UTC()
+		settings := entity.DefaultNotificationSettings("user-id")
+		settings.QuietHours = &entity.QuietHours{
+			Start:    now.Add(-time.Hour).Format("15:04"),
+			End:      now.Add(2 * time.Hour).Format("15:04"),
+			TimeZone: "UTC",
+		}
+		notifier, queueRepo, next := newNotifier(settings)
+
+		// テスト実行
+		assert.NoError(t, notifier.Notify("user-id", notification))
+
+		// 結果の検証
+		assert.Empty(t, next.sent)
+		assert.Len(t, queueRepo.queued, 1)
+		deliverAt := queueRepo.queued[0].DeliverAt
+		assert.True(t, deliverAt.After(now.Add(time.Hour)))
+		assert.False(t, deliverAt.After(now.Add(2*time.Hour)))
+		assert.Equal(t, notification, queueRepo.queued[0].Notification)
+
+		// 送る時刻になる前は送らない
+		assert.NoError(t, notifier.DeliverDue(now))
+		assert.Empty(t, next.sent)
+		assert.Len(t, queueRepo.queued, 1)
+
+		// 時間帯が明けたら送って、保留を消す
+		settings.QuietHours = nil
+		assert.NoError(t, notifier.DeliverDue(deliverAt))
+		assert.Equal(t, []entity.Notification{notification}, next.sent)
+		assert.Empty(t, queueRepo.queued)
+	})
+
+	t.Run("正常系: 保留中に無効にした種類は時間帯が明けても送らない", func(t *testing.T) {
+		settings := entity.DefaultNotificationSettings("user-id")
+		notifier, queueRepo, next := newNotifier(settings)
+		queueRepo.queued = []entity.QueuedNotification{{QueuedNotificationID: "queued-id", UserID: "user-id", Notification: notification, DeliverAt: time.Now().Add(-time.Minute)}}
+		settings.Kinds[entity.NotificationNewEvent] = false
+
+		assert.NoError(t, notifier.DeliverDue(time.Now()))
+		assert.Empty(t, next.sent)
+		assert.Empty(t, queueRepo.queued)
+	})
+}
+
+func TestNotificationSettingsQuietUntil(t *testing.T) {
+	settings := entity.DefaultNotificationSettings("user-id")
+	settings.QuietHours = &entity.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Asia/Tokyo"}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		now           time.Time
+		expectedUntil time.Time
+		expectedQuiet bool
+	}{
+		{name: "日付が変わる前は翌朝まで", now: time.Date(2026, 1, 15, 23, 30, 0, 0, tokyo), expectedUntil: time.Date(2026, 1, 16, 7, 0, 0, 0, tokyo), expectedQuiet: true},
+		{name: "日付が変わった後はその日の朝まで", now: time.Date(2026, 1, 16, 3, 0, 0, 0, tokyo), expectedUntil: time.Date(2026, 1, 16, 7, 0, 0, 0, tokyo), expectedQuiet: true},
+		{name: "開始ちょうどは時間帯に入る", now: time.Date(2026, 1, 15, 22, 0, 0, 0, tokyo), expectedUntil: time.Date(2026, 1, 16, 7, 0, 0, 0, tokyo), expectedQuiet: true},
+		{name: "終了ちょうどは時間帯に入らない", now: time.Date(2026, 1, 16, 7, 0, 0, 0, tokyo), expectedQuiet: false},
+		{name: "UTC で渡しても利用者のタイムゾーンで判定する", now: time.Date(2026, 1, 15, 14, 0, 0, 0, time.UTC), expectedUntil: time.Date(2026, 1, 16, 7, 0, 0, 0, tokyo), expectedQuiet: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			until, quiet := settings.QuietUntil(tc.now)
+
+			// 結果の検証
+			assert.Equal(t, tc.expectedQuiet, quiet)
+			if tc.expectedQuiet {
+				assert.True(t, tc.expectedUntil.Equal(until))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+)
+
+// ErrUnregisteredToken はプッシュ通知の提供元がトークンを無効と判断したことを表す
+// アプリのアンインストールなどで発生し、同じトークンに再送しても届かない
+var ErrUnregisteredToken = errors.New("push token is unregistered")
+
+// PushClient は 1 つの端末にプッシュ通知を送る
+type PushClient interface {
+	Send(token entity.FCMToken, notification entity.Notification) error
+}
+
+// PushNotifier はユーザーが登録したすべての端末にプッシュ通知を送り、無効になったトークンを削除する
+type PushNotifier struct {
+	deviceRepo repository.DeviceRepository
+	userRepo   repository.UserRepository
+	client     PushClient
+}
+
+func NewPushNotifier(deviceRepo repository.DeviceRepository, userRepo repository.UserRepository, client PushClient) service.Notifier {
+	return &PushNotifier{
+		deviceRepo: deviceRepo,
+		userRepo:   userRepo,
+		client:     client,
+	}
+}
+
+func (n *PushNotifier) Notify(userID entity.UserID, notification entity.Notification) error {
+	devices, err := n.deviceRepo.FindDevicesByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("端末の取得に失敗しました: %w", err)
+	}
+
+	// 端末を登録する前のクライアントは、サインアップ時に送った FCM トークンだけを持っている
+	if len(devices) == 0 {
+		return n.notifyLegacyToken(userID, notification)
+	}
+
+	var unregistered []entity.FCMToken
+	var errs []error
+	for _, device := range devices {
+		err := n.client.Send(device.Token, notification)
+		if errors.Is(err, ErrUnregisteredToken) {
+			unregistered = append(unregistered, device.Token)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("端末 %s への通知に失敗しました: %w", device.DeviceID, err))
+		}
+	}
+
+	if len(unregistered) > 0 {
+		deleted, err := n.deviceRepo.DeleteDevicesByTokens(unregistered)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("無効になった端末の削除に失敗しました: %w", err))
+		} else {
+			log.Printf("Pruned %d unregistered devices of %s", deleted, userID)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *PushNotifier) notifyLegacyToken(userID entity.UserID, notification entity.Notification) error {
+	user, err := n.userRepo.FindUserByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("ユーザーの取得に失敗しました: %w", err)
+	}
+	if user == nil || user.FCMToken == "" {
+		return nil
+	}
+
+	err = n.client.Send(user.FCMToken, notification)
+	if errors.Is(err, ErrUnregisteredToken) {
+		empty := entity.FCMToken("")
+		if _, err := n.userRepo.UpdateUserProfile(userID, entity.UserProfileUpdate{FCMToken: &empty}); err != nil {
+			return fmt.Errorf("無効になった FCM トークンの削除に失敗しました: %w", err)
+		}
+		return nil
+	}
+	return err
+}
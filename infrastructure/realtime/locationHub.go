@@ -0,0 +1,103 @@
+package realtime
+
+import (
+	"sync"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/service"
+)
+
+// 購読者ごとの送信バッファ。受信が追いつかない購読者には古い位置情報を捨てて配信を続ける
+const subscriberBufferSize = 16
+
+type InMemoryLocationHub struct {
+	mu          sync.RWMutex
+	subscribers map[entity.EventID]map[*locationSubscription]struct{}
+}
+
+func NewInMemoryLocationHub() service.LocationHub {
+	return &InMemoryLocationHub{
+		subscribers: make(map[entity.EventID]map[*locationSubscription]struct{}),
+	}
+}
+
+func (h *InMemoryLocationHub) Subscribe(eventID entity.EventID, userID entity.UserID) (service.LocationSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &locationSubscription{
+		hub:     h,
+		eventID: eventID,
+		userID:  userID,
+		updates: make(chan entity.UserLocation, subscriberBufferSize),
+	}
+
+	if _, ok := h.subscribers[eventID]; !ok {
+		h.subscribers[eventID] = make(map[*locationSubscription]struct{})
+	}
+	h.subscribers[eventID][sub] = struct{}{}
+
+	return sub, nil
+}
+
+func (h *InMemoryLocationHub) Publish(eventID entity.EventID, location entity.UserLocation) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers[eventID] {
+		// 送信者自身には配信しない
+		if sub.userID == location.UserID {
+			continue
+		}
+
+		select {
+		case sub.updates <- location:
+		default:
+			// バッファが埋まっていれば一番古い位置を捨てて、最新の位置を入れる
+			select {
+			case <-sub.updates:
+			default:
+			}
+			select {
+			case sub.updates <- location:
+			default:
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *InMemoryLocationHub) unsubscribe(sub *locationSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subscribers[sub.eventID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.eventID)
+	}
+	close(sub.updates)
+}
+
+type locationSubscription struct {
+	hub     *InMemoryLocationHub
+	eventID entity.EventID
+	userID  entity.UserID
+	updates chan entity.UserLocation
+}
+
+func (s *locationSubscription) Updates() <-chan entity.UserLocation {
+	return s.updates
+}
+
+func (s *locationSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
@@ -0,0 +1,102 @@
+package realtime
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func location(userID entity.UserID, latitude entity.Latitude) entity.UserLocation {
+	return entity.UserLocation{UserID: userID, Latitude: latitude, Longitude: 139.7, RecordedAt: time.Now()}
+}
+
+func TestInMemoryLocationHub(t *testing.T) {
+	t.Run("正常系: 同じイベントの他の購読者にだけ配信する", func(t *testing.T) {
+		hub := NewInMemoryLocationHub()
+		sender, _ := hub.Subscribe("event-id", "sender-id")
+		receiver, _ := hub.Subscribe("event-id", "receiver-id")
+		other, _ := hub.Subscribe("other-event-id", "other-id")
+		defer sender.Close()
+		defer receiver.Close()
+		defer other.Close()
+
+		// テスト実行
+		assert.NoError(t, hub.Publish("event-id", location("sender-id", 35.6)))
+
+		// 結果の検証
+		assert.Len(t, receiver.Updates(), 1)
+		assert.Equal(t, entity.Latitude(35.6), (<-receiver.Updates()).Latitude)
+		assert.Empty(t, sender.Updates())
+		assert.Empty(t, other.Updates())
+	})
+
+	t.Run("正常系: 購読をやめるとチャネルが閉じられ、以降は配信されない", func(t *testing.T) {
+		hub := NewInMemoryLocationHub()
+		sub, _ := hub.Subscribe("event-id", "receiver-id")
+
+		// テスト実行
+		sub.Close()
+		assert.NoError(t, hub.Publish("event-id", location("sender-id", 35.6)))
+
+		// 結果の検証
+		_, ok := <-sub.Updates()
+		assert.False(t, ok)
+		// 二重に閉じても panic しない
+		assert.NotPanics(t, sub.Close)
+		assert.Empty(t, hub.(*InMemoryLocationHub).subscribers)
+	})
+
+	t.Run("正常系: 受信しない購読者がいても配信は止まらず、古い位置から捨てる", func(t *testing.T) {
+		hub := NewInMemoryLocationHub()
+		slow, _ := hub.Subscribe("event-id", "slow-id")
+		defer slow.Close()
+
+		// テスト実行: バッファの倍の件数を受信せずに配信する
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < subscriberBufferSize*2; i++ {
+				hub.Publish("event-id", location("sender-id", entity.Latitude(i)))
+			}
+			close(done)
+		}()
+
+		// 結果の検証
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("配信が受信の遅い購読者に止められた")
+		}
+		assert.Len(t, slow.Updates(), subscriberBufferSize)
+		assert.Equal(t, entity.Latitude(subscriberBufferSize), (<-slow.Updates()).Latitude)
+	})
+
+	t.Run("正常系: 購読・配信・購読の終了が並行しても競合しない", func(t *testing.T) {
+		hub := NewInMemoryLocationHub()
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				userID := entity.UserID("user-" + strconv.Itoa(i))
+				sub, _ := hub.Subscribe("event-id", userID)
+				for j := 0; j < 50; j++ {
+					hub.Publish("event-id", location(userID, entity.Latitude(j)))
+					select {
+					case <-sub.Updates():
+					default:
+					}
+				}
+				sub.Close()
+			}(i)
+		}
+
+		wg.Wait()
+		assert.Empty(t, hub.(*InMemoryLocationHub).subscribers)
+	})
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const authUserKey = "auth_user"
+
+// NewAuthMiddleware は Authorization ヘッダー（Bearer <auth_id>）から認証ユーザーを特定し、コンテキストに格納する
+// WebSocket ではヘッダーを付与できないクライアントがあるため、アップグレード要求に限り auth_id クエリパラメータも受け付ける
+func NewAuthMiddleware(userRepo repository.UserRepository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authID := strings.TrimSpace(strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer "))
+			if authID == "" && strings.EqualFold(c.Request().Header.Get(echo.HeaderUpgrade), "websocket") {
+				authID = c.QueryParam("auth_id")
+			}
+			if authID == "" {
+				return c.JSON(http.StatusUnauthorized, NewErrorResponse("認証情報が必要です"))
+			}
+
+			user, err := userRepo.FindUserByAuthID(entity.AuthID(authID))
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, NewErrorResponse(err.Error()))
+			}
+			if user == nil {
+				return c.JSON(http.StatusUnauthorized, NewErrorResponse("ユーザーが見つかりません"))
+			}
+
+			c.Set(authUserKey, user)
+			return next(c)
+		}
+	}
+}
+
+// GetAuthUser は NewAuthMiddleware が格納した認証ユーザーを返す
+func GetAuthUser(c echo.Context) *entity.User {
+	user, _ := c.Get(authUserKey).(*entity.User)
+	return user
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CloseSeason struct {
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	seasonRepo repository.SeasonRepository
+	scoreRepo  repository.ScoreRepository
+}
+
+func NewCloseSeason(groupRepo repository.GroupRepository, userRepo repository.UserRepository, seasonRepo repository.SeasonRepository, scoreRepo repository.ScoreRepository) *CloseSeason {
+	return &CloseSeason{
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		seasonRepo: seasonRepo,
+		scoreRepo:  scoreRepo,
+	}
+}
+
+// @Summary close season
+// @Description close the season and freeze its standings. Only the group manager can close it. Closing before the end time shortens the season so later events are not assigned to it.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param season_id path string true "Season ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} entity.Season
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/seasons/{season_id}/close [post]
+func (cs *CloseSeason) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	seasonIDStr := c.Param("season_id")
+	if groupIDStr == "" || seasonIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDとシーズンIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	season, err := usecase.NewCloseSeasonUseCase(cs.groupRepo, cs.userRepo, cs.seasonRepo, cs.scoreRepo, user.UserID, entity.GroupID(groupIDStr), entity.SeasonID(seasonIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrSeasonNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("シーズンが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupManager):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("グループの管理者のみがシーズンを締められます"))
+		case errors.Is(err, usecase.ErrSeasonAlreadyClosed):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("このシーズンはすでに締められています"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, season)
+}
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeleteDevice struct {
+	deviceRepo repository.DeviceRepository
+}
+
+func NewDeleteDevice(deviceRepo repository.DeviceRepository) *DeleteDevice {
+	return &DeleteDevice{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// @Summary unregister device
+// @Description stop sending push notifications to one of the user's devices, e.g. on logout
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param device_id path string true "Device ID"
+// @Success 204
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/devices/{device_id} [delete]
+func (d *DeleteDevice) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	err := usecase.NewUnregisterDeviceUseCase(d.deviceRepo, user.UserID, entity.DeviceID(c.Param("device_id"))).Execute()
+	if err != nil {
+		if errors.Is(err, usecase.ErrDeviceNotFound) {
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("端末が登録されていません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
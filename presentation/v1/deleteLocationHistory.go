@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeleteLocationHistoryResponse struct {
+	DeletedCount int64 `json:"deleted_count" example:"120"`
+}
+
+type DeleteLocationHistory struct {
+	locationRepo repository.LocationRepository
+	historyRepo  repository.LocationHistoryRepository
+}
+
+func NewDeleteLocationHistory(locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository) *DeleteLocationHistory {
+	return &DeleteLocationHistory{
+		locationRepo: locationRepo,
+		historyRepo:  historyRepo,
+	}
+}
+
+// @Summary delete location history
+// @Description delete all of the authenticated user's location history and last known location
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} DeleteLocationHistoryResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/location-history [delete]
+func (d *DeleteLocationHistory) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	deleted, err := usecase.NewDeleteLocationHistoryUseCase(d.locationRepo, d.historyRepo, user.UserID).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, DeleteLocationHistoryResponse{DeletedCount: deleted})
+}
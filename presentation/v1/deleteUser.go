@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeleteUser struct {
+	userRepo     repository.UserRepository
+	groupRepo    repository.GroupRepository
+	eventRepo    repository.EventRepository
+	scoreRepo    repository.ScoreRepository
+	titleRepo    repository.TitleRepository
+	locationRepo repository.LocationRepository
+	historyRepo  repository.LocationHistoryRepository
+	auditRepo    repository.AuditLogRepository
+	deviceRepo   repository.DeviceRepository
+	settingsRepo repository.NotificationSettingsRepository
+	queueRepo    repository.NotificationQueueRepository
+	blobStore    service.BlobStore
+	cache        *usecase.LeaderboardCache
+}
+
+func NewDeleteUser(userRepo repository.UserRepository, groupRepo repository.GroupRepository, eventRepo repository.EventRepository, scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, auditRepo repository.AuditLogRepository, deviceRepo repository.DeviceRepository, settingsRepo repository.NotificationSettingsRepository, queueRepo repository.NotificationQueueRepository, blobStore service.BlobStore, cache *usecase.LeaderboardCache) *DeleteUser {
+	return &DeleteUser{
+		userRepo:     userRepo,
+		groupRepo:    groupRepo,
+		eventRepo:    eventRepo,
+		scoreRepo:    scoreRepo,
+		titleRepo:    titleRepo,
+		locationRepo: locationRepo,
+		historyRepo:  historyRepo,
+		auditRepo:    auditRepo,
+		deviceRepo:   deviceRepo,
+		settingsRepo: settingsRepo,
+		queueRepo:    queueRepo,
+		blobStore:    blobStore,
+		cache:        cache,
+	}
+}
+
+// @Summary delete account
+// @Description delete the authenticated user. Managed groups are handed over to the longest-standing other member, past event records are kept under an anonymous ID, and location data is purged
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.DeleteUserResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse "the user is the only member of a group they manage"
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me [delete]
+func (d *DeleteUser) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	response, err := usecase.NewDeleteUserUseCase(d.userRepo, d.groupRepo, d.eventRepo, d.scoreRepo, d.titleRepo, d.locationRepo, d.historyRepo, d.auditRepo, d.deviceRepo, d.settingsRepo, d.queueRepo, d.blobStore, d.cache, user.UserID, "self").Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserManagesGroup):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("管理しているグループに他のメンバーがいないため退会できません。先にグループを削除してください"))
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("ユーザーが見つかりません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
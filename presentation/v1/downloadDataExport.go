@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DownloadDataExport struct {
+	exporter *usecase.DataExporter
+}
+
+func NewDownloadDataExport(exporter *usecase.DataExporter) *DownloadDataExport {
+	return &DownloadDataExport{
+		exporter: exporter,
+	}
+}
+
+// @Summary download personal data export
+// @Description download a built data export archive. The link returned by the export endpoints carries its own token, so no Authorization header is needed
+// @Tags users
+// @Produce application/zip
+// @Param job_id path string true "export job ID"
+// @Param token query string true "download token"
+// @Success 200 {file} binary
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse "the export has not been built"
+// @Failure 410 {object} middleware.ErrorResponse "the download link has expired"
+// @Router /exports/{job_id}/download [get]
+func (d *DownloadDataExport) Handler(c echo.Context) error {
+	archive, job, err := d.exporter.OpenArchive(entity.ExportJobID(c.Param("job_id")), c.QueryParam("token"), time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrExportJobNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("エクスポートが見つかりません"))
+		case errors.Is(err, usecase.ErrExportNotReady):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("エクスポートはまだ作成できていません"))
+		case errors.Is(err, usecase.ErrExportLinkExpired):
+			return c.JSON(http.StatusGone, middleware.NewErrorResponse("ダウンロードの期限が過ぎています"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+	defer archive.Close()
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="chikokulympic-export-%s.zip"`, job.CompletedAt.Format("20060102")))
+	return c.Stream(http.StatusOK, "application/zip", archive)
+}
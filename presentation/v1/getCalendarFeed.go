@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetCalendarFeed struct {
+	userRepo  repository.UserRepository
+	groupRepo repository.GroupRepository
+	eventRepo repository.EventRepository
+}
+
+func NewGetCalendarFeed(userRepo repository.UserRepository, groupRepo repository.GroupRepository, eventRepo repository.EventRepository) *GetCalendarFeed {
+	return &GetCalendarFeed{
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// @Summary get calendar feed
+// @Description get an iCalendar feed of every event in the user's groups for calendar app subscriptions. Authenticated by the feed token instead of the Authorization header. Each event has an alarm at its voting deadline.
+// @Tags users
+// @Produce text/calendar
+// @Param user_id path string true "user_id"
+// @Param token query string true "calendar feed token"
+// @Success 200 {string} string "iCalendar data"
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/{user_id}/calendar.ics [get]
+func (g *GetCalendarFeed) Handler(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ユーザーIDは必須です"))
+	}
+
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusUnauthorized, middleware.NewErrorResponse("トークンは必須です"))
+	}
+
+	feed, err := usecase.NewFetchCalendarFeedUseCase(g.userRepo, g.groupRepo, g.eventRepo, entity.UserID(userIDParam), entity.CalendarToken(token)).Execute()
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCalendarToken) {
+			return c.JSON(http.StatusUnauthorized, middleware.NewErrorResponse("トークンが正しくありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	name := "Chikokulympic"
+	if feed.User.UserName != "" {
+		name = "Chikokulympic (" + string(feed.User.UserName) + ")"
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=300")
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", writeICalendar(name, feed.Events, time.Now()))
+}
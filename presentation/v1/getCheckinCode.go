@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/skip2/go-qrcode"
+)
+
+const checkinQRSize = 512
+
+type GetCheckinCode struct {
+	eventRepo repository.EventRepository
+	policy    usecase.CheckinCodePolicy
+}
+
+func NewGetCheckinCode(eventRepo repository.EventRepository, policy usecase.CheckinCodePolicy) *GetCheckinCode {
+	return &GetCheckinCode{
+		eventRepo: eventRepo,
+		policy:    policy,
+	}
+}
+
+// @Summary get check-in code
+// @Description get the current rotating check-in code for the event. Only the event author can display it.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.CheckinCodeResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/checkin-code [get]
+func (g *GetCheckinCode) Handler(c echo.Context) error {
+	result, err := g.issue(c)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary get check-in QR code
+// @Description render the current rotating check-in code as a PNG QR image. Only the event author can display it.
+// @Tags events
+// @Produce png
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {file} binary
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/checkin-qr [get]
+func (g *GetCheckinCode) QRHandler(c echo.Context) error {
+	result, err := g.issue(c)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	png, err := qrcode.Encode(result.Payload, qrcode.Medium, checkinQRSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse("QRコードの生成に失敗しました"))
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.Blob(http.StatusOK, "image/png", png)
+}
+
+// issue はコードを発行し、失敗した場合はエラーレスポンスを書き込んで nil を返す
+func (g *GetCheckinCode) issue(c echo.Context) (*usecase.CheckinCodeResponse, error) {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return nil, c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewIssueCheckinCodeUseCase(g.eventRepo, g.policy, user.UserID, entity.EventID(eventIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return nil, c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotEventAuthor):
+			return nil, c.JSON(http.StatusForbidden, middleware.NewErrorResponse("イベントの作成者のみがチェックインコードを表示できます"))
+		}
+		return nil, c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DataExportResponse struct {
+	entity.ExportJob
+	// 完了していて期限内の場合のみ含まれる。認証なしでダウンロードできるため、他人と共有しない
+	DownloadURL string `json:"download_url,omitempty" example:"/exports/job123/download?token=abc"`
+}
+
+func newDataExportResponse(job *entity.ExportJob, now time.Time) DataExportResponse {
+	response := DataExportResponse{ExportJob: *job}
+	if job.IsDownloadable(now) {
+		response.DownloadURL = "/exports/" + url.PathEscape(string(job.JobID)) + "/download?" + url.Values{"token": {job.DownloadToken}}.Encode()
+	}
+	return response
+}
+
+// dataExportStatusCode は作成が終わっていなければ 202 を返す
+func dataExportStatusCode(job *entity.ExportJob) int {
+	if job.IsActive() {
+		return http.StatusAccepted
+	}
+	return http.StatusOK
+}
+
+type GetDataExport struct {
+	exporter *usecase.DataExporter
+}
+
+func NewGetDataExport(exporter *usecase.DataExporter) *GetDataExport {
+	return &GetDataExport{
+		exporter: exporter,
+	}
+}
+
+// @Summary export personal data
+// @Description request a ZIP archive of the authenticated user's profile, groups, authored events, votes and arrivals, location history and titles in JSON and CSV. The archive is built in the background; while it is being built 202 is returned. Once built, the response includes a download link that expires. An in-progress or still downloadable export is returned instead of starting a new one
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} DataExportResponse
+// @Success 202 {object} DataExportResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/export [get]
+func (g *GetDataExport) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	job, err := g.exporter.RequestExport(user.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(dataExportStatusCode(job), newDataExportResponse(job, time.Now()))
+}
+
+type GetDataExportJob struct {
+	exporter *usecase.DataExporter
+}
+
+func NewGetDataExportJob(exporter *usecase.DataExporter) *GetDataExportJob {
+	return &GetDataExportJob{
+		exporter: exporter,
+	}
+}
+
+// @Summary get personal data export status
+// @Description get the status of one of the authenticated user's data exports
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param job_id path string true "export job ID"
+// @Success 200 {object} DataExportResponse
+// @Success 202 {object} DataExportResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /users/me/export/{job_id} [get]
+func (g *GetDataExportJob) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	job, err := g.exporter.FindJob(user.UserID, entity.ExportJobID(c.Param("job_id")))
+	if errors.Is(err, usecase.ErrExportJobNotFound) {
+		return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("エクスポートが見つかりません"))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(dataExportStatusCode(job), newDataExportResponse(job, time.Now()))
+}
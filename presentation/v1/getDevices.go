@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetDevices struct {
+	deviceRepo repository.DeviceRepository
+}
+
+func NewGetDevices(deviceRepo repository.DeviceRepository) *GetDevices {
+	return &GetDevices{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// @Summary list devices
+// @Description list the devices registered for push notifications, most recently seen first
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.FetchUserDevicesResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/devices [get]
+func (g *GetDevices) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewFetchUserDevicesUseCase(g.deviceRepo, user.UserID).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetEventETA struct {
+	eventRepo    repository.EventRepository
+	groupRepo    repository.GroupRepository
+	userRepo     repository.UserRepository
+	locationRepo repository.LocationRepository
+	speedProfile usecase.TravelSpeedProfile
+}
+
+func NewGetEventETA(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, locationRepo repository.LocationRepository, speedProfile usecase.TravelSpeedProfile) *GetEventETA {
+	return &GetEventETA{
+		eventRepo:    eventRepo,
+		groupRepo:    groupRepo,
+		userRepo:     userRepo,
+		locationRepo: locationRepo,
+		speedProfile: speedProfile,
+	}
+}
+
+// @Summary get estimated arrival
+// @Description estimate each participant's arrival time and predicted lateness from their last shared location. Participants who stopped sharing their location with the group, or whose last location falls outside the event's tracking window or is older than 10 minutes, have no estimate (has_location is false)
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param mode query string false "travel mode (walking, transit, car)" default(transit)
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.EstimateArrivalResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/eta [get]
+func (g *GetEventETA) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	travelMode := usecase.TravelModeTransit
+	if mode := c.QueryParam("mode"); mode != "" {
+		travelMode = usecase.TravelMode(mode)
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewEstimateArrivalUseCase(g.eventRepo, g.groupRepo, g.userRepo, g.locationRepo, g.speedProfile, entity.EventID(eventIDStr), user.UserID, travelMode).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnknownTravelMode):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("移動手段は walking, transit, car のいずれかを指定してください"))
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントを閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
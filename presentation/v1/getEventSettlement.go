@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetEventSettlement struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+}
+
+func NewGetEventSettlement(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository) *GetEventSettlement {
+	return &GetEventSettlement{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// @Summary get event settlement
+// @Description get how the event cost is split among the members who voted to attend, and who has paid the event author. Late participants pay a surcharge that is taken off the on-time participants' shares. Members whose arrival is not confirmed are treated as late until the event ends (no_show). The amounts are provisional until the event ends.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.EventSettlement
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/settlement [get]
+func (g *GetEventSettlement) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	settlement, err := usecase.NewGetEventSettlementUseCase(g.eventRepo, g.groupRepo, g.userRepo, user.UserID, entity.EventID(eventIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントの精算を閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, settlement)
+}
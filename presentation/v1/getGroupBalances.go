@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetGroupBalances struct {
+	groupRepo repository.GroupRepository
+	eventRepo repository.EventRepository
+	userRepo  repository.UserRepository
+}
+
+func NewGetGroupBalances(groupRepo repository.GroupRepository, eventRepo repository.EventRepository, userRepo repository.UserRepository) *GetGroupBalances {
+	return &GetGroupBalances{
+		groupRepo: groupRepo,
+		eventRepo: eventRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// @Summary get group balances
+// @Description get the unpaid event costs in the group as a running balance per member and as who owes whom. Only events that have ended are included, and debts between the same two members are netted.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.GetGroupBalancesResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/balances [get]
+func (g *GetGroupBalances) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	balances, err := usecase.NewGetGroupBalancesUseCase(g.groupRepo, g.eventRepo, g.userRepo, user.UserID, entity.GroupID(groupIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このグループの精算を閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, balances)
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetGroupLeaderboard struct {
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	seasonRepo repository.SeasonRepository
+	cache      *usecase.LeaderboardCache
+}
+
+func NewGetGroupLeaderboard(groupRepo repository.GroupRepository, userRepo repository.UserRepository, seasonRepo repository.SeasonRepository, cache *usecase.LeaderboardCache) *GetGroupLeaderboard {
+	return &GetGroupLeaderboard{
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		seasonRepo: seasonRepo,
+		cache:      cache,
+	}
+}
+
+// @Summary get group leaderboard
+// @Description rank group members by a metric over finalized events in a time range, with deltas versus the previous period of the same length. The range defaults to the last 30 days, or the season's period when season_id is given.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param from query string false "range start (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "range end, exclusive (YYYY-MM-DD or RFC3339)"
+// @Param season_id query string false "Season ID. Takes precedence over from and to"
+// @Param metric query string false "total_late_minutes, average_late_minutes, on_time_rate or attendance" default(total_late_minutes)
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.GetGroupLeaderboardResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/leaderboard [get]
+func (g *GetGroupLeaderboard) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	from, err := parsePeriodParam(c.QueryParam("from"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("from の形式が正しくありません"))
+	}
+	to, err := parsePeriodParam(c.QueryParam("to"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("to の形式が正しくありません"))
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("from は to より前の日時を指定してください"))
+	}
+
+	metric := usecase.LeaderboardMetricTotalLateMinutes
+	if m := c.QueryParam("metric"); m != "" {
+		metric = usecase.LeaderboardMetric(m)
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewGetGroupLeaderboardUseCase(g.groupRepo, g.userRepo, g.seasonRepo, g.cache, user.UserID, entity.GroupID(groupIDStr), metric, entity.SeasonID(c.QueryParam("season_id")), from, to).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnknownLeaderboardMetric):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("metric は total_late_minutes, average_late_minutes, on_time_rate, attendance のいずれかを指定してください"))
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrSeasonNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("シーズンが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このグループのリーダーボードを閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetGroupSeasonsResponse struct {
+	Seasons []entity.Season `json:"seasons"`
+}
+
+type GetGroupSeasons struct {
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+}
+
+func NewGetGroupSeasons(groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository) *GetGroupSeasons {
+	return &GetGroupSeasons{
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+	}
+}
+
+// @Summary get group seasons
+// @Description get the seasons of the group ordered by start time
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} GetGroupSeasonsResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/seasons [get]
+func (g *GetGroupSeasons) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	seasons, err := usecase.NewFetchGroupSeasonsUseCase(g.groupRepo, g.seasonRepo, user.UserID, entity.GroupID(groupIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このグループのシーズンを閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, &GetGroupSeasonsResponse{Seasons: seasons})
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetGroupStandings struct {
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	scoreRepo  repository.ScoreRepository
+	seasonRepo repository.SeasonRepository
+}
+
+func NewGetGroupStandings(groupRepo repository.GroupRepository, userRepo repository.UserRepository, scoreRepo repository.ScoreRepository, seasonRepo repository.SeasonRepository) *GetGroupStandings {
+	return &GetGroupStandings{
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		scoreRepo:  scoreRepo,
+		seasonRepo: seasonRepo,
+	}
+}
+
+// @Summary get group standings
+// @Description get all-time and seasonal point standings of the group. When season_id is given, the seasonal standings cover that season, and a closed season returns the standings frozen at closing. Otherwise the season defaults to the current year.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param from query string false "season start (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "season end, exclusive (YYYY-MM-DD or RFC3339)"
+// @Param season_id query string false "Season ID. Takes precedence over from and to"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.GetGroupStandingsResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/standings [get]
+func (g *GetGroupStandings) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	from, err := parsePeriodParam(c.QueryParam("from"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("from の形式が正しくありません"))
+	}
+	to, err := parsePeriodParam(c.QueryParam("to"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("to の形式が正しくありません"))
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("from は to より前の日時を指定してください"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewGetGroupStandingsUseCase(g.groupRepo, g.userRepo, g.scoreRepo, g.seasonRepo, user.UserID, entity.GroupID(groupIDStr), entity.SeasonID(c.QueryParam("season_id")), from, to).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrSeasonNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("シーズンが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このグループの順位表を閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// parsePeriodParam は期間を表すクエリパラメータを日付または RFC3339 形式で解釈する。空の場合はゼロ値を返す
+func parsePeriodParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetLocationTrail struct {
+	eventRepo   repository.EventRepository
+	groupRepo   repository.GroupRepository
+	historyRepo repository.LocationHistoryRepository
+}
+
+func NewGetLocationTrail(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, historyRepo repository.LocationHistoryRepository) *GetLocationTrail {
+	return &GetLocationTrail{
+		eventRepo:   eventRepo,
+		groupRepo:   groupRepo,
+		historyRepo: historyRepo,
+	}
+}
+
+// @Summary get location trail
+// @Description replay a participant's recorded trail for an event (kept only for the retention period)
+// @Tags events
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param user_id path string true "User ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.LocationTrailResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/locations/{user_id}/trail [get]
+func (g *GetLocationTrail) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	userIDStr := c.Param("user_id")
+	if eventIDStr == "" || userIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDとユーザーIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewFetchLocationTrailUseCase(g.eventRepo, g.groupRepo, g.historyRepo, user.UserID, entity.EventID(eventIDStr), entity.UserID(userIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントを閲覧する権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetNotificationSettings struct {
+	settingsRepo repository.NotificationSettingsRepository
+}
+
+func NewGetNotificationSettings(settingsRepo repository.NotificationSettingsRepository) *GetNotificationSettings {
+	return &GetNotificationSettings{
+		settingsRepo: settingsRepo,
+	}
+}
+
+// @Summary get notification settings
+// @Description get which notifications the user receives. kinds lists every notification type; users who have never changed their settings receive everything
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} entity.NotificationSettings
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/notification-settings [get]
+func (g *GetNotificationSettings) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	settings, err := usecase.NewFetchNotificationSettingsUseCase(g.settingsRepo, user.UserID).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, settings)
+}
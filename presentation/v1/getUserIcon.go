@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetUserIcon struct {
+	blobStore service.BlobStore
+}
+
+func NewGetUserIcon(blobStore service.BlobStore) *GetUserIcon {
+	return &GetUserIcon{
+		blobStore: blobStore,
+	}
+}
+
+// @Summary get user icon
+// @Description get an icon uploaded with POST /users/me/icon as a JPEG
+// @Tags users
+// @Produce image/jpeg
+// @Param user_id path string true "user_id"
+// @Param size query int false "icon size in pixels (64, 128 or 256)" default(256)
+// @Success 200 {file} binary
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/{user_id}/icon [get]
+func (g *GetUserIcon) Handler(c echo.Context) error {
+	size := usecase.DefaultIconSize
+	if sizeParam := c.QueryParam("size"); sizeParam != "" {
+		parsed, err := strconv.Atoi(sizeParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("size は数値で指定してください"))
+		}
+		size = parsed
+	}
+
+	icon, err := usecase.NewFetchUserIconUseCase(g.blobStore, entity.UserID(c.Param("user_id")), size).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidIconSize):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("size は 64, 128, 256 のいずれかを指定してください"))
+		case errors.Is(err, usecase.ErrIconNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("アイコンが見つかりません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+	defer icon.Close()
+
+	// アップロードし直すと user_icon の URL が変わるため、しばらくキャッシュしてよい
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=86400")
+	return c.Stream(http.StatusOK, "image/jpeg", icon)
+}
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetUserStats struct {
+	eventRepo repository.EventRepository
+}
+
+func NewGetUserStats(eventRepo repository.EventRepository) *GetUserStats {
+	return &GetUserStats{
+		eventRepo: eventRepo,
+	}
+}
+
+// @Summary get user stats
+// @Description get lateness statistics of the user, overall and per group
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Success 200 {object} usecase.FetchUserStatsResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/{user_id}/stats [get]
+func (g *GetUserStats) Handler(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ユーザーIDは必須です"))
+	}
+
+	result, err := usecase.NewFetchUserStatsUseCase(g.eventRepo, entity.UserID(userIDParam)).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GetUserTitles struct {
+	titleRepo repository.TitleRepository
+}
+
+func NewGetUserTitles(titleRepo repository.TitleRepository) *GetUserTitles {
+	return &GetUserTitles{
+		titleRepo: titleRepo,
+	}
+}
+
+// @Summary get user titles
+// @Description get all titles the user has earned, including ones that have since been revoked. Filter by season with season_id.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user_id path string true "user_id"
+// @Param season_id query string false "Season ID"
+// @Success 200 {object} usecase.FetchUserTitlesResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/{user_id}/titles [get]
+func (g *GetUserTitles) Handler(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ユーザーIDは必須です"))
+	}
+
+	result, err := usecase.NewFetchUserTitlesUseCase(g.titleRepo, entity.UserID(userIDParam), entity.SeasonID(c.QueryParam("season_id"))).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,438 @@
+package v1
+
+import (
+	"bytes"
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/usecase"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar (RFC 5545) の読み書きに使う定義
+const (
+	icalProdID     = "-//Chikokulympic//Chikokulympic API//JA"
+	icalUIDDomain  = "chikokulympic"
+	icalTimeLayout = "20060102T150405Z"
+	icalLineLimit  = 75
+)
+
+// writeICalendar はイベントを購読用の VCALENDAR として書き出す。投票の締切は VALARM で通知する
+func writeICalendar(name string, events []entity.Event, now time.Time) []byte {
+	var buf bytes.Buffer
+	writeICalLine(&buf, "BEGIN:VCALENDAR")
+	writeICalLine(&buf, "VERSION:2.0")
+	writeICalLine(&buf, "PRODID:"+icalProdID)
+	writeICalLine(&buf, "CALSCALE:GREGORIAN")
+	writeICalLine(&buf, "METHOD:PUBLISH")
+	writeICalLine(&buf, "X-WR-CALNAME:"+escapeICalText(name))
+
+	stamp := now.UTC().Format(icalTimeLayout)
+	for _, event := range events {
+		writeICalLine(&buf, "BEGIN:VEVENT")
+		writeICalLine(&buf, fmt.Sprintf("UID:%s@%s", event.EventID, icalUIDDomain))
+		writeICalLine(&buf, "DTSTAMP:"+stamp)
+		writeICalLine(&buf, "DTSTART:"+formatICalTime(time.Time(event.EventStartDateTime)))
+		writeICalLine(&buf, "DTEND:"+formatICalTime(time.Time(event.EventEndDateTime)))
+		writeICalLine(&buf, "SUMMARY:"+escapeICalText(string(event.EventTitle)))
+		if event.EventDescription != "" {
+			writeICalLine(&buf, "DESCRIPTION:"+escapeICalText(string(event.EventDescription)))
+		}
+		if event.EventLocationName != "" {
+			writeICalLine(&buf, "LOCATION:"+escapeICalText(string(event.EventLocationName)))
+		}
+		if event.Latitude != 0 || event.Longitude != 0 {
+			writeICalLine(&buf, fmt.Sprintf("GEO:%.6f;%.6f", float64(event.Latitude), float64(event.Longitude)))
+		}
+
+		closing := time.Time(event.EventClosingDateTime)
+		if !closing.IsZero() {
+			writeICalLine(&buf, "BEGIN:VALARM")
+			writeICalLine(&buf, "ACTION:DISPLAY")
+			writeICalLine(&buf, "TRIGGER;VALUE=DATE-TIME:"+formatICalTime(closing))
+			writeICalLine(&buf, "DESCRIPTION:"+escapeICalText(fmt.Sprintf("「%s」の投票締切です", event.EventTitle)))
+			writeICalLine(&buf, "END:VALARM")
+		}
+		writeICalLine(&buf, "END:VEVENT")
+	}
+
+	writeICalLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeLayout)
+}
+
+// escapeICalText は TEXT 型の値で特別な意味を持つ文字をエスケープする
+func escapeICalText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeICalLine は 1 行が 75 オクテットを超えないよう、UTF-8 の文字の途中で切らずに折り返して書き込む
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// 継続行は先頭の空白の分だけ短くする
+		limit = icalLineLimit - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// icalProperty は "NAME;PARAM=VALUE:値" の 1 行分
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icalComponent は VEVENT と、その中の VALARM のプロパティ
+type icalComponent struct {
+	properties map[string]icalProperty
+	alarms     []map[string]icalProperty
+}
+
+var errICalNoCalendar = errors.New("VCALENDAR が見つかりません")
+
+var icalDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICalendarEvents は VCALENDAR に含まれる VEVENT をイベントとして読み取る
+// 読み取れない予定はファイル全体を失敗にせず、その予定だけ ParseErr を設定して返す
+func parseICalendarEvents(data []byte) ([]usecase.EventImportItem, error) {
+	var (
+		items      []usecase.EventImportItem
+		stack      []string
+		current    *icalComponent
+		alarm      map[string]icalProperty
+		inCalendar bool
+	)
+
+	for _, line := range unfoldICalLines(data) {
+		if line == "" {
+			continue
+		}
+		prop, err := parseICalProperty(line)
+		if err != nil {
+			if current != nil {
+				// 壊れた行は読み飛ばし、予定として必要な項目が欠けていればその予定を失敗にする
+				continue
+			}
+			return nil, err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			stack = append(stack, component)
+			switch {
+			case component == "VCALENDAR":
+				inCalendar = true
+			case component == "VEVENT" && len(stack) == 2:
+				current = &icalComponent{properties: map[string]icalProperty{}}
+			case component == "VALARM" && current != nil:
+				alarm = map[string]icalProperty{}
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("END:%s に対応する BEGIN がありません", component)
+			}
+			stack = stack[:len(stack)-1]
+			switch {
+			case component == "VALARM" && alarm != nil:
+				current.alarms = append(current.alarms, alarm)
+				alarm = nil
+			case component == "VEVENT" && current != nil:
+				items = append(items, buildImportItem(current))
+				current = nil
+			}
+			continue
+		}
+
+		switch {
+		case alarm != nil:
+			alarm[prop.name] = prop
+		case current != nil && len(stack) == 2:
+			// 同じプロパティが複数ある場合は最初のものを使う
+			if _, ok := current.properties[prop.name]; !ok {
+				current.properties[prop.name] = prop
+			}
+		}
+	}
+
+	if !inCalendar {
+		return nil, errICalNoCalendar
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("BEGIN:%s が閉じられていません", stack[len(stack)-1])
+	}
+	return items, nil
+}
+
+func buildImportItem(component *icalComponent) usecase.EventImportItem {
+	props := component.properties
+	item := usecase.EventImportItem{UID: props["UID"].value}
+
+	event := &entity.Event{
+		EventTitle:        entity.EventTitle(unescapeICalText(props["SUMMARY"].value)),
+		EventDescription:  entity.EventDescription(unescapeICalText(props["DESCRIPTION"].value)),
+		EventLocationName: entity.LocationName(unescapeICalText(props["LOCATION"].value)),
+	}
+	item.Event = event
+
+	if _, ok := props["RRULE"]; ok {
+		item.ParseErr = errors.New("繰り返しの予定は取り込めません")
+		return item
+	}
+	if strings.EqualFold(props["STATUS"].value, "CANCELLED") {
+		item.ParseErr = errors.New("キャンセルされた予定です")
+		return item
+	}
+
+	if geo, ok := props["GEO"]; ok {
+		latitude, longitude, err := parseICalGeo(geo.value)
+		if err != nil {
+			item.ParseErr = err
+			return item
+		}
+		event.Latitude = entity.Latitude(latitude)
+		event.Longitude = entity.Longitude(longitude)
+	}
+
+	startProp, ok := props["DTSTART"]
+	if !ok {
+		item.ParseErr = errors.New("DTSTART がありません")
+		return item
+	}
+	start, isDate, err := parseICalDateTime(startProp)
+	if err != nil {
+		item.ParseErr = fmt.Errorf("DTSTART を読み取れません: %w", err)
+		return item
+	}
+
+	// 終了がない場合、日付だけの予定は 1 日、日時の予定は開始と同時に終わる (RFC 5545 3.6.1)
+	end := start
+	if isDate {
+		end = start.AddDate(0, 0, 1)
+	}
+	if endProp, ok := props["DTEND"]; ok {
+		end, _, err = parseICalDateTime(endProp)
+		if err != nil {
+			item.ParseErr = fmt.Errorf("DTEND を読み取れません: %w", err)
+			return item
+		}
+	} else if durationProp, ok := props["DURATION"]; ok {
+		duration, err := parseICalDuration(durationProp.value)
+		if err != nil {
+			item.ParseErr = fmt.Errorf("DURATION を読み取れません: %w", err)
+			return item
+		}
+		end = start.Add(duration)
+	}
+	if end.Before(start) {
+		item.ParseErr = errors.New("終了日時が開始日時より前です")
+		return item
+	}
+
+	event.EventStartDateTime = entity.StartDateTIme(start)
+	event.EventEndDateTime = entity.EndDateTime(end)
+	event.EventClosingDateTime = entity.EventClosingDateTime(icalClosingTime(component.alarms, start, end))
+	return item
+}
+
+// icalClosingTime は最初の VALARM の通知時刻を投票の締切にする。通知がなければ開始日時を締切にする
+func icalClosingTime(alarms []map[string]icalProperty, start time.Time, end time.Time) time.Time {
+	for _, alarm := range alarms {
+		trigger, ok := alarm["TRIGGER"]
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(trigger.params["VALUE"], "DATE-TIME") {
+			if t, err := time.Parse(icalTimeLayout, trigger.value); err == nil {
+				return t
+			}
+			continue
+		}
+		duration, err := parseICalDuration(trigger.value)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(trigger.params["RELATED"], "END") {
+			return end.Add(duration)
+		}
+		return start.Add(duration)
+	}
+	return start
+}
+
+// unfoldICalLines は空白で始まる継続行を前の行につなげる
+func unfoldICalLines(data []byte) []string {
+	raw := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, line := range raw {
+		line = strings.TrimSuffix(line, "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseICalProperty は 1 行をプロパティ名、パラメータ、値に分ける。引用符で囲まれたパラメータ内の区切り文字は無視する
+func parseICalProperty(line string) (icalProperty, error) {
+	inQuote := false
+	separators := []int{}
+	colon := -1
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == ';' && !inQuote:
+			separators = append(separators, i)
+		case r == ':' && !inQuote:
+			colon = i
+		}
+		if colon >= 0 {
+			break
+		}
+	}
+	if colon < 0 {
+		return icalProperty{}, fmt.Errorf("iCalendar の行を読み取れません: %q", line)
+	}
+
+	head := line[:colon]
+	prop := icalProperty{params: map[string]string{}, value: line[colon+1:]}
+
+	nameEnd := len(head)
+	if len(separators) > 0 {
+		nameEnd = separators[0]
+	}
+	prop.name = strings.ToUpper(head[:nameEnd])
+
+	for i, sep := range separators {
+		paramEnd := len(head)
+		if i+1 < len(separators) {
+			paramEnd = separators[i+1]
+		}
+		key, value, _ := strings.Cut(head[sep+1:paramEnd], "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// parseICalDateTime は日時を読み取る。日付だけの場合は 2 番目の戻り値が true になる
+// TZID がないか読み込めないタイムゾーンの場合は既定のタイムゾーンとして扱う
+func parseICalDateTime(prop icalProperty) (time.Time, bool, error) {
+	location := icalLocation(prop.params["TZID"])
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", prop.value, location)
+		return t, true, err
+	}
+	if strings.HasSuffix(prop.value, "Z") {
+		t, err := time.Parse(icalTimeLayout, prop.value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("20060102T150405", prop.value, location)
+	return t, false, err
+}
+
+func icalLocation(tzid string) *time.Location {
+	if tzid != "" {
+		if location, err := time.LoadLocation(tzid); err == nil {
+			return location
+		}
+	}
+	location, err := time.LoadLocation(entity.DefaultTimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// parseICalDuration は "-PT30M" や "P1DT2H" のような期間を読み取る
+func parseICalDuration(value string) (time.Duration, error) {
+	match := icalDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if match == nil || strings.Join(match[2:], "") == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		duration += time.Duration(n) * unit
+	}
+	if match[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+func parseICalGeo(value string) (float64, float64, error) {
+	latText, lonText, ok := strings.Cut(value, ";")
+	if !ok {
+		return 0, 0, fmt.Errorf("GEO を読み取れません: %q", value)
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return 0, 0, fmt.Errorf("GEO を読み取れません: %q", value)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lonText), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, fmt.Errorf("GEO を読み取れません: %q", value)
+	}
+	return latitude, longitude, nil
+}
+
+// unescapeICalText は escapeICalText の逆変換
+func unescapeICalText(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+				continue
+			}
+			b.WriteRune(r)
+			continue
+		}
+		escaped = false
+		switch r {
+		case 'n', 'N':
+			b.WriteRune('\n')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 取り込めるファイルの上限
+const icalImportMaxSize = 1 << 20
+
+type ImportEvents struct {
+	eventRepo  repository.EventRepository
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+}
+
+func NewImportEvents(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository) *ImportEvents {
+	return &ImportEvents{
+		eventRepo:  eventRepo,
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+	}
+}
+
+// @Summary import events from iCalendar
+// @Description import the VEVENTs of an uploaded .ics file as events of the group. Each VEVENT is reported as created, skipped or failed. Events already imported into the group with the same UID are skipped, so importing the same file again creates nothing new. The first VALARM becomes the voting deadline. Recurring VEVENTs are not supported.
+// @Tags groups
+// @Accept multipart/form-data
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param file formData file true ".ics file (up to 1 MiB)"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.ImportEventsResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/events/import [post]
+func (i *ImportEvents) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("file に .ics ファイルを指定してください"))
+	}
+	if fileHeader.Size > icalImportMaxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, middleware.NewErrorResponse(fmt.Sprintf("ファイルは %d バイト以下にしてください", icalImportMaxSize)))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, icalImportMaxSize))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	items, err := parseICalendarEvents(data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(fmt.Sprintf("iCalendar ファイルを読み取れません: %v", err)))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewImportEventsUseCase(i.eventRepo, i.groupRepo, i.seasonRepo, user.UserID, entity.GroupID(groupIDStr), items).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このグループにイベントを取り込む権限がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -23,12 +23,14 @@ type JoinGroupResponse struct {
 type JoinGroup struct {
 	userRepo  repository.UserRepository
 	groupRepo repository.GroupRepository
+	cache     *usecase.LeaderboardCache
 }
 
-func NewJoinGroup(userRepo repository.UserRepository, groupRepo repository.GroupRepository) *JoinGroup {
+func NewJoinGroup(userRepo repository.UserRepository, groupRepo repository.GroupRepository, cache *usecase.LeaderboardCache) *JoinGroup {
 	return &JoinGroup{
 		userRepo:  userRepo,
 		groupRepo: groupRepo,
+		cache:     cache,
 	}
 }
 
@@ -59,7 +61,7 @@ func (j *JoinGroup) Handler(c echo.Context) error {
 
 	userID := entity.UserID(req.UserID)
 
-	groupID, err := usecase.NewJoinGroupUseCase(j.groupRepo, j.userRepo, userID, *group).Execute()
+	groupID, err := usecase.NewJoinGroupUseCase(j.groupRepo, j.userRepo, j.cache, userID, *group).Execute()
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
 	}
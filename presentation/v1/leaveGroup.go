@@ -16,11 +16,13 @@ type LeaveGroupRequest struct {
 
 type LeaveGroup struct {
 	groupRepo repository.GroupRepository
+	cache     *usecase.LeaderboardCache
 }
 
-func NewLeaveGroup(groupRepo repository.GroupRepository) *LeaveGroup {
+func NewLeaveGroup(groupRepo repository.GroupRepository, cache *usecase.LeaderboardCache) *LeaveGroup {
 	return &LeaveGroup{
 		groupRepo: groupRepo,
+		cache:     cache,
 	}
 }
 
@@ -56,7 +58,7 @@ func (l *LeaveGroup) Handler(c echo.Context) error {
 	groupID := entity.GroupID(groupIDParam)
 	userID := entity.UserID(req.UserID)
 
-	err := usecase.NewLeaveGroupUseCase(l.groupRepo, userID, groupID).Execute()
+	err := usecase.NewLeaveGroupUseCase(l.groupRepo, l.cache, userID, groupID).Execute()
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
 	}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MarkPaymentRequest struct {
+	Paid bool `json:"paid" example:"true"`
+}
+
+type MarkPayment struct {
+	eventRepo repository.EventRepository
+}
+
+func NewMarkPayment(eventRepo repository.EventRepository) *MarkPayment {
+	return &MarkPayment{
+		eventRepo: eventRepo,
+	}
+}
+
+// @Summary mark payment
+// @Description mark a participant's share of the event cost as paid or unpaid. Only the event author, who paid the cost up front, can change it.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param user_id path string true "User ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body MarkPaymentRequest true "request"
+// @Success 200 {object} usecase.SettlementShare
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/settlement/payments/{user_id} [put]
+func (m *MarkPayment) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	userIDStr := c.Param("user_id")
+	if eventIDStr == "" || userIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDとユーザーIDは必須です"))
+	}
+
+	req := new(MarkPaymentRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	share, err := usecase.NewMarkPaymentUseCase(m.eventRepo, user.UserID, entity.EventID(eventIDStr), entity.UserID(userIDStr), req.Paid).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotEventAuthor):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("イベントの作成者のみが支払い状況を変更できます"))
+		case errors.Is(err, usecase.ErrNotSettlementParticipant):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("このユーザーには支払う費用がありません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, share)
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PatchEventRequest は省略した項目を変更しない
+type PatchEventRequest struct {
+	EventTitle           *entity.EventTitle       `json:"event_title,omitempty" example:"テストイベント"`
+	EventDescription     *entity.EventDescription `json:"event_description,omitempty" example:"これはテストイベントです"`
+	EventLocationName    *entity.LocationName     `json:"event_location_name,omitempty" example:"東京ドーム"`
+	Cost                 *entity.Cost             `json:"cost,omitempty" example:"1000"`
+	EventMessage         *entity.EventMessage     `json:"event_message,omitempty" example:"参加してください！"`
+	Latitude             *entity.Latitude         `json:"latitude,omitempty" example:"35.6895"`
+	Longitude            *entity.Longitude        `json:"longitude,omitempty" example:"139.6917"`
+	EventStartDateTime   *time.Time               `json:"event_start_date_time,omitempty" example:"2023-10-01T10:00:00Z"`
+	EventEndDateTime     *time.Time               `json:"event_end_date_time,omitempty" example:"2023-10-01T12:00:00Z"`
+	EventClosingDateTime *time.Time               `json:"event_closing_date_time,omitempty" example:"2023-09-30T23:59:59Z"`
+}
+
+type PatchEventResponse struct {
+	Events []entity.Event `json:"events"`
+}
+
+type PatchEvent struct {
+	eventRepo  repository.EventRepository
+	seriesRepo repository.EventSeriesRepository
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+}
+
+func NewPatchEvent(eventRepo repository.EventRepository, seriesRepo repository.EventSeriesRepository, groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository) *PatchEvent {
+	return &PatchEvent{
+		eventRepo:  eventRepo,
+		seriesRepo: seriesRepo,
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+	}
+}
+
+// @Summary update event
+// @Description update an event. Only the event author can edit it. For an occurrence of a recurring event, scope=following also applies the change to all later occurrences and to occurrences created in the future. Moving the start time without an end or closing time moves them by the same amount. Votes and arrivals of each occurrence are kept.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param scope query string false "this or following" default(this)
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body PatchEventRequest true "request"
+// @Success 200 {object} PatchEventResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id} [patch]
+func (p *PatchEvent) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	req := new(PatchEventRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	patch := usecase.EventPatch{
+		EventTitle:           req.EventTitle,
+		EventDescription:     req.EventDescription,
+		EventLocationName:    req.EventLocationName,
+		Cost:                 req.Cost,
+		EventMessage:         req.EventMessage,
+		Latitude:             req.Latitude,
+		Longitude:            req.Longitude,
+		EventStartDateTime:   req.EventStartDateTime,
+		EventEndDateTime:     req.EventEndDateTime,
+		EventClosingDateTime: req.EventClosingDateTime,
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	events, err := usecase.NewUpdateEventUseCase(p.eventRepo, p.seriesRepo, p.groupRepo, p.seasonRepo, user.UserID, entity.EventID(eventIDStr), patch, usecase.EditScope(c.QueryParam("scope"))).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidEditScope):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("scope は this または following を指定してください"))
+		case errors.Is(err, usecase.ErrInvalidEventTime):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("終了日時は開始日時より後にしてください"))
+		case errors.Is(err, usecase.ErrNotRecurringEvent):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("繰り返しイベントではないため following は指定できません"))
+		case errors.Is(err, usecase.ErrEventNotFound), errors.Is(err, usecase.ErrGroupNotFound), errors.Is(err, usecase.ErrEventSeriesMissing):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotEventAuthor):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("イベントの作成者のみが編集できます"))
+		case errors.Is(err, usecase.ErrEventAlreadyFinalized):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("確定済みのイベントは編集できません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, &PatchEventResponse{Events: events})
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ArrivalSample struct {
+	Latitude   entity.Latitude  `json:"latitude" example:"35.6895"`
+	Longitude  entity.Longitude `json:"longitude" example:"139.6917"`
+	RecordedAt time.Time        `json:"recorded_at" example:"2023-10-01T09:58:00Z"`
+}
+
+type PostArrivalRequest struct {
+	Samples []ArrivalSample `json:"samples"`
+}
+
+type PostArrival struct {
+	eventRepo   repository.EventRepository
+	groupRepo   repository.GroupRepository
+	historyRepo repository.LocationHistoryRepository
+	policy      usecase.ArrivalPolicy
+	notifier    service.Notifier
+}
+
+func NewPostArrival(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, historyRepo repository.LocationHistoryRepository, policy usecase.ArrivalPolicy, notifier service.Notifier) *PostArrival {
+	return &PostArrival{
+		eventRepo:   eventRepo,
+		groupRepo:   groupRepo,
+		historyRepo: historyRepo,
+		policy:      policy,
+		notifier:    notifier,
+	}
+}
+
+// @Summary post arrival
+// @Description claim arrival at the event venue. The claim is verified against the location trail the server received through location sharing and any samples sent with the request. Samples dated more than a minute after the request or more than 30 minutes before it are ignored. Claims that the server-received trail does not confirm, and other suspicious claims, are flagged for the event author to review.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body PostArrivalRequest false "request"
+// @Success 200 {object} usecase.RecordArrivalResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 422 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/arrival [post]
+func (p *PostArrival) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	req := new(PostArrivalRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	samples := make([]entity.UserLocation, 0, len(req.Samples))
+	for _, sample := range req.Samples {
+		samples = append(samples, entity.UserLocation{
+			Latitude:   sample.Latitude,
+			Longitude:  sample.Longitude,
+			RecordedAt: sample.RecordedAt,
+		})
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewRecordArrivalUseCase(p.eventRepo, p.groupRepo, p.historyRepo, p.policy, p.notifier, user, entity.EventID(eventIDStr), samples).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントに到着を報告する権限がありません"))
+		case errors.Is(err, usecase.ErrNotAttending):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントに参加で投票していません"))
+		case errors.Is(err, usecase.ErrAlreadyArrived):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("すでに到着済みです"))
+		case errors.Is(err, usecase.ErrOutsideEventWindow):
+			return c.JSON(http.StatusUnprocessableEntity, middleware.NewErrorResponse("イベントの開催時間外です"))
+		case errors.Is(err, usecase.ErrArrivalNotVerified):
+			return c.JSON(http.StatusUnprocessableEntity, middleware.NewErrorResponse("会場での滞在が確認できませんでした。しばらく会場にいてから再度お試しください"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
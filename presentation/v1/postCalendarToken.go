@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PostCalendarTokenResponse struct {
+	Token entity.CalendarToken `json:"token" example:"3f2a..."`
+	// カレンダーアプリに登録する購読用のパス
+	FeedPath string `json:"feed_path" example:"/users/user123/calendar.ics?token=3f2a..."`
+}
+
+type PostCalendarToken struct {
+	userRepo repository.UserRepository
+}
+
+func NewPostCalendarToken(userRepo repository.UserRepository) *PostCalendarToken {
+	return &PostCalendarToken{
+		userRepo: userRepo,
+	}
+}
+
+// @Summary issue calendar feed token
+// @Description issue a secret token for subscribing to the user's events from a calendar app. Issuing a new token invalidates the previous one.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 201 {object} PostCalendarTokenResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/calendar-token [post]
+func (p *PostCalendarToken) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	token, err := usecase.NewIssueCalendarTokenUseCase(p.userRepo, user.UserID).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, &PostCalendarTokenResponse{
+		Token:    token,
+		FeedPath: fmt.Sprintf("/users/%s/calendar.ics?token=%s", url.PathEscape(string(user.UserID)), url.QueryEscape(string(token))),
+	})
+}
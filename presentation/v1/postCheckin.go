@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PostCheckinRequest struct {
+	Code string `json:"code" validate:"required" example:"492039"`
+}
+
+type PostCheckin struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	policy    usecase.CheckinCodePolicy
+	limiter   *usecase.CheckinAttemptLimiter
+	notifier  service.Notifier
+}
+
+func NewPostCheckin(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, policy usecase.CheckinCodePolicy, limiter *usecase.CheckinAttemptLimiter, notifier service.Notifier) *PostCheckin {
+	return &PostCheckin{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		policy:    policy,
+		limiter:   limiter,
+		notifier:  notifier,
+	}
+}
+
+// @Summary post check-in
+// @Description record arrival with the check-in code displayed by the event author at the venue
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body PostCheckinRequest true "request"
+// @Success 200 {object} entity.VotedMember
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 422 {object} middleware.ErrorResponse
+// @Failure 429 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/checkin [post]
+func (p *PostCheckin) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	req := new(PostCheckinRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+	if req.Code == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("チェックインコードは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	member, err := usecase.NewCheckinEventUseCase(p.eventRepo, p.groupRepo, p.policy, p.limiter, p.notifier, user, entity.EventID(eventIDStr), req.Code).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントにチェックインする権限がありません"))
+		case errors.Is(err, usecase.ErrNotAttending):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントに参加で投票していません"))
+		case errors.Is(err, usecase.ErrAlreadyArrived):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("すでに到着済みです"))
+		case errors.Is(err, usecase.ErrOutsideEventWindow):
+			return c.JSON(http.StatusUnprocessableEntity, middleware.NewErrorResponse("イベントの開催時間外です"))
+		case errors.Is(err, usecase.ErrInvalidCheckinCode):
+			return c.JSON(http.StatusUnprocessableEntity, middleware.NewErrorResponse("チェックインコードが正しくないか、有効期限が切れています"))
+		case errors.Is(err, usecase.ErrCheckinLocked):
+			return c.JSON(http.StatusTooManyRequests, middleware.NewErrorResponse("チェックインコードの入力に続けて失敗したため、しばらくチェックインできません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
@@ -3,8 +3,10 @@ package v1
 import (
 	"chikokulympic-api/domain/entity"
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
 	"chikokulympic-api/middleware"
 	"chikokulympic-api/usecase"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -18,38 +20,55 @@ type PostEventRequest struct {
 	EventLocationName    entity.LocationName         `json:"event_location_name" example:"東京ドーム"`
 	Cost                 entity.Cost                 `json:"cost" example:"1000"`
 	EventMessage         entity.EventMessage         `json:"event_message" example:"参加してください！"`
-	EventAuthorID        entity.UserID               `json:"event_author_id" example:"user123"`
 	Latitude             entity.Latitude             `json:"latitude" example:"35.6895"`
 	Longitude            entity.Longitude            `json:"longitude" example:"139.6917"`
 	EventStartDateTime   entity.StartDateTIme        `json:"event_start_date_time" example:"2023-10-01T10:00:00Z"`
 	EventEndDateTime     entity.EndDateTime          `json:"event_end_date_time" example:"2023-10-01T12:00:00Z"`
 	EventClosingDateTime entity.EventClosingDateTime `json:"event_closing_date_time" example:"2023-09-30T23:59:59Z"`
+	// 参加の上限人数。0 または省略した場合は上限なし
+	Capacity int `json:"capacity,omitempty" example:"8"`
+	// 指定した場合は繰り返しイベントとして作成し、このイベントを最初の回にする
+	Recurrence *entity.RecurrenceRule `json:"recurrence,omitempty" swaggertype:"string" example:"FREQ=WEEKLY;BYDAY=FR"`
+	TimeZone   string                 `json:"time_zone,omitempty" example:"Asia/Tokyo"`
 }
 
 type PostEventResponse struct {
-	EventID entity.EventID `json:"event_id" example:"event123"`
+	EventID  entity.EventID       `json:"event_id" example:"event123"`
+	SeriesID entity.EventSeriesID `json:"series_id,omitempty" example:"series123"`
 }
 
 type PostEvent struct {
-	groupRepo repository.GroupRepository
-	eventRepo repository.EventRepository
+	groupRepo    repository.GroupRepository
+	eventRepo    repository.EventRepository
+	seasonRepo   repository.SeasonRepository
+	seriesRepo   repository.EventSeriesRepository
+	materializer *usecase.EventSeriesMaterializer
+	notifier     service.Notifier
 }
 
-func NewPostEvent(groupRepo repository.GroupRepository, eventRepo repository.EventRepository) *PostEvent {
+func NewPostEvent(groupRepo repository.GroupRepository, eventRepo repository.EventRepository, seasonRepo repository.SeasonRepository, seriesRepo repository.EventSeriesRepository, materializer *usecase.EventSeriesMaterializer, notifier service.Notifier) *PostEvent {
 	return &PostEvent{
-		groupRepo: groupRepo,
-		eventRepo: eventRepo,
+		groupRepo:    groupRepo,
+		eventRepo:    eventRepo,
+		seasonRepo:   seasonRepo,
+		seriesRepo:   seriesRepo,
+		materializer: materializer,
+		notifier:     notifier,
 	}
 }
 
 // @Summary create event
-// @Description create a new event
+// @Description create a new event authored by the authenticated user. Only members of the group can create events in it. The event joins the group's season whose period contains its start time. When recurrence is given as an RFC 5545 RRULE (weekly or monthly), the event becomes the first occurrence of a series and later occurrences are created ahead of time as separate events. capacity limits the number of attendees; further attend votes join a waitlist.
 // @Tags events
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
 // @Param request body PostEventRequest true "request"
 // @Success 201 {object} PostEventResponse
 // @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
 // @Failure 500 {object} middleware.ErrorResponse
 // @Router /events [post]
 func (p *PostEvent) Handler(c echo.Context) error {
@@ -65,16 +84,54 @@ func (p *PostEvent) Handler(c echo.Context) error {
 		EventLocationName:    req.EventLocationName,
 		Cost:                 req.Cost,
 		EventMessage:         req.EventMessage,
-		EventAuthorID:        req.EventAuthorID,
+		EventAuthorID:        middleware.GetAuthUser(c).UserID,
 		Latitude:             req.Latitude,
 		Longitude:            req.Longitude,
 		EventStartDateTime:   req.EventStartDateTime,
 		EventEndDateTime:     req.EventEndDateTime,
 		EventClosingDateTime: req.EventClosingDateTime,
+		Capacity:             req.Capacity,
 	}
 
-	createdEvent, err := usecase.NewCreateEventUseCase(p.eventRepo, p.groupRepo, event, req.GroupID).Execute()
+	if req.Capacity < 0 {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("参加の上限人数には 0 以上の値を指定してください"))
+	}
+
+	if req.Recurrence != nil {
+		result, err := usecase.NewCreateEventSeriesUseCase(p.seriesRepo, p.groupRepo, p.materializer, p.notifier, event, req.GroupID, *req.Recurrence, req.TimeZone).Execute()
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidEventTime):
+				return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("終了日時は開始日時より後にしてください"))
+			case errors.Is(err, usecase.ErrInvalidTimeZone):
+				return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("タイムゾーンが正しくありません"))
+			case errors.Is(err, entity.ErrInvalidRecurrenceRule):
+				return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+			case errors.Is(err, usecase.ErrRecurrenceStartMismatch):
+				return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("開始日時が繰り返しのルールと一致しません"))
+			case errors.Is(err, usecase.ErrGroupNotFound):
+				return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+			case errors.Is(err, usecase.ErrNotGroupMember):
+				return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("グループのメンバーのみがイベントを作成できます"))
+			}
+			return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+		}
+
+		response := &PostEventResponse{SeriesID: result.Series.SeriesID}
+		if result.FirstEvent != nil {
+			response.EventID = result.FirstEvent.EventID
+		}
+		return c.JSON(http.StatusCreated, response)
+	}
+
+	createdEvent, err := usecase.NewCreateEventUseCase(p.eventRepo, p.groupRepo, p.seasonRepo, p.notifier, event, req.GroupID).Execute()
 	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("グループのメンバーのみがイベントを作成できます"))
+		}
 		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
 	}
 
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PostFinalizeEvent struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+	scoreRepo repository.ScoreRepository
+	titleRepo repository.TitleRepository
+	cache     *usecase.LeaderboardCache
+	notifier  service.Notifier
+}
+
+func NewPostFinalizeEvent(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, cache *usecase.LeaderboardCache, notifier service.Notifier) *PostFinalizeEvent {
+	return &PostFinalizeEvent{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		scoreRepo: scoreRepo,
+		titleRepo: titleRepo,
+		cache:     cache,
+		notifier:  notifier,
+	}
+}
+
+// @Summary finalize event
+// @Description finalize an ended event and award points to participants according to the group's scoring rule. Titles are re-evaluated from the updated scores. Only the event author can finalize.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Success 200 {object} usecase.FinalizeEventResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/finalize [post]
+func (p *PostFinalizeEvent) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewFinalizeEventUseCase(p.eventRepo, p.groupRepo, p.userRepo, p.scoreRepo, p.titleRepo, p.cache, p.notifier, user.UserID, entity.EventID(eventIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが属するグループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotEventAuthor):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("イベントの作成者のみが結果を確定できます"))
+		case errors.Is(err, usecase.ErrEventNotEnded):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("イベントはまだ終了していません"))
+		case errors.Is(err, usecase.ErrEventAlreadyFinalized):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("イベントの結果はすでに確定しています"))
+		case errors.Is(err, usecase.ErrPendingArrivalReview):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("確認待ちの到着報告があります。先に承認または却下してください"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PostSeasonRequest struct {
+	Name          entity.SeasonName `json:"name" example:"2024 春シーズン"`
+	StartDateTime time.Time         `json:"start_date_time" example:"2024-04-01T00:00:00+09:00"`
+	EndDateTime   time.Time         `json:"end_date_time" example:"2024-07-01T00:00:00+09:00"`
+}
+
+type PostSeason struct {
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	eventRepo  repository.EventRepository
+	scoreRepo  repository.ScoreRepository
+}
+
+func NewPostSeason(groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, eventRepo repository.EventRepository, scoreRepo repository.ScoreRepository) *PostSeason {
+	return &PostSeason{
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		eventRepo:  eventRepo,
+		scoreRepo:  scoreRepo,
+	}
+}
+
+// @Summary create season
+// @Description create a season for the group. Only the group manager can create it. Existing events starting within the period are assigned to the season. The end time is exclusive and seasons must not overlap.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body PostSeasonRequest true "request"
+// @Success 201 {object} entity.Season
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/seasons [post]
+func (p *PostSeason) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	req := new(PostSeasonRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	season, err := usecase.NewCreateSeasonUseCase(p.groupRepo, p.seasonRepo, p.eventRepo, p.scoreRepo, user.UserID, entity.GroupID(groupIDStr), req.Name, req.StartDateTime, req.EndDateTime).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidSeason):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("シーズン名と、終了日時より前の開始日時を指定してください"))
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupManager):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("グループの管理者のみがシーズンを作成できます"))
+		case errors.Is(err, usecase.ErrSeasonOverlap):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("既存のシーズンと期間が重なっています"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, season)
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// アップロードできる画像の上限
+const iconUploadMaxSize = 5 << 20
+
+type PostUserIcon struct {
+	userRepo  repository.UserRepository
+	blobStore service.BlobStore
+}
+
+func NewPostUserIcon(userRepo repository.UserRepository, blobStore service.BlobStore) *PostUserIcon {
+	return &PostUserIcon{
+		userRepo:  userRepo,
+		blobStore: blobStore,
+	}
+}
+
+// @Summary upload user icon
+// @Description upload a JPEG or PNG image as the user's icon. The image is rotated according to its EXIF orientation, cropped to a centred square and resized to 64, 128 and 256 pixel JPEGs without any metadata. user_icon in the response points to GET /users/{user_id}/icon.
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param icon formData file true "JPEG or PNG image (up to 5 MiB)"
+// @Success 200 {object} UpdateUserResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/icon [post]
+func (p *PostUserIcon) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	fileHeader, err := c.FormFile("icon")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("icon に画像ファイルを指定してください"))
+	}
+	if fileHeader.Size > iconUploadMaxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, middleware.NewErrorResponse(fmt.Sprintf("画像は %d バイト以下にしてください", iconUploadMaxSize)))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, iconUploadMaxSize))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	updatedUser, err := usecase.NewUploadUserIconUseCase(p.userRepo, p.blobStore, user.UserID, data).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnsupportedImage):
+			return c.JSON(http.StatusUnsupportedMediaType, middleware.NewErrorResponse("JPEG または PNG の画像を指定してください"))
+		case errors.Is(err, usecase.ErrImageTooLarge):
+			return c.JSON(http.StatusRequestEntityTooLarge, middleware.NewErrorResponse("画像の縦横が大きすぎます"))
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("ユーザーが見つかりません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, newUpdateUserResponse(updatedUser))
+}
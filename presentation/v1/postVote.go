@@ -3,44 +3,55 @@ package v1
 import (
 	"chikokulympic-api/domain/entity"
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
 	"chikokulympic-api/middleware"
 	"chikokulympic-api/usecase"
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
 
 type PostVoteRequest struct {
-	UserID entity.UserID `json:"user_id" validate:"required" example:"user123"`
-	Option entity.Vote   `json:"option" validate:"required" example:"参加"`
+	Option entity.Vote `json:"option" validate:"required" example:"参加"`
+}
+
+type PostVoteResponse struct {
+	Waitlisted bool `json:"waitlisted" example:"true"`
+	// キャンセル待ちの何番目に並んでいるか。キャンセル待ちでない場合は 0
+	WaitlistPosition int `json:"waitlist_position" example:"2"`
 }
 
 type PostVote struct {
 	eventRepo repository.EventRepository
 	groupRepo repository.GroupRepository
 	userRepo  repository.UserRepository
+	notifier  service.Notifier
 }
 
-func NewPostVote(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository) *PostVote {
+func NewPostVote(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, notifier service.Notifier) *PostVote {
 	return &PostVote{
 		eventRepo: eventRepo,
 		groupRepo: groupRepo,
 		userRepo:  userRepo,
+		notifier:  notifier,
 	}
 }
 
 // @Summary post vote
-// @Description post a vote for an event
+// @Description post a vote for an event. When the event has a capacity and the attend option is full, the attend vote joins an ordered waitlist instead. Waitlisted members are promoted in order when an attendee changes their vote, and are notified.
 // @Tags events
 // @Accept json
 // @Produce json
 // @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
 // @Param request body PostVoteRequest true "request"
-// @Success 200
+// @Success 200 {object} PostVoteResponse
 // @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
 // @Failure 403 {object} middleware.ErrorResponse
 // @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
 // @Failure 500 {object} middleware.ErrorResponse
 // @Router /events/{event_id}/votes [post]
 func (p *PostVote) Handler(c echo.Context) error {
@@ -56,21 +67,17 @@ func (p *PostVote) Handler(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
 	}
 
-	if req.UserID == "" {
-		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ユーザーIDは必須です"))
-	}
 	if req.Option == "" {
 		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("投票オプションは必須です"))
 	}
 
-	userIDInt, err := strconv.Atoi(string(req.UserID))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("無効なユーザーIDです"))
-	}
-	userID := entity.UserID(strconv.Itoa(userIDInt))
+	userID := middleware.GetAuthUser(c).UserID
 
-	_, err = usecase.NewPostParticipationUseCase(p.eventRepo, p.groupRepo, &userID, &eventID, &req.Option).Execute()
+	event, err := usecase.NewPostParticipationUseCase(p.eventRepo, p.groupRepo, p.notifier, &userID, &eventID, &req.Option).Execute()
 	if err != nil {
+		if errors.Is(err, usecase.ErrVoteConflict) {
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("投票が混み合っています。もう一度お試しください"))
+		}
 		if err.Error() == "event not found" {
 			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
 		}
@@ -80,5 +87,14 @@ func (p *PostVote) Handler(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
 	}
 
-	return c.NoContent(http.StatusOK)
+	response := &PostVoteResponse{}
+	for i, entry := range event.Waitlist {
+		if entry.UserID == userID {
+			response.Waitlisted = true
+			response.WaitlistPosition = i + 1
+			break
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
 }
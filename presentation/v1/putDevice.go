@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PutDeviceRequest struct {
+	Token    entity.FCMToken       `json:"token" validate:"required" example:"fcm-token-123456"`
+	Platform entity.DevicePlatform `json:"platform" validate:"required" example:"ios" enums:"ios,android,web"`
+}
+
+type PutDevice struct {
+	deviceRepo repository.DeviceRepository
+}
+
+func NewPutDevice(deviceRepo repository.DeviceRepository) *PutDevice {
+	return &PutDevice{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// @Summary register device
+// @Description register or refresh the push notification token of one of the user's devices. device_id is chosen by the client and must stay the same across app launches. Call this on every launch to keep last_seen_at current.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param device_id path string true "Device ID"
+// @Param request body PutDeviceRequest true "request"
+// @Success 200 {object} entity.Device
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/devices/{device_id} [put]
+func (p *PutDevice) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	deviceID := entity.DeviceID(c.Param("device_id"))
+	if deviceID == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("端末IDは必須です"))
+	}
+
+	req := new(PutDeviceRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+	if req.Token == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("トークンは必須です"))
+	}
+	if !req.Platform.IsValid() {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("プラットフォームは ios, android, web のいずれかを指定してください"))
+	}
+
+	device, err := usecase.NewRegisterDeviceUseCase(p.deviceRepo, user.UserID, deviceID, req.Token, req.Platform).Execute()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PutNotificationSettingsRequest struct {
+	// 通知の種類ごとに受け取るかどうか。省略した種類は受け取る
+	Kinds map[entity.NotificationKind]bool `json:"kinds"`
+	// グループごとの設定。全体の設定より優先する
+	Groups []entity.GroupNotificationSettings `json:"groups"`
+	// 省略すると時間帯による制限をなくす
+	QuietHours *entity.QuietHours `json:"quiet_hours,omitempty"`
+}
+
+type PutNotificationSettings struct {
+	settingsRepo repository.NotificationSettingsRepository
+	groupRepo    repository.GroupRepository
+}
+
+func NewPutNotificationSettings(settingsRepo repository.NotificationSettingsRepository, groupRepo repository.GroupRepository) *PutNotificationSettings {
+	return &PutNotificationSettings{
+		settingsRepo: settingsRepo,
+		groupRepo:    groupRepo,
+	}
+}
+
+// @Summary update notification settings
+// @Description replace the user's notification settings. kinds toggles each notification type (new_event, ranking_posted, member_arrived, waitlist_promoted); omitted types stay enabled. groups overrides kinds for a group the user belongs to, or mutes it entirely. Notifications raised during quiet_hours, evaluated in its time_zone (Asia/Tokyo if omitted), are held and delivered when the quiet hours end.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body PutNotificationSettingsRequest true "request"
+// @Success 200 {object} entity.NotificationSettings
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/notification-settings [put]
+func (p *PutNotificationSettings) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
+	req := new(PutNotificationSettingsRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	if err := validateNotificationKinds(req.Kinds); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+	seen := make(map[entity.GroupID]bool, len(req.Groups))
+	for _, group := range req.Groups {
+		if group.GroupID == "" {
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+		}
+		if seen[group.GroupID] {
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(fmt.Sprintf("グループ %s の設定が重複しています", group.GroupID)))
+		}
+		seen[group.GroupID] = true
+		if err := validateNotificationKinds(group.Kinds); err != nil {
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+		}
+	}
+	if req.QuietHours != nil {
+		if req.QuietHours.TimeZone == "" {
+			req.QuietHours.TimeZone = entity.DefaultTimeZone
+		}
+		if err := req.QuietHours.Validate(); err != nil {
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(fmt.Sprintf("通知を受け取らない時間帯が正しくありません: %v", err)))
+		}
+	}
+
+	settings := entity.NotificationSettings{
+		UserID:     user.UserID,
+		Kinds:      req.Kinds,
+		Groups:     req.Groups,
+		QuietHours: req.QuietHours,
+	}
+
+	result, err := usecase.NewUpdateNotificationSettingsUseCase(p.settingsRepo, p.groupRepo, settings).Execute()
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotGroupMember) {
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("所属していないグループの通知は設定できません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func validateNotificationKinds(kinds map[entity.NotificationKind]bool) error {
+	for kind := range kinds {
+		if !kind.IsValid() {
+			return fmt.Errorf("通知の種類 %s はありません", kind)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewArrivalRequest struct {
+	Action          usecase.ArrivalReviewAction `json:"action" validate:"required" example:"approve"`
+	ArrivalDateTime *time.Time                  `json:"arrival_date_time,omitempty" example:"2023-10-01T10:05:00Z"`
+}
+
+type ReviewArrival struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+	cache     *usecase.LeaderboardCache
+	notifier  service.Notifier
+}
+
+func NewReviewArrival(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, cache *usecase.LeaderboardCache, notifier service.Notifier) *ReviewArrival {
+	return &ReviewArrival{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		cache:     cache,
+		notifier:  notifier,
+	}
+}
+
+// @Summary review arrival
+// @Description approve or reject a participant's arrival as the event author. arrival_date_time overrides the recorded arrival time when approving. Approving an arrival that was pending review notifies the other attendees. Arrivals can no longer be reviewed once the event is finalized.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param user_id path string true "User ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body ReviewArrivalRequest true "request"
+// @Success 200 {object} entity.VotedMember
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/arrivals/{user_id}/review [post]
+func (r *ReviewArrival) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	userIDStr := c.Param("user_id")
+	if eventIDStr == "" || userIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDとユーザーIDは必須です"))
+	}
+
+	req := new(ReviewArrivalRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	member, err := usecase.NewReviewArrivalUseCase(r.eventRepo, r.groupRepo, r.userRepo, r.cache, r.notifier, user.UserID, entity.EventID(eventIDStr), entity.UserID(userIDStr), req.Action, req.ArrivalDateTime).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidReviewAction):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("actionは approve か reject を指定してください"))
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotEventAuthor):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("イベントの作成者のみが到着を確認できます"))
+		case errors.Is(err, usecase.ErrArrivalNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("到着の報告が見つかりません"))
+		case errors.Is(err, usecase.ErrEventAlreadyFinalized):
+			return c.JSON(http.StatusConflict, middleware.NewErrorResponse("確定済みのイベントの到着は確認できません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
@@ -5,6 +5,7 @@ import (
 	"chikokulympic-api/domain/repository"
 	"chikokulympic-api/middleware"
 	"chikokulympic-api/usecase"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -39,6 +40,7 @@ func NewSignup(userRepo repository.UserRepository) *Signup {
 // @Param request body SignupRequest true "request"
 // @Success 201 {object} SignupResponse
 // @Failure 400 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
 // @Failure 500 {object} middleware.ErrorResponse
 // @Router /users/signup [post]
 func (s *Signup) Handler(c echo.Context) error {
@@ -59,6 +61,9 @@ func (s *Signup) Handler(c echo.Context) error {
 	}
 
 	registeredUser, err := usecase.NewRegisterUserUseCase(s.userRepo, user).Execute()
+	if errors.Is(err, repository.ErrDuplicateAuthID) {
+		return c.JSON(http.StatusConflict, middleware.NewErrorResponse("このAuthIDは既に登録されています"))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
 	}
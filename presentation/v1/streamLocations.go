@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	locationWriteTimeout = 10 * time.Second
+	locationPongTimeout  = 60 * time.Second
+	locationPingInterval = 30 * time.Second
+)
+
+// モバイルアプリからの接続のため Origin は検証しない
+var locationUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type LocationMessage struct {
+	Latitude  entity.Latitude  `json:"latitude" example:"35.6895"`
+	Longitude entity.Longitude `json:"longitude" example:"139.6917"`
+}
+
+type StreamLocations struct {
+	eventRepo    repository.EventRepository
+	groupRepo    repository.GroupRepository
+	locationRepo repository.LocationRepository
+	historyRepo  repository.LocationHistoryRepository
+	hub          service.LocationHub
+	throttle     *usecase.LocationThrottle
+}
+
+func NewStreamLocations(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, hub service.LocationHub, throttle *usecase.LocationThrottle) *StreamLocations {
+	return &StreamLocations{
+		eventRepo:    eventRepo,
+		groupRepo:    groupRepo,
+		locationRepo: locationRepo,
+		historyRepo:  historyRepo,
+		hub:          hub,
+		throttle:     throttle,
+	}
+}
+
+// @Summary stream participant locations
+// @Description share live locations with the other participants of an event over WebSocket until the event ends. Send LocationMessage frames, receive entity.UserLocation frames. Users who turned off sharing for the group only receive.
+// @Tags events
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string false "Bearer {auth_id}"
+// @Param auth_id query string false "auth_id (for clients that cannot set headers on WebSocket)"
+// @Success 101
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 410 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/locations/ws [get]
+func (s *StreamLocations) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	session, err := usecase.NewJoinLocationSharingUseCase(s.eventRepo, s.groupRepo, s.locationRepo, s.historyRepo, s.hub, s.throttle, user, entity.EventID(eventIDStr)).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupMember):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このイベントの位置情報を共有する権限がありません"))
+		case errors.Is(err, usecase.ErrLocationSharingClosed):
+			return c.JSON(http.StatusGone, middleware.NewErrorResponse("イベントが終了したため位置情報の共有はできません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+	defer session.Close()
+
+	conn, err := locationUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// Upgrader がエラーレスポンスを書き込み済み
+		return nil
+	}
+	defer conn.Close()
+
+	// 受信ループ。書き込みは下のループだけが行う
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+
+		conn.SetReadDeadline(time.Now().Add(locationPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(locationPongTimeout))
+		})
+
+		for {
+			var msg LocationMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+
+			err := session.Publish(msg.Latitude, msg.Longitude)
+			if errors.Is(err, usecase.ErrLocationSharingClosed) {
+				return
+			}
+			// 間引き・不正な座標・共有停止中の送信は接続を維持したまま破棄する
+		}
+	}()
+
+	endTimer := time.NewTimer(time.Until(session.EndsAt()))
+	defer endTimer.Stop()
+	pingTicker := time.NewTicker(locationPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case location, ok := <-session.Updates():
+			if !ok {
+				return nil
+			}
+			conn.SetWriteDeadline(time.Now().Add(locationWriteTimeout))
+			if err := conn.WriteJSON(location); err != nil {
+				return nil
+			}
+		case <-pingTicker.C:
+			conn.SetWriteDeadline(time.Now().Add(locationWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return nil
+			}
+		case <-endTimer.C:
+			closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "event ended")
+			conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(locationWriteTimeout))
+			return nil
+		case <-readDone:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UpdateLocationSharingRequest struct {
+	Enabled *bool `json:"enabled" validate:"required" example:"false"`
+}
+
+type UpdateLocationSharing struct {
+	userRepo  repository.UserRepository
+	groupRepo repository.GroupRepository
+}
+
+func NewUpdateLocationSharing(userRepo repository.UserRepository, groupRepo repository.GroupRepository) *UpdateLocationSharing {
+	return &UpdateLocationSharing{
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+	}
+}
+
+// @Summary update location sharing
+// @Description turn location sharing on or off for a group
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body UpdateLocationSharingRequest true "request"
+// @Success 200
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me/location-sharing/{group_id} [put]
+func (u *UpdateLocationSharing) Handler(c echo.Context) error {
+	groupIDParam := c.Param("group_id")
+	if groupIDParam == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	req := new(UpdateLocationSharingRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	if req.Enabled == nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("enabledは必須です"))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	err := usecase.NewUpdateLocationSharingUseCase(u.userRepo, u.groupRepo, user.UserID, entity.GroupID(groupIDParam), *req.Enabled).Execute()
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotGroupMember) {
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("このグループに所属していません"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.NoContent(http.StatusOK)
+}
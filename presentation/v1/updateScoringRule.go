@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UpdateScoringRule struct {
+	groupRepo repository.GroupRepository
+}
+
+func NewUpdateScoringRule(groupRepo repository.GroupRepository) *UpdateScoringRule {
+	return &UpdateScoringRule{
+		groupRepo: groupRepo,
+	}
+}
+
+// @Summary update scoring rule
+// @Description update how points are awarded for events in the group. Only the group manager can change it. Penalties are given as positive numbers.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body entity.ScoringRule true "request"
+// @Success 200 {object} entity.ScoringRule
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /groups/{group_id}/scoring-rule [put]
+func (u *UpdateScoringRule) Handler(c echo.Context) error {
+	groupIDStr := c.Param("group_id")
+	if groupIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("グループIDは必須です"))
+	}
+
+	req := new(entity.ScoringRule)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	result, err := usecase.NewUpdateScoringRuleUseCase(u.groupRepo, user.UserID, entity.GroupID(groupIDStr), *req).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidScoringRule):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ポイントの設定には 0 以上の値を指定してください"))
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("グループが見つかりません"))
+		case errors.Is(err, usecase.ErrNotGroupManager):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("グループの管理者のみがポイントの設定を変更できます"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
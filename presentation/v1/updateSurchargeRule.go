@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UpdateSurchargeRule struct {
+	eventRepo repository.EventRepository
+}
+
+func NewUpdateSurchargeRule(eventRepo repository.EventRepository) *UpdateSurchargeRule {
+	return &UpdateSurchargeRule{
+		eventRepo: eventRepo,
+	}
+}
+
+// @Summary update surcharge rule
+// @Description set the late-arrival surcharge of the event. Only the event author can change it. Late participants pay per_minute for each minute late, up to max (0 means no cap), and the total is taken off the on-time participants' shares. Set per_minute to 0 to disable the surcharge.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param Authorization header string true "Bearer {auth_id}"
+// @Param request body entity.SurchargeRule true "request"
+// @Success 200 {object} entity.SurchargeRule
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 500 {object} middleware.ErrorResponse
+// @Router /events/{event_id}/settlement/surcharge-rule [put]
+func (u *UpdateSurchargeRule) Handler(c echo.Context) error {
+	eventIDStr := c.Param("event_id")
+	if eventIDStr == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("イベントIDは必須です"))
+	}
+
+	req := new(entity.SurchargeRule)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
+	}
+
+	user := middleware.GetAuthUser(c)
+
+	rule, err := usecase.NewUpdateSurchargeRuleUseCase(u.eventRepo, user.UserID, entity.EventID(eventIDStr), *req).Execute()
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidSurchargeRule):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("上乗せ額には 0 以上の値を指定してください"))
+		case errors.Is(err, usecase.ErrEventNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("イベントが見つかりません"))
+		case errors.Is(err, usecase.ErrNotEventAuthor):
+			return c.JSON(http.StatusForbidden, middleware.NewErrorResponse("イベントの作成者のみが上乗せ額を変更できます"))
+		}
+		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
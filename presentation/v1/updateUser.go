@@ -5,66 +5,123 @@ import (
 	"chikokulympic-api/domain/repository"
 	"chikokulympic-api/middleware"
 	"chikokulympic-api/usecase"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
-
+// UpdateUserRequest は省略したフィールドを変更しない
 type UpdateUserRequest struct {
-	UserName entity.UserName `json:"user_name" example:"user_name"`
-	UserIcon entity.UserIcon `json:"user_icon" example:"https://example.com/icon.png"`
+	UserName *entity.UserName `json:"user_name,omitempty" example:"山田太郎"`
+	UserIcon *entity.UserIcon `json:"user_icon,omitempty" example:"https://example.com/icon.png"`
+	FCMToken *entity.FCMToken `json:"fcm_token,omitempty" example:"fcm-token-123456"`
+	// 空文字にすると保持している称号から自動で選ぶ
+	AliasPreference *entity.Alias `json:"alias_preference,omitempty" example:"遅刻王"`
 }
 
-
 type UpdateUserResponse struct {
-	UserID entity.UserID `json:"user_id" example:"user123"`
+	UserID               entity.UserID    `json:"user_id" example:"user123"`
+	UserName             entity.UserName  `json:"user_name" example:"山田太郎"`
+	UserIcon             entity.UserIcon  `json:"user_icon" example:"https://example.com/icon.png"`
+	FCMToken             entity.FCMToken  `json:"fcm_token" example:"fcm-token-123456"`
+	Alias                entity.Alias     `json:"alias" example:"遅刻王"`
+	AliasPreference      entity.Alias     `json:"alias_preference" example:"遅刻王"`
+	LocationOptOutGroups []entity.GroupID `json:"location_opt_out_groups"`
 }
 
 type UpdateUser struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	titleRepo repository.TitleRepository
 }
 
-func NewUpdateUser(userRepo repository.UserRepository) *UpdateUser {
+func NewUpdateUser(userRepo repository.UserRepository, titleRepo repository.TitleRepository) *UpdateUser {
 	return &UpdateUser{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		titleRepo: titleRepo,
 	}
 }
 
-
-// @Summary update user
-// @Description update user information(icon, name)
+// @Summary update profile
+// @Description partially update the authenticated user's profile. Only the fields present in the request are changed; alias_preference must be a title the user currently holds, or empty to pick one automatically
 // @Tags users
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer {auth_id}"
 // @Param request body UpdateUserRequest true "request"
 // @Success 200 {object} UpdateUserResponse
 // @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
 // @Failure 500 {object} middleware.ErrorResponse
+// @Router /users/me [put]
+// @Router /users/me [patch]
 // @Router /users [put]
 func (u *UpdateUser) Handler(c echo.Context) error {
+	user := middleware.GetAuthUser(c)
+
 	req := new(UpdateUserRequest)
 	if err := c.Bind(req); err != nil {
 		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse(err.Error()))
 	}
 
-	if req.UserName == "" {
-		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ユーザー名は必須です"))
+	if req.UserName != nil {
+		name := entity.UserName(strings.TrimSpace(string(*req.UserName)))
+		if name == "" {
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("ユーザー名は空にできません"))
+		}
+		req.UserName = &name
+	}
+	if req.UserIcon != nil && *req.UserIcon != "" && !isHTTPURL(string(*req.UserIcon)) {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("アイコンは http または https の URL で指定してください"))
+	}
+	if req.FCMToken != nil && *req.FCMToken == "" {
+		return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("FCM トークンは空にできません"))
 	}
 
-	user := &entity.User{
-		UserName: entity.UserName(req.UserName),
-		UserIcon: entity.UserIcon(req.UserIcon),
+	update := entity.UserProfileUpdate{
+		UserName:        req.UserName,
+		UserIcon:        req.UserIcon,
+		FCMToken:        req.FCMToken,
+		AliasPreference: req.AliasPreference,
 	}
 
-	updatedUser, err := usecase.NewUpdateUserUseCase(u.userRepo, user).Execute()
+	updatedUser, err := usecase.NewUpdateUserUseCase(u.userRepo, u.titleRepo, user.UserID, update).Execute()
 	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrAliasNotHeld):
+			return c.JSON(http.StatusBadRequest, middleware.NewErrorResponse("保持していない称号はエイリアスにできません"))
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.JSON(http.StatusNotFound, middleware.NewErrorResponse("ユーザーが見つかりません"))
+		}
 		return c.JSON(http.StatusInternalServerError, middleware.NewErrorResponse(err.Error()))
 	}
 
-	response := UpdateUserResponse{
-		UserID: updatedUser.UserID,
+	return c.JSON(http.StatusOK, newUpdateUserResponse(updatedUser))
+}
+
+func newUpdateUserResponse(user *entity.User) UpdateUserResponse {
+	optOut := user.LocationOptOutGroups
+	if optOut == nil {
+		optOut = []entity.GroupID{}
+	}
+	return UpdateUserResponse{
+		UserID:               user.UserID,
+		UserName:             user.UserName,
+		UserIcon:             user.UserIcon,
+		FCMToken:             user.FCMToken,
+		Alias:                user.Alias,
+		AliasPreference:      user.AliasPreference,
+		LocationOptOutGroups: optOut,
 	}
+}
 
-	return c.JSON(http.StatusOK, response)
+func isHTTPURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
 }
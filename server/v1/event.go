@@ -2,32 +2,74 @@ package v1
 
 import (
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
 	presentationV1 "chikokulympic-api/presentation/v1"
+	"chikokulympic-api/usecase"
 
 	"github.com/labstack/echo/v4"
 )
 
 type EventServer struct {
-	postEvent     *presentationV1.PostEvent
-	getEvents     *presentationV1.GetEvents
-	getEventBoard *presentationV1.GetEventBoard
-	postVote      *presentationV1.PostVote
+	auth            echo.MiddlewareFunc
+	postEvent       *presentationV1.PostEvent
+	patchEvent      *presentationV1.PatchEvent
+	getEvents       *presentationV1.GetEvents
+	getEventBoard   *presentationV1.GetEventBoard
+	postVote        *presentationV1.PostVote
+	streamLocations *presentationV1.StreamLocations
+	getEventETA     *presentationV1.GetEventETA
+	getTrail        *presentationV1.GetLocationTrail
+	postArrival     *presentationV1.PostArrival
+	reviewArrival   *presentationV1.ReviewArrival
+	getCheckinCode  *presentationV1.GetCheckinCode
+	postCheckin     *presentationV1.PostCheckin
+	finalizeEvent   *presentationV1.PostFinalizeEvent
+	getSettlement   *presentationV1.GetEventSettlement
+	updateSurcharge *presentationV1.UpdateSurchargeRule
+	markPayment     *presentationV1.MarkPayment
 }
 
-func NewEventServer(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository) *EventServer {
+func NewEventServer(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, seasonRepo repository.SeasonRepository, seriesRepo repository.EventSeriesRepository, seriesMaterializer *usecase.EventSeriesMaterializer, leaderboardCache *usecase.LeaderboardCache, locationHub service.LocationHub, locationThrottle *usecase.LocationThrottle, speedProfile usecase.TravelSpeedProfile, arrivalPolicy usecase.ArrivalPolicy, checkinPolicy usecase.CheckinCodePolicy, checkinLimiter *usecase.CheckinAttemptLimiter, notifier service.Notifier) *EventServer {
 	return &EventServer{
-		postEvent:     presentationV1.NewPostEvent(groupRepo, eventRepo),
-		getEvents:     presentationV1.NewGetEvents(eventRepo, groupRepo),
-		getEventBoard: presentationV1.NewGetEventBoard(groupRepo, eventRepo, userRepo),
-		postVote:      presentationV1.NewPostVote(eventRepo, groupRepo, userRepo),
+		auth:            middleware.NewAuthMiddleware(userRepo),
+		postEvent:       presentationV1.NewPostEvent(groupRepo, eventRepo, seasonRepo, seriesRepo, seriesMaterializer, notifier),
+		patchEvent:      presentationV1.NewPatchEvent(eventRepo, seriesRepo, groupRepo, seasonRepo),
+		getEvents:       presentationV1.NewGetEvents(eventRepo, groupRepo),
+		getEventBoard:   presentationV1.NewGetEventBoard(groupRepo, eventRepo, userRepo),
+		postVote:        presentationV1.NewPostVote(eventRepo, groupRepo, userRepo, notifier),
+		streamLocations: presentationV1.NewStreamLocations(eventRepo, groupRepo, locationRepo, historyRepo, locationHub, locationThrottle),
+		getEventETA:     presentationV1.NewGetEventETA(eventRepo, groupRepo, userRepo, locationRepo, speedProfile),
+		getTrail:        presentationV1.NewGetLocationTrail(eventRepo, groupRepo, historyRepo),
+		postArrival:     presentationV1.NewPostArrival(eventRepo, groupRepo, historyRepo, arrivalPolicy, notifier),
+		reviewArrival:   presentationV1.NewReviewArrival(eventRepo, groupRepo, userRepo, leaderboardCache, notifier),
+		getCheckinCode:  presentationV1.NewGetCheckinCode(eventRepo, checkinPolicy),
+		postCheckin:     presentationV1.NewPostCheckin(eventRepo, groupRepo, checkinPolicy, checkinLimiter, notifier),
+		finalizeEvent:   presentationV1.NewPostFinalizeEvent(eventRepo, groupRepo, userRepo, scoreRepo, titleRepo, leaderboardCache, notifier),
+		getSettlement:   presentationV1.NewGetEventSettlement(eventRepo, groupRepo, userRepo),
+		updateSurcharge: presentationV1.NewUpdateSurchargeRule(eventRepo),
+		markPayment:     presentationV1.NewMarkPayment(eventRepo),
 	}
 }
 
 func (s *EventServer) RegisterRoutes(e *echo.Echo) {
 	eventGroup := e.Group("/events")
 
-	eventGroup.POST("", s.postEvent.Handler)
+	eventGroup.POST("", s.postEvent.Handler, s.auth)
 	eventGroup.GET("", s.getEvents.Handler)
 	eventGroup.GET("/board", s.getEventBoard.Handler)
-	eventGroup.POST("/:event_id/votes", s.postVote.Handler)
+	eventGroup.PATCH("/:event_id", s.patchEvent.Handler, s.auth)
+	eventGroup.POST("/:event_id/votes", s.postVote.Handler, s.auth)
+	eventGroup.GET("/:event_id/locations/ws", s.streamLocations.Handler, s.auth)
+	eventGroup.GET("/:event_id/eta", s.getEventETA.Handler, s.auth)
+	eventGroup.GET("/:event_id/locations/:user_id/trail", s.getTrail.Handler, s.auth)
+	eventGroup.POST("/:event_id/arrival", s.postArrival.Handler, s.auth)
+	eventGroup.POST("/:event_id/arrivals/:user_id/review", s.reviewArrival.Handler, s.auth)
+	eventGroup.GET("/:event_id/checkin-code", s.getCheckinCode.Handler, s.auth)
+	eventGroup.GET("/:event_id/checkin-qr", s.getCheckinCode.QRHandler, s.auth)
+	eventGroup.POST("/:event_id/checkin", s.postCheckin.Handler, s.auth)
+	eventGroup.POST("/:event_id/finalize", s.finalizeEvent.Handler, s.auth)
+	eventGroup.GET("/:event_id/settlement", s.getSettlement.Handler, s.auth)
+	eventGroup.PUT("/:event_id/settlement/surcharge-rule", s.updateSurcharge.Handler, s.auth)
+	eventGroup.PUT("/:event_id/settlement/payments/:user_id", s.markPayment.Handler, s.auth)
 }
@@ -3,22 +3,42 @@ package v1
 import (
 	presentationV1 "chikokulympic-api/presentation/v1"
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/middleware"
+	"chikokulympic-api/usecase"
 	"github.com/labstack/echo/v4"
 )
 
 type GroupServer struct {
-	createGroup   *presentationV1.PostGroup
-	joinGroup     *presentationV1.JoinGroup
-	leaveGroup    *presentationV1.LeaveGroup
-	getGroupInfo  *presentationV1.GetGroupInfo
+	auth              echo.MiddlewareFunc
+	createGroup       *presentationV1.PostGroup
+	joinGroup         *presentationV1.JoinGroup
+	leaveGroup        *presentationV1.LeaveGroup
+	getGroupInfo      *presentationV1.GetGroupInfo
+	getStandings      *presentationV1.GetGroupStandings
+	updateScoringRule *presentationV1.UpdateScoringRule
+	getLeaderboard    *presentationV1.GetGroupLeaderboard
+	postSeason        *presentationV1.PostSeason
+	getSeasons        *presentationV1.GetGroupSeasons
+	closeSeason       *presentationV1.CloseSeason
+	importEvents      *presentationV1.ImportEvents
+	getBalances       *presentationV1.GetGroupBalances
 }
 
-func NewGroupServer(groupRepo repository.GroupRepository, userRepo repository.UserRepository) *GroupServer {
+func NewGroupServer(groupRepo repository.GroupRepository, userRepo repository.UserRepository, eventRepo repository.EventRepository, scoreRepo repository.ScoreRepository, seasonRepo repository.SeasonRepository, leaderboardCache *usecase.LeaderboardCache) *GroupServer {
 	return &GroupServer{
-		createGroup:  presentationV1.NewPostGroup(groupRepo, userRepo),
-		joinGroup:     presentationV1.NewJoinGroup(userRepo, groupRepo),
-		leaveGroup:    presentationV1.NewLeaveGroup(groupRepo),
-		getGroupInfo:  presentationV1.NewGetGroupInfo(groupRepo, userRepo),
+		auth:              middleware.NewAuthMiddleware(userRepo),
+		createGroup:       presentationV1.NewPostGroup(groupRepo, userRepo),
+		joinGroup:         presentationV1.NewJoinGroup(userRepo, groupRepo, leaderboardCache),
+		leaveGroup:        presentationV1.NewLeaveGroup(groupRepo, leaderboardCache),
+		getGroupInfo:      presentationV1.NewGetGroupInfo(groupRepo, userRepo),
+		getStandings:      presentationV1.NewGetGroupStandings(groupRepo, userRepo, scoreRepo, seasonRepo),
+		updateScoringRule: presentationV1.NewUpdateScoringRule(groupRepo),
+		getLeaderboard:    presentationV1.NewGetGroupLeaderboard(groupRepo, userRepo, seasonRepo, leaderboardCache),
+		postSeason:        presentationV1.NewPostSeason(groupRepo, seasonRepo, eventRepo, scoreRepo),
+		getSeasons:        presentationV1.NewGetGroupSeasons(groupRepo, seasonRepo),
+		closeSeason:       presentationV1.NewCloseSeason(groupRepo, userRepo, seasonRepo, scoreRepo),
+		importEvents:      presentationV1.NewImportEvents(eventRepo, groupRepo, seasonRepo),
+		getBalances:       presentationV1.NewGetGroupBalances(groupRepo, eventRepo, userRepo),
 	}
 }
 func (s *GroupServer) RegisterRoutes(e *echo.Echo) {
@@ -31,4 +51,20 @@ func (s *GroupServer) RegisterRoutes(e *echo.Echo) {
 	groupGroup.POST("/:group_id/leave", s.leaveGroup.Handler)
 
 	groupGroup.GET("/:group_id", s.getGroupInfo.Handler)
+
+	groupGroup.GET("/:group_id/standings", s.getStandings.Handler, s.auth)
+
+	groupGroup.GET("/:group_id/leaderboard", s.getLeaderboard.Handler, s.auth)
+
+	groupGroup.PUT("/:group_id/scoring-rule", s.updateScoringRule.Handler, s.auth)
+
+	groupGroup.POST("/:group_id/seasons", s.postSeason.Handler, s.auth)
+
+	groupGroup.GET("/:group_id/seasons", s.getSeasons.Handler, s.auth)
+
+	groupGroup.POST("/:group_id/seasons/:season_id/close", s.closeSeason.Handler, s.auth)
+
+	groupGroup.POST("/:group_id/events/import", s.importEvents.Handler, s.auth)
+
+	groupGroup.GET("/:group_id/balances", s.getBalances.Handler, s.auth)
 }
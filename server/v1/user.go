@@ -2,24 +2,63 @@ package v1
 
 import (
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/middleware"
 	presentationV1 "chikokulympic-api/presentation/v1"
+	"chikokulympic-api/usecase"
 
 	"github.com/labstack/echo/v4"
 )
 
 type UserServer struct {
-	signup        *presentationV1.Signup
-	signin        *presentationV1.Signin
-	updateUser    *presentationV1.UpdateUser
-	getUserGroups *presentationV1.GetUserGroups
+	auth                    echo.MiddlewareFunc
+	signup                  *presentationV1.Signup
+	signin                  *presentationV1.Signin
+	updateUser              *presentationV1.UpdateUser
+	getUserGroups           *presentationV1.GetUserGroups
+	updateLocationSharing   *presentationV1.UpdateLocationSharing
+	deleteLocationHistory   *presentationV1.DeleteLocationHistory
+	getUserTitles           *presentationV1.GetUserTitles
+	getUserStats            *presentationV1.GetUserStats
+	postCalendarToken       *presentationV1.PostCalendarToken
+	getCalendarFeed         *presentationV1.GetCalendarFeed
+	deleteUser              *presentationV1.DeleteUser
+	getDataExport           *presentationV1.GetDataExport
+	getDataExportJob        *presentationV1.GetDataExportJob
+	downloadDataExport      *presentationV1.DownloadDataExport
+	getDevices              *presentationV1.GetDevices
+	putDevice               *presentationV1.PutDevice
+	deleteDevice            *presentationV1.DeleteDevice
+	postUserIcon            *presentationV1.PostUserIcon
+	getUserIcon             *presentationV1.GetUserIcon
+	getNotificationSettings *presentationV1.GetNotificationSettings
+	putNotificationSettings *presentationV1.PutNotificationSettings
 }
 
-func NewUserServer(userRepo repository.UserRepository, groupRepo repository.GroupRepository) *UserServer {
+func NewUserServer(userRepo repository.UserRepository, eventRepo repository.EventRepository, groupRepo repository.GroupRepository, locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, titleRepo repository.TitleRepository, scoreRepo repository.ScoreRepository, auditRepo repository.AuditLogRepository, deviceRepo repository.DeviceRepository, settingsRepo repository.NotificationSettingsRepository, queueRepo repository.NotificationQueueRepository, blobStore service.BlobStore, leaderboardCache *usecase.LeaderboardCache, exporter *usecase.DataExporter) *UserServer {
 	return &UserServer{
-		signup:        presentationV1.NewSignup(userRepo),
-		signin:        presentationV1.NewSignin(userRepo),
-		updateUser:    presentationV1.NewUpdateUser(userRepo),
-		getUserGroups: presentationV1.NewGetUserGroups(groupRepo),
+		auth:                    middleware.NewAuthMiddleware(userRepo),
+		signup:                  presentationV1.NewSignup(userRepo),
+		signin:                  presentationV1.NewSignin(userRepo),
+		updateUser:              presentationV1.NewUpdateUser(userRepo, titleRepo),
+		getUserGroups:           presentationV1.NewGetUserGroups(groupRepo),
+		updateLocationSharing:   presentationV1.NewUpdateLocationSharing(userRepo, groupRepo),
+		deleteLocationHistory:   presentationV1.NewDeleteLocationHistory(locationRepo, historyRepo),
+		getUserTitles:           presentationV1.NewGetUserTitles(titleRepo),
+		getUserStats:            presentationV1.NewGetUserStats(eventRepo),
+		postCalendarToken:       presentationV1.NewPostCalendarToken(userRepo),
+		getCalendarFeed:         presentationV1.NewGetCalendarFeed(userRepo, groupRepo, eventRepo),
+		deleteUser:              presentationV1.NewDeleteUser(userRepo, groupRepo, eventRepo, scoreRepo, titleRepo, locationRepo, historyRepo, auditRepo, deviceRepo, settingsRepo, queueRepo, blobStore, leaderboardCache),
+		getDataExport:           presentationV1.NewGetDataExport(exporter),
+		getDataExportJob:        presentationV1.NewGetDataExportJob(exporter),
+		downloadDataExport:      presentationV1.NewDownloadDataExport(exporter),
+		getDevices:              presentationV1.NewGetDevices(deviceRepo),
+		putDevice:               presentationV1.NewPutDevice(deviceRepo),
+		deleteDevice:            presentationV1.NewDeleteDevice(deviceRepo),
+		postUserIcon:            presentationV1.NewPostUserIcon(userRepo, blobStore),
+		getUserIcon:             presentationV1.NewGetUserIcon(blobStore),
+		getNotificationSettings: presentationV1.NewGetNotificationSettings(settingsRepo),
+		putNotificationSettings: presentationV1.NewPutNotificationSettings(settingsRepo, groupRepo),
 	}
 }
 
@@ -30,7 +69,47 @@ func (s *UserServer) RegisterRoutes(e *echo.Echo) {
 
 	authGroup.POST("/signin", s.signin.Handler)
 
-	authGroup.PUT("", s.updateUser.Handler)
+	// 以前のクライアント向けに残している。/me と同じく認証したユーザーを更新する
+	authGroup.PUT("", s.updateUser.Handler, s.auth)
 
 	authGroup.GET("/:user_id/groups", s.getUserGroups.Handler)
+
+	authGroup.GET("/:user_id/titles", s.getUserTitles.Handler)
+
+	authGroup.GET("/:user_id/stats", s.getUserStats.Handler)
+
+	authGroup.GET("/:user_id/calendar.ics", s.getCalendarFeed.Handler)
+
+	authGroup.GET("/:user_id/icon", s.getUserIcon.Handler)
+
+	authGroup.PUT("/me/location-sharing/:group_id", s.updateLocationSharing.Handler, s.auth)
+
+	authGroup.DELETE("/me/location-history", s.deleteLocationHistory.Handler, s.auth)
+
+	authGroup.POST("/me/calendar-token", s.postCalendarToken.Handler, s.auth)
+
+	authGroup.POST("/me/icon", s.postUserIcon.Handler, s.auth)
+
+	authGroup.GET("/me/notification-settings", s.getNotificationSettings.Handler, s.auth)
+
+	authGroup.PUT("/me/notification-settings", s.putNotificationSettings.Handler, s.auth)
+
+	authGroup.PUT("/me", s.updateUser.Handler, s.auth)
+
+	authGroup.PATCH("/me", s.updateUser.Handler, s.auth)
+
+	authGroup.DELETE("/me", s.deleteUser.Handler, s.auth)
+
+	authGroup.GET("/me/export", s.getDataExport.Handler, s.auth)
+
+	authGroup.GET("/me/export/:job_id", s.getDataExportJob.Handler, s.auth)
+
+	authGroup.GET("/me/devices", s.getDevices.Handler, s.auth)
+
+	authGroup.PUT("/me/devices/:device_id", s.putDevice.Handler, s.auth)
+
+	authGroup.DELETE("/me/devices/:device_id", s.deleteDevice.Handler, s.auth)
+
+	// リンクに含まれるトークンで確認するため、認証ヘッダーは不要
+	e.GET("/exports/:job_id/download", s.downloadDataExport.Handler)
 }
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	checkinCodeDigits = 6
+	checkinSecretSize = 20
+)
+
+// CheckinCodePolicy はチェックインコードの切り替え間隔と、前後に許容するステップ数
+type CheckinCodePolicy struct {
+	Step time.Duration
+	Skew int
+}
+
+// DefaultCheckinCodePolicy は 30 秒ごとに切り替わり、前後 1 ステップ分の遅延を許容する
+func DefaultCheckinCodePolicy() CheckinCodePolicy {
+	return CheckinCodePolicy{
+		Step: 30 * time.Second,
+		Skew: 1,
+	}
+}
+
+func generateCheckinSecret() (string, error) {
+	secret := make([]byte, checkinSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("チェックイン用シークレットの生成に失敗しました: %w", err)
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+// stepSeconds は 1 秒未満の設定でも 0 除算にならないようにする
+func (p CheckinCodePolicy) stepSeconds() int64 {
+	if p.Step < time.Second {
+		return 1
+	}
+	return int64(p.Step / time.Second)
+}
+
+func (p CheckinCodePolicy) counterAt(t time.Time) int64 {
+	return t.Unix() / p.stepSeconds()
+}
+
+// codeAt は RFC 6238 (TOTP) と同じ方式でステップごとのコードを求める
+func (p CheckinCodePolicy) codeAt(secret string, counter int64) (string, error) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("チェックイン用シークレットが不正です: %w", err)
+	}
+
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < checkinCodeDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", checkinCodeDigits, value%modulo), nil
+}
+
+// verify は表示中のコードと、前後 Skew ステップ分のコードを受け付ける
+func (p CheckinCodePolicy) verify(secret string, code string, t time.Time) bool {
+	if len(code) != checkinCodeDigits {
+		return false
+	}
+
+	counter := p.counterAt(t)
+	for i := -p.Skew; i <= p.Skew; i++ {
+		expected, err := p.codeAt(secret, counter+int64(i))
+		if err != nil {
+			return false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkinPayload はアプリで読み取る QR コードの内容
+func checkinPayload(eventID entity.EventID, code string) string {
+	query := url.Values{}
+	query.Set("event_id", string(eventID))
+	query.Set("code", code)
+	return "chikokulympic://checkin?" + query.Encode()
+}
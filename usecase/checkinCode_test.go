@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"encoding/base32"
+	"sync"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 付録 B の SHA-1 のテスト用シークレット "12345678901234567890"
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCheckinCodePolicyCodeAt(t *testing.T) {
+	policy := DefaultCheckinCodePolicy()
+
+	// RFC 6238 の 8 桁のコードの下 6 桁
+	testCases := []struct {
+		name     string
+		unix     int64
+		expected string
+	}{
+		{name: "正常系: 59", unix: 59, expected: "287082"},
+		{name: "正常系: 1111111109", unix: 1111111109, expected: "081804"},
+		{name: "正常系: 1111111111", unix: 1111111111, expected: "050471"},
+		{name: "正常系: 1234567890", unix: 1234567890, expected: "005924"},
+		{name: "正常系: 2000000000", unix: 2000000000, expected: "279037"},
+		{name: "正常系: 20000000000", unix: 20000000000, expected: "353130"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			code, err := policy.codeAt(rfc6238Secret, policy.counterAt(time.Unix(tc.unix, 0)))
+
+			// 結果の検証
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, code)
+		})
+	}
+
+	t.Run("異常系: シークレットが base32 ではない", func(t *testing.T) {
+		_, err := policy.codeAt("not base32!", 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckinCodePolicyVerify(t *testing.T) {
+	policy := DefaultCheckinCodePolicy()
+
+	// 1111111111 はステップ 37037037 の 21 秒目
+	now := time.Unix(1111111111, 0)
+	counter := policy.counterAt(now)
+	codeAtStep := func(offset int64) string {
+		code, err := policy.codeAt(rfc6238Secret, counter+offset)
+		assert.NoError(t, err)
+		return code
+	}
+
+	testCases := []struct {
+		name     string
+		code     string
+		at       time.Time
+		expected bool
+	}{
+		{name: "正常系: 表示中のコード", code: codeAtStep(0), at: now, expected: true},
+		{name: "正常系: 1 ステップ前のコード", code: codeAtStep(-1), at: now, expected: true},
+		{name: "正常系: 1 ステップ後のコード", code: codeAtStep(1), at: now, expected: true},
+		{name: "異常系: 2 ステップ前のコード", code: codeAtStep(-2), at: now, expected: false},
+		{name: "異常系: 2 ステップ後のコード", code: codeAtStep(2), at: now, expected: false},
+		// ステップの切り替わりちょうどで、1 ステップ前の範囲に入る・外れる
+		{name: "正常系: 次のステップの最後の秒に前のコード", code: codeAtStep(0), at: time.Unix((counter+2)*30-1, 0), expected: true},
+		{name: "異常系: 2 ステップ先の最初の秒に前のコード", code: codeAtStep(0), at: time.Unix((counter+2)*30, 0), expected: false},
+		{name: "正常系: 前のステップの最初の秒に次のコード", code: codeAtStep(0), at: time.Unix((counter-1)*30, 0), expected: true},
+		{name: "異常系: 2 ステップ前の最後の秒に次のコード", code: codeAtStep(0), at: time.Unix((counter-1)*30-1, 0), expected: false},
+		{name: "異常系: 桁が足りない", code: codeAtStep(0)[:5], at: now, expected: false},
+		{name: "異常系: 桁が多い", code: codeAtStep(0) + "0", at: now, expected: false},
+		{name: "異常系: RFC 6238 の 8 桁のコード", code: "14050471", at: now, expected: false},
+		{name: "異常系: 空のコード", code: "", at: now, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行・結果の検証
+			assert.Equal(t, tc.expected, policy.verify(rfc6238Secret, tc.code, tc.at))
+		})
+	}
+
+	t.Run("正常系: Skew が 0 なら表示中のコードだけを受け付ける", func(t *testing.T) {
+		strict := CheckinCodePolicy{Step: 30 * time.Second, Skew: 0}
+		assert.True(t, strict.verify(rfc6238Secret, codeAtStep(0), now))
+		assert.False(t, strict.verify(rfc6238Secret, codeAtStep(-1), now))
+		assert.False(t, strict.verify(rfc6238Secret, codeAtStep(1), now))
+	})
+}
+
+func TestIssueCheckinCodeUseCase(t *testing.T) {
+	t.Run("正常系: 同時に初めて表示しても同じシークレットのコードを返す", func(t *testing.T) {
+		eventRepo := newEventRepoStub(&entity.Event{EventID: "event-id", EventAuthorID: "author-id"})
+		policy := DefaultCheckinCodePolicy()
+
+		// テスト実行
+		codes := make([]string, 8)
+		var wg sync.WaitGroup
+		for i := range codes {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				result, err := NewIssueCheckinCodeUseCase(eventRepo, policy, "author-id", "event-id").Execute()
+				assert.NoError(t, err)
+				codes[i] = result.Code
+			}(i)
+		}
+		wg.Wait()
+
+		// 結果の検証: 保存されたシークレットで今のコードを検証できる
+		secret := eventRepo.events["event-id"].CheckinSecret
+		assert.NotEmpty(t, secret)
+		for _, code := range codes {
+			assert.True(t, policy.verify(secret, code, time.Now()))
+		}
+	})
+
+	t.Run("異常系: 作成者でなければ表示できない", func(t *testing.T) {
+		eventRepo := newEventRepoStub(&entity.Event{EventID: "event-id", EventAuthorID: "author-id"})
+
+		_, err := NewIssueCheckinCodeUseCase(eventRepo, DefaultCheckinCodePolicy(), "member-id", "event-id").Execute()
+
+		assert.ErrorIs(t, err, ErrNotEventAuthor)
+		assert.Empty(t, eventRepo.events["event-id"].CheckinSecret)
+	})
+}
+
+func TestCheckinEventUseCase(t *testing.T) {
+	policy := DefaultCheckinCodePolicy()
+
+	newUseCase := func(code func(now time.Time) string) (*CheckinEventUseCaseImpl, *eventRepoStub, *recordingNotifier) {
+		now := time.Now()
+		event := &entity.Event{
+			EventID:            "event-id",
+			EventTitle:         "ランチ",
+			EventStartDateTime: entity.StartDateTIme(now.Add(-10 * time.Minute)),
+			EventEndDateTime:   entity.EndDateTime(now.Add(time.Hour)),
+			CheckinSecret:      rfc6238Secret,
+			VotedMembers: []entity.VotedMember{
+				{UserID: "checkin-user-id", Vote: entity.VoteAttend},
+				{UserID: "member-id", Vote: entity.VoteAttend},
+				{UserID: "absent-member-id", Vote: "不参加"},
+			},
+		}
+		group := &entity.Group{GroupID: "group-id", GroupMembers: entity.GroupMembers{"checkin-user-id", "member-id", "absent-member-id"}, GroupEvents: entity.GroupEvents{"event-id"}}
+		eventRepo := newEventRepoStub(event)
+		notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+		user := &entity.User{UserID: "checkin-user-id", UserName: "到着"}
+		uc := NewCheckinEventUseCase(eventRepo, &groupRepoStub{groups: []*entity.Group{group}}, policy, NewCheckinAttemptLimiter(5, 15*time.Minute), notifier, user, "event-id", code(now))
+		return uc, eventRepo, notifier
+	}
+
+	t.Run("正常系: チェックインすると到着を記録し、他の参加者に通知する", func(t *testing.T) {
+		uc, eventRepo, notifier := newUseCase(func(now time.Time) string {
+			code, err := policy.codeAt(rfc6238Secret, policy.counterAt(now))
+			assert.NoError(t, err)
+			return code
+		})
+
+		// テスト実行
+		member, err := uc.Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.True(t, member.IsArrival)
+		assert.True(t, eventRepo.events["event-id"].VotedMembers[0].IsArrival)
+		assert.Len(t, notifier.sent["member-id"], 1)
+		assert.Equal(t, entity.NotificationMemberArrived, notifier.sent["member-id"][0].Kind)
+		assert.Equal(t, entity.GroupID("group-id"), notifier.sent["member-id"][0].GroupID)
+		assert.Empty(t, notifier.sent["checkin-user-id"])
+		assert.Empty(t, notifier.sent["absent-member-id"])
+	})
+
+	t.Run("異常系: コードが違えば記録も通知もしない", func(t *testing.T) {
+		uc, eventRepo, notifier := newUseCase(func(now time.Time) string {
+			code, err := policy.codeAt(rfc6238Secret, policy.counterAt(now)+10)
+			assert.NoError(t, err)
+			return code
+		})
+
+		_, err := uc.Execute()
+
+		assert.ErrorIs(t, err, ErrInvalidCheckinCode)
+		assert.False(t, eventRepo.events["event-id"].VotedMembers[0].IsArrival)
+		assert.Empty(t, notifier.sent)
+	})
+}
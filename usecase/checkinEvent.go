@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidCheckinCode = errors.New("invalid check-in code")
+	ErrCheckinLocked      = errors.New("too many failed check-in attempts")
+)
+
+// CheckinAttemptLimiter はコードの総当たりを防ぐため、イベントとユーザーの組ごとに失敗した回数を数える
+// lockout の間に maxFailures 回失敗すると、最後の失敗から lockout の間はチェックインさせない
+type CheckinAttemptLimiter struct {
+	maxFailures int
+	lockout     time.Duration
+	mu          sync.Mutex
+	attempts    map[checkinAttemptKey]*checkinAttempts
+}
+
+type checkinAttemptKey struct {
+	eventID entity.EventID
+	userID  entity.UserID
+}
+
+type checkinAttempts struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+func NewCheckinAttemptLimiter(maxFailures int, lockout time.Duration) *CheckinAttemptLimiter {
+	return &CheckinAttemptLimiter{
+		maxFailures: maxFailures,
+		lockout:     lockout,
+		attempts:    make(map[checkinAttemptKey]*checkinAttempts),
+	}
+}
+
+// Allow はロックされていなければ true を返す
+func (l *CheckinAttemptLimiter) Allow(eventID entity.EventID, userID entity.UserID, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	attempts, ok := l.attempts[checkinAttemptKey{eventID: eventID, userID: userID}]
+	return !ok || !now.Before(attempts.lockedUntil)
+}
+
+// RecordFailure は失敗を記録し、上限に達した場合はロックする
+func (l *CheckinAttemptLimiter) RecordFailure(eventID entity.EventID, userID entity.UserID, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := checkinAttemptKey{eventID: eventID, userID: userID}
+	attempts, ok := l.attempts[key]
+	if !ok || now.Sub(attempts.lastFailure) >= l.lockout {
+		attempts = &checkinAttempts{}
+		l.attempts[key] = attempts
+	}
+	attempts.failures++
+	attempts.lastFailure = now
+	if attempts.failures >= l.maxFailures {
+		attempts.lockedUntil = now.Add(l.lockout)
+	}
+
+	// 古いエントリが溜まり続けないよう、ロックも失敗の記録も切れたものを掃除する
+	if len(l.attempts) > 1024 {
+		for k, a := range l.attempts {
+			if now.Sub(a.lastFailure) >= l.lockout && !now.Before(a.lockedUntil) {
+				delete(l.attempts, k)
+			}
+		}
+	}
+}
+
+// Reset はチェックインに成功したときに失敗の記録を消す
+func (l *CheckinAttemptLimiter) Reset(eventID entity.EventID, userID entity.UserID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, checkinAttemptKey{eventID: eventID, userID: userID})
+}
+
+type CheckinEventUseCase interface {
+	Execute() (*entity.VotedMember, error)
+}
+
+type CheckinEventUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	policy    CheckinCodePolicy
+	limiter   *CheckinAttemptLimiter
+	notifier  service.Notifier
+	user      *entity.User
+	eventID   entity.EventID
+	code      string
+}
+
+// NewCheckinEventUseCase は会場で表示されたコードを使って到着を記録する
+// GPS が使えない屋内向けの手段で、コードを見られること自体を会場にいる証拠とみなす
+func NewCheckinEventUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, policy CheckinCodePolicy, limiter *CheckinAttemptLimiter, notifier service.Notifier, user *entity.User, eventID entity.EventID, code string) *CheckinEventUseCaseImpl {
+	return &CheckinEventUseCaseImpl{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		policy:    policy,
+		limiter:   limiter,
+		notifier:  notifier,
+		user:      user,
+		eventID:   eventID,
+		code:      code,
+	}
+}
+
+func (uc *CheckinEventUseCaseImpl) Execute() (*entity.VotedMember, error) {
+	if !uc.limiter.Allow(uc.eventID, uc.user.UserID, time.Now()) {
+		return nil, ErrCheckinLocked
+	}
+
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	group, err := findEventGroup(uc.groupRepo, event.EventID, uc.user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *entity.VotedMember
+	for i := range event.VotedMembers {
+		if event.VotedMembers[i].UserID == uc.user.UserID {
+			member = &event.VotedMembers[i]
+			break
+		}
+	}
+	if member == nil || member.Vote != entity.VoteAttend {
+		return nil, ErrNotAttending
+	}
+	if member.IsArrival {
+		return nil, ErrAlreadyArrived
+	}
+
+	now := time.Now()
+	windowStart := time.Time(event.EventStartDateTime).Add(-locationTrailLeadTime)
+	if now.Before(windowStart) || now.After(time.Time(event.EventEndDateTime)) {
+		return nil, ErrOutsideEventWindow
+	}
+
+	// まだ一度もコードが表示されていないイベントにはチェックインできない
+	if event.CheckinSecret == "" || !uc.policy.verify(event.CheckinSecret, uc.code, now) {
+		uc.limiter.RecordFailure(uc.eventID, uc.user.UserID, now)
+		return nil, ErrInvalidCheckinCode
+	}
+	uc.limiter.Reset(uc.eventID, uc.user.UserID)
+
+	member.IsArrival = true
+	member.ArrivalDateTime = now
+	member.ArrivalFlag = nil
+
+	if err := uc.eventRepo.UpdateVotedMember(event.EventID, *member); err != nil {
+		return nil, fmt.Errorf("到着情報の更新に失敗しました: %w", err)
+	}
+
+	notifyMemberArrived(uc.notifier, group.GroupID, event, uc.user)
+
+	return member, nil
+}
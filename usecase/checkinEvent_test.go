@@ -0,0 +1,58 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckinAttemptLimiter(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("上限まで失敗するとロックされ、期間が過ぎると解除される", func(t *testing.T) {
+		limiter := usecase.NewCheckinAttemptLimiter(3, 15*time.Minute)
+
+		for i := 0; i < 2; i++ {
+			limiter.RecordFailure("event-id", "user-id", base.Add(time.Duration(i)*time.Minute))
+			assert.True(t, limiter.Allow("event-id", "user-id", base.Add(time.Duration(i)*time.Minute)))
+		}
+
+		limiter.RecordFailure("event-id", "user-id", base.Add(2*time.Minute))
+		assert.False(t, limiter.Allow("event-id", "user-id", base.Add(2*time.Minute)))
+		assert.False(t, limiter.Allow("event-id", "user-id", base.Add(16*time.Minute)))
+
+		// 他のユーザーや他のイベントには影響しない
+		assert.True(t, limiter.Allow("event-id", "other-user-id", base.Add(2*time.Minute)))
+		assert.True(t, limiter.Allow("other-event-id", "user-id", base.Add(2*time.Minute)))
+
+		assert.True(t, limiter.Allow("event-id", "user-id", base.Add(17*time.Minute)))
+
+		// 解除後は失敗の回数を数え直す
+		limiter.RecordFailure("event-id", "user-id", base.Add(18*time.Minute))
+		assert.True(t, limiter.Allow("event-id", "user-id", base.Add(18*time.Minute)))
+	})
+
+	t.Run("間隔を空けた失敗は数えない", func(t *testing.T) {
+		limiter := usecase.NewCheckinAttemptLimiter(3, 15*time.Minute)
+
+		for i := 0; i < 5; i++ {
+			now := base.Add(time.Duration(i) * 20 * time.Minute)
+			limiter.RecordFailure("event-id", "user-id", now)
+			assert.True(t, limiter.Allow("event-id", "user-id", now))
+		}
+	})
+
+	t.Run("成功すると失敗の記録が消える", func(t *testing.T) {
+		limiter := usecase.NewCheckinAttemptLimiter(3, 15*time.Minute)
+
+		limiter.RecordFailure("event-id", "user-id", base)
+		limiter.RecordFailure("event-id", "user-id", base.Add(time.Minute))
+		limiter.Reset("event-id", "user-id")
+		limiter.RecordFailure("event-id", "user-id", base.Add(2*time.Minute))
+
+		assert.True(t, limiter.Allow("event-id", "user-id", base.Add(2*time.Minute)))
+	})
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"time"
+)
+
+type CloseSeasonUseCase interface {
+	Execute() (*entity.Season, error)
+}
+
+type CloseSeasonUseCaseImpl struct {
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	seasonRepo repository.SeasonRepository
+	scoreRepo  repository.ScoreRepository
+	userID     entity.UserID
+	groupID    entity.GroupID
+	seasonID   entity.SeasonID
+}
+
+// NewCloseSeasonUseCase はシーズンを締め、その時点の順位表を保存する
+// 保存した順位表は、後からイベントが編集・再確定されても変わらない
+// 期間の途中で締めた場合は、以降のイベントが割り当てられないよう終了時刻を締めた時刻に縮める
+func NewCloseSeasonUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, seasonRepo repository.SeasonRepository, scoreRepo repository.ScoreRepository, userID entity.UserID, groupID entity.GroupID, seasonID entity.SeasonID) *CloseSeasonUseCaseImpl {
+	return &CloseSeasonUseCaseImpl{
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		seasonRepo: seasonRepo,
+		scoreRepo:  scoreRepo,
+		userID:     userID,
+		groupID:    groupID,
+		seasonID:   seasonID,
+	}
+}
+
+func (uc *CloseSeasonUseCaseImpl) Execute() (*entity.Season, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.GroupManagerID != uc.userID {
+		return nil, ErrNotGroupManager
+	}
+
+	season, err := findGroupSeason(uc.seasonRepo, group.GroupID, uc.seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season.IsClosed() {
+		return nil, ErrSeasonAlreadyClosed
+	}
+
+	scores, err := uc.scoreRepo.FindScoresBySeasonID(season.SeasonID)
+	if err != nil {
+		return nil, fmt.Errorf("スコアの取得に失敗しました: %w", err)
+	}
+	standings := toSeasonStandings(buildStandings(scores, findScoreUsers(uc.userRepo, scores)))
+
+	now := time.Now()
+	endDateTime := season.EndDateTime
+	if now.Before(endDateTime) {
+		endDateTime = now
+	}
+
+	if err := uc.seasonRepo.CloseSeason(season.SeasonID, now, endDateTime, standings); err != nil {
+		return nil, fmt.Errorf("シーズンを締められませんでした: %w", err)
+	}
+
+	season.ClosedAt = &now
+	season.EndDateTime = endDateTime
+	season.FinalStandings = standings
+	return season, nil
+}
+
+func toSeasonStandings(standings []Standing) []entity.SeasonStanding {
+	snapshot := make([]entity.SeasonStanding, 0, len(standings))
+	for _, standing := range standings {
+		snapshot = append(snapshot, entity.SeasonStanding{
+			Rank:             standing.Rank,
+			UserID:           standing.UserID,
+			UserName:         standing.Name,
+			Alias:            standing.Alias,
+			Points:           standing.Points,
+			Events:           standing.Events,
+			OnTimeCount:      standing.OnTimeCount,
+			LateCount:        standing.LateCount,
+			NoShowCount:      standing.NoShowCount,
+			TotalLateMinutes: standing.TotalLateMinutes,
+		})
+	}
+	return snapshot
+}
+
+func fromSeasonStandings(snapshot []entity.SeasonStanding) []Standing {
+	standings := make([]Standing, 0, len(snapshot))
+	for _, standing := range snapshot {
+		standings = append(standings, Standing{
+			Rank:             standing.Rank,
+			UserID:           standing.UserID,
+			Name:             standing.UserName,
+			Alias:            standing.Alias,
+			Points:           standing.Points,
+			Events:           standing.Events,
+			OnTimeCount:      standing.OnTimeCount,
+			LateCount:        standing.LateCount,
+			NoShowCount:      standing.NoShowCount,
+			TotalLateMinutes: standing.TotalLateMinutes,
+		})
+	}
+	return standings
+}
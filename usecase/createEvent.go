@@ -3,6 +3,9 @@ package usecase
 import (
 	"chikokulympic-api/domain/entity"
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"fmt"
+	"time"
 )
 
 type CreateEventUseCase interface {
@@ -10,38 +13,60 @@ type CreateEventUseCase interface {
 }
 
 type CreateEventUseCaseImpl struct {
-	eventRepo repository.EventRepository
-	groupRepo repository.GroupRepository
-	event     *entity.Event
-	groupID   entity.GroupID
+	eventRepo  repository.EventRepository
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	notifier   service.Notifier
+	event      *entity.Event
+	groupID    entity.GroupID
 }
 
-func NewCreateEventUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, event *entity.Event, groupID entity.GroupID) *CreateEventUseCaseImpl {
+// NewCreateEventUseCase はグループにイベントを作成する。作成できるのはグループのメンバーのみ
+// イベントの作成とグループへの追加はまとめて行い、どちらかに失敗した場合はイベントを残さない
+// 作成したら作成者以外のメンバーに通知する
+func NewCreateEventUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, notifier service.Notifier, event *entity.Event, groupID entity.GroupID) *CreateEventUseCaseImpl {
 	return &CreateEventUseCaseImpl{
-		eventRepo: eventRepo,
-		groupRepo: groupRepo,
-		event:     event,
-		groupID:   groupID,
+		eventRepo:  eventRepo,
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		notifier:   notifier,
+		event:      event,
+		groupID:    groupID,
 	}
 }
 
 func (uc *CreateEventUseCaseImpl) Execute() (*entity.Event, error) {
-	createdEvent, err := uc.eventRepo.CreateEvent(*uc.event)
-	if err != nil {
-		return nil, err
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.event.EventAuthorID) {
+		return nil, ErrNotGroupMember
 	}
 
-	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	seasonID, err := findEventSeason(uc.seasonRepo, group.GroupID, time.Time(uc.event.EventStartDateTime))
 	if err != nil {
 		return nil, err
 	}
+	uc.event.SeasonID = seasonID
 
-	group.GroupEvents = append(group.GroupEvents, createdEvent.EventID)
-
-	_, err = uc.groupRepo.UpdateGroup(*group)
+	createdEvent, err := uc.eventRepo.CreateEventInGroup(*uc.event, group.GroupID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("イベントの作成に失敗しました: %w", err)
 	}
+	notifyNewEvent(uc.notifier, group, createdEvent)
 
 	return createdEvent, nil
 }
+
+// findEventSeason は開始時刻を含むシーズンを返す。締めたシーズンには追加しないため、その場合は空になる
+func findEventSeason(seasonRepo repository.SeasonRepository, groupID entity.GroupID, start time.Time) (entity.SeasonID, error) {
+	season, err := seasonRepo.FindSeasonByTime(groupID, start)
+	if err != nil {
+		return "", fmt.Errorf("シーズンの取得に失敗しました: %w", err)
+	}
+	if season == nil || season.IsClosed() {
+		return "", nil
+	}
+	return season.SeasonID, nil
+}
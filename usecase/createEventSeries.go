@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidEventTime        = errors.New("event must end after it starts")
+	ErrInvalidTimeZone         = errors.New("unknown time zone")
+	ErrRecurrenceStartMismatch = errors.New("start time does not match the recurrence rule")
+)
+
+type CreateEventSeriesResponse struct {
+	Series     *entity.EventSeries `json:"series"`
+	FirstEvent *entity.Event       `json:"first_event"`
+}
+
+type CreateEventSeriesUseCase interface {
+	Execute() (*CreateEventSeriesResponse, error)
+}
+
+type CreateEventSeriesUseCaseImpl struct {
+	seriesRepo   repository.EventSeriesRepository
+	groupRepo    repository.GroupRepository
+	materializer *EventSeriesMaterializer
+	notifier     service.Notifier
+	event        *entity.Event
+	groupID      entity.GroupID
+	rule         entity.RecurrenceRule
+	timeZone     string
+}
+
+// NewCreateEventSeriesUseCase は event を最初の回とする繰り返しイベントを作成し、一定期間先までの回を作成する
+// 最初の回の開始日時は timeZone で数えたときにルールの最初の回と一致していなければならない
+// 作成者以外のメンバーには最初の回だけを通知し、先まで作成した回は通知しない
+func NewCreateEventSeriesUseCase(seriesRepo repository.EventSeriesRepository, groupRepo repository.GroupRepository, materializer *EventSeriesMaterializer, notifier service.Notifier, event *entity.Event, groupID entity.GroupID, rule entity.RecurrenceRule, timeZone string) *CreateEventSeriesUseCaseImpl {
+	return &CreateEventSeriesUseCaseImpl{
+		seriesRepo:   seriesRepo,
+		groupRepo:    groupRepo,
+		materializer: materializer,
+		notifier:     notifier,
+		event:        event,
+		groupID:      groupID,
+		rule:         rule,
+		timeZone:     timeZone,
+	}
+}
+
+func (uc *CreateEventSeriesUseCaseImpl) Execute() (*CreateEventSeriesResponse, error) {
+	start := time.Time(uc.event.EventStartDateTime)
+	end := time.Time(uc.event.EventEndDateTime)
+	if !start.Before(end) {
+		return nil, ErrInvalidEventTime
+	}
+
+	if uc.timeZone == "" {
+		uc.timeZone = entity.DefaultTimeZone
+	}
+	location, err := time.LoadLocation(uc.timeZone)
+	if err != nil {
+		return nil, ErrInvalidTimeZone
+	}
+
+	if err := uc.rule.Validate(); err != nil {
+		return nil, err
+	}
+	if !recurrenceStartsAt(uc.rule, start, location) {
+		return nil, ErrRecurrenceStartMismatch
+	}
+
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.event.EventAuthorID) {
+		return nil, ErrNotGroupMember
+	}
+
+	series, err := uc.seriesRepo.CreateSeries(entity.EventSeries{
+		GroupID:         uc.groupID,
+		Rule:            uc.rule,
+		TimeZone:        uc.timeZone,
+		StartDateTime:   start,
+		Duration:        end.Sub(start),
+		ClosingLeadTime: start.Sub(time.Time(uc.event.EventClosingDateTime)),
+		Template: entity.EventTemplate{
+			EventTitle:        uc.event.EventTitle,
+			EventDescription:  uc.event.EventDescription,
+			EventLocationName: uc.event.EventLocationName,
+			Cost:              uc.event.Cost,
+			EventMessage:      uc.event.EventMessage,
+			EventAuthorID:     uc.event.EventAuthorID,
+			Latitude:          uc.event.Latitude,
+			Longitude:         uc.event.Longitude,
+			Capacity:          uc.event.Capacity,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("繰り返しイベントの作成に失敗しました: %w", err)
+	}
+
+	// 最初の回が作成期間より先にあっても、作成した回を返せるよう少なくとも最初の回までは作成する
+	horizon := time.Now().Add(uc.materializer.window)
+	if !start.Before(horizon) {
+		horizon = start.Add(time.Second)
+	}
+
+	created, err := uc.materializer.materialize(series, horizon)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateEventSeriesResponse{Series: series}
+	if len(created) > 0 {
+		response.FirstEvent = &created[0]
+		notifyNewEvent(uc.notifier, group, response.FirstEvent)
+	}
+	return response, nil
+}
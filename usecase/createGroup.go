@@ -3,6 +3,7 @@ package usecase
 import (
 	"chikokulympic-api/domain/entity"
 	"chikokulympic-api/domain/repository"
+	"errors"
 	"fmt"
 )
 
@@ -40,5 +41,10 @@ func (uc *CreateGroupUseCaseImpl) Execute() (*entity.Group, error) {
 
 	uc.group.GroupMembers = append(uc.group.GroupMembers, uc.group.GroupManagerID)
 
-	return uc.groupRepo.CreateGroup(*uc.group)
+	// 事前の確認と作成の間に同じ名前で作成された場合は一意インデックスで弾かれる
+	createdGroup, err := uc.groupRepo.CreateGroup(*uc.group)
+	if errors.Is(err, repository.ErrDuplicateGroupName) {
+		return nil, fmt.Errorf("グループ名 '%s' は既に使用されています", string(uc.group.GroupName))
+	}
+	return createdGroup, err
 }
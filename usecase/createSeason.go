@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidSeason       = errors.New("invalid season")
+	ErrSeasonOverlap       = errors.New("season overlaps an existing season")
+	ErrSeasonNotFound      = errors.New("season not found")
+	ErrSeasonAlreadyClosed = errors.New("season already closed")
+)
+
+type CreateSeasonUseCase interface {
+	Execute() (*entity.Season, error)
+}
+
+type CreateSeasonUseCaseImpl struct {
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	eventRepo  repository.EventRepository
+	scoreRepo  repository.ScoreRepository
+	userID     entity.UserID
+	groupID    entity.GroupID
+	name       entity.SeasonName
+	start      time.Time
+	end        time.Time
+}
+
+// NewCreateSeasonUseCase はグループにシーズンを追加し、期間内に始まる既存のイベントとそのスコアを割り当てる
+// 作成できるのはグループの管理者のみで、既存のシーズンと期間が重なってはいけない
+func NewCreateSeasonUseCase(groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, eventRepo repository.EventRepository, scoreRepo repository.ScoreRepository, userID entity.UserID, groupID entity.GroupID, name entity.SeasonName, start time.Time, end time.Time) *CreateSeasonUseCaseImpl {
+	return &CreateSeasonUseCaseImpl{
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		eventRepo:  eventRepo,
+		scoreRepo:  scoreRepo,
+		userID:     userID,
+		groupID:    groupID,
+		name:       name,
+		start:      start,
+		end:        end,
+	}
+}
+
+func (uc *CreateSeasonUseCaseImpl) Execute() (*entity.Season, error) {
+	if uc.name == "" || uc.start.IsZero() || !uc.start.Before(uc.end) {
+		return nil, ErrInvalidSeason
+	}
+
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.GroupManagerID != uc.userID {
+		return nil, ErrNotGroupManager
+	}
+
+	seasons, err := uc.seasonRepo.FindSeasonsByGroupID(group.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("シーズンの取得に失敗しました: %w", err)
+	}
+	for _, season := range seasons {
+		if uc.start.Before(season.EndDateTime) && season.StartDateTime.Before(uc.end) {
+			return nil, ErrSeasonOverlap
+		}
+	}
+
+	created, err := uc.seasonRepo.CreateSeason(entity.Season{
+		GroupID:       group.GroupID,
+		Name:          uc.name,
+		StartDateTime: uc.start,
+		EndDateTime:   uc.end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("シーズンの作成に失敗しました: %w", err)
+	}
+
+	if _, err := uc.eventRepo.AssignSeason(group.GroupEvents, created.SeasonID, created.StartDateTime, created.EndDateTime); err != nil {
+		return nil, fmt.Errorf("イベントのシーズンへの割り当てに失敗しました: %w", err)
+	}
+	// シーズンより前に確定したイベントのスコアにはシーズンがないため、締めや順位表に含まれるよう割り当てる
+	if _, err := uc.scoreRepo.AssignSeason(group.GroupID, created.SeasonID, created.StartDateTime, created.EndDateTime); err != nil {
+		return nil, fmt.Errorf("スコアのシーズンへの割り当てに失敗しました: %w", err)
+	}
+
+	return created, nil
+}
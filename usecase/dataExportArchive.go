@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"archive/zip"
+	"chikokulympic-api/domain/entity"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// exportedProfile は書き出すプロフィール。認証やプッシュ通知、カレンダーの購読に使う秘密の値は含めない
+type exportedProfile struct {
+	UserID               entity.UserID    `json:"user_id"`
+	UserName             entity.UserName  `json:"user_name"`
+	UserIcon             entity.UserIcon  `json:"user_icon"`
+	Alias                entity.Alias     `json:"alias"`
+	LocationOptOutGroups []entity.GroupID `json:"location_opt_out_groups"`
+}
+
+// exportedGroup は所属しているグループ。参加用のパスワードは含めない
+type exportedGroup struct {
+	GroupID          entity.GroupID          `json:"group_id"`
+	GroupName        entity.GroupName        `json:"group_name"`
+	GroupDescription entity.GroupDescription `json:"group_description"`
+	IsManager        bool                    `json:"is_manager"`
+	MemberCount      int                     `json:"member_count"`
+}
+
+// exportedEvent は作成したイベント
+type exportedEvent struct {
+	EventID           entity.EventID          `json:"event_id"`
+	GroupID           entity.GroupID          `json:"group_id,omitempty"`
+	EventTitle        entity.EventTitle       `json:"event_title"`
+	EventDescription  entity.EventDescription `json:"event_description"`
+	EventLocationName entity.LocationName     `json:"event_location_name"`
+	Cost              entity.Cost             `json:"cost"`
+	EventStart        time.Time               `json:"event_start_date_time"`
+	EventEnd          time.Time               `json:"event_end_date_time"`
+	EventClosing      time.Time               `json:"event_closing_date_time"`
+}
+
+// exportedVote はイベントへの投票と到着の記録
+type exportedVote struct {
+	EventID         entity.EventID    `json:"event_id"`
+	EventTitle      entity.EventTitle `json:"event_title"`
+	EventStart      time.Time         `json:"event_start_date_time"`
+	Vote            entity.Vote       `json:"vote"`
+	Waitlisted      bool              `json:"waitlisted"`
+	IsArrival       bool              `json:"is_arrival"`
+	ArrivalDateTime *time.Time        `json:"arrival_date_time,omitempty"`
+	ArrivalReview   string            `json:"arrival_review,omitempty"`
+	Paid            bool              `json:"paid"`
+}
+
+type dataExport struct {
+	ExportedAt time.Time                   `json:"exported_at"`
+	Profile    exportedProfile             `json:"profile"`
+	Groups     []exportedGroup             `json:"groups"`
+	Events     []exportedEvent             `json:"events"`
+	Votes      []exportedVote              `json:"votes"`
+	Locations  []entity.LocationTrailPoint `json:"locations"`
+	Titles     []entity.Title              `json:"titles"`
+}
+
+func newDataExport(user *entity.User, groups []*entity.Group, events []entity.Event, locations []entity.LocationTrailPoint, titles []entity.Title, now time.Time) *dataExport {
+	data := &dataExport{
+		ExportedAt: now,
+		Profile: exportedProfile{
+			UserID:               user.UserID,
+			UserName:             user.UserName,
+			UserIcon:             user.UserIcon,
+			Alias:                user.Alias,
+			LocationOptOutGroups: user.LocationOptOutGroups,
+		},
+		Groups:    []exportedGroup{},
+		Events:    []exportedEvent{},
+		Votes:     []exportedVote{},
+		Locations: locations,
+		Titles:    titles,
+	}
+
+	eventGroups := make(map[entity.EventID]entity.GroupID)
+	for _, group := range groups {
+		data.Groups = append(data.Groups, exportedGroup{
+			GroupID:          group.GroupID,
+			GroupName:        group.GroupName,
+			GroupDescription: group.GroupDescription,
+			IsManager:        group.GroupManagerID == user.UserID,
+			MemberCount:      len(group.GroupMembers),
+		})
+		for _, eventID := range group.GroupEvents {
+			eventGroups[eventID] = group.GroupID
+		}
+	}
+
+	for _, event := range events {
+		if event.EventAuthorID == user.UserID {
+			data.Events = append(data.Events, exportedEvent{
+				EventID:           event.EventID,
+				GroupID:           eventGroups[event.EventID],
+				EventTitle:        event.EventTitle,
+				EventDescription:  event.EventDescription,
+				EventLocationName: event.EventLocationName,
+				Cost:              event.Cost,
+				EventStart:        time.Time(event.EventStartDateTime),
+				EventEnd:          time.Time(event.EventEndDateTime),
+				EventClosing:      time.Time(event.EventClosingDateTime),
+			})
+		}
+
+		_, paid := event.Payments[user.UserID]
+		vote := exportedVote{
+			EventID:    event.EventID,
+			EventTitle: event.EventTitle,
+			EventStart: time.Time(event.EventStartDateTime),
+			Waitlisted: isWaitlisted(&event, user.UserID),
+			Paid:       paid,
+		}
+		for _, member := range event.VotedMembers {
+			if member.UserID != user.UserID {
+				continue
+			}
+			vote.Vote = member.Vote
+			vote.IsArrival = member.IsArrival
+			if member.IsArrival {
+				arrival := member.ArrivalDateTime
+				vote.ArrivalDateTime = &arrival
+			}
+			if member.ArrivalFlag != nil {
+				vote.ArrivalReview = string(member.ArrivalFlag.Status)
+			}
+		}
+		if vote.Vote != "" || vote.Waitlisted {
+			data.Votes = append(data.Votes, vote)
+		}
+	}
+
+	return data
+}
+
+// writeDataExportArchive は同じ内容を JSON と CSV の両方で ZIP に書き出す
+func writeDataExportArchive(w io.Writer, data *dataExport) error {
+	archive := zip.NewWriter(w)
+
+	if err := writeArchiveJSON(archive, "data.json", data); err != nil {
+		return err
+	}
+	if err := writeArchiveJSON(archive, "profile.json", data.Profile); err != nil {
+		return err
+	}
+
+	groups := [][]string{{"group_id", "group_name", "group_description", "is_manager", "member_count"}}
+	for _, group := range data.Groups {
+		groups = append(groups, []string{string(group.GroupID), string(group.GroupName), string(group.GroupDescription), strconv.FormatBool(group.IsManager), strconv.Itoa(group.MemberCount)})
+	}
+
+	events := [][]string{{"event_id", "group_id", "event_title", "event_description", "event_location_name", "cost", "event_start_date_time", "event_end_date_time", "event_closing_date_time"}}
+	for _, event := range data.Events {
+		events = append(events, []string{string(event.EventID), string(event.GroupID), string(event.EventTitle), string(event.EventDescription), string(event.EventLocationName), strconv.Itoa(int(event.Cost)), formatExportTime(event.EventStart), formatExportTime(event.EventEnd), formatExportTime(event.EventClosing)})
+	}
+
+	votes := [][]string{{"event_id", "event_title", "event_start_date_time", "vote", "waitlisted", "is_arrival", "arrival_date_time", "arrival_review", "paid"}}
+	for _, vote := range data.Votes {
+		arrival := ""
+		if vote.ArrivalDateTime != nil {
+			arrival = formatExportTime(*vote.ArrivalDateTime)
+		}
+		votes = append(votes, []string{string(vote.EventID), string(vote.EventTitle), formatExportTime(vote.EventStart), string(vote.Vote), strconv.FormatBool(vote.Waitlisted), strconv.FormatBool(vote.IsArrival), arrival, vote.ArrivalReview, strconv.FormatBool(vote.Paid)})
+	}
+
+	locations := [][]string{{"event_id", "latitude", "longitude", "recorded_at"}}
+	for _, point := range data.Locations {
+		locations = append(locations, []string{string(point.EventID), strconv.FormatFloat(float64(point.Latitude), 'f', -1, 64), strconv.FormatFloat(float64(point.Longitude), 'f', -1, 64), formatExportTime(point.RecordedAt)})
+	}
+
+	titles := [][]string{{"title_id", "group_id", "name", "description", "awarded_at", "revoked_at"}}
+	for _, title := range data.Titles {
+		revoked := ""
+		if title.RevokedAt != nil {
+			revoked = formatExportTime(*title.RevokedAt)
+		}
+		titles = append(titles, []string{string(title.TitleID), string(title.GroupID), string(title.Name), title.Description, formatExportTime(title.AwardedAt), revoked})
+	}
+
+	tables := []struct {
+		name string
+		json any
+		rows [][]string
+	}{
+		{"groups", data.Groups, groups},
+		{"events", data.Events, events},
+		{"votes", data.Votes, votes},
+		{"locations", data.Locations, locations},
+		{"titles", data.Titles, titles},
+	}
+	for _, table := range tables {
+		if err := writeArchiveJSON(archive, table.name+".json", table.json); err != nil {
+			return err
+		}
+		if err := writeArchiveCSV(archive, table.name+".csv", table.rows); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("アーカイブの書き出しに失敗しました: %w", err)
+	}
+	return nil
+}
+
+func writeArchiveJSON(archive *zip.Writer, name string, v any) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("%s の作成に失敗しました: %w", name, err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("%s の書き出しに失敗しました: %w", name, err)
+	}
+	return nil
+}
+
+func writeArchiveCSV(archive *zip.Writer, name string, rows [][]string) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("%s の作成に失敗しました: %w", name, err)
+	}
+	// 表計算ソフトで開いたときに文字化けしないよう BOM を付ける
+	if _, err := file.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return fmt.Errorf("%s の書き出しに失敗しました: %w", name, err)
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("%s の書き出しに失敗しました: %w", name, err)
+	}
+	return nil
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+)
+
+var (
+	ErrExportJobNotFound = errors.New("export job not found")
+	ErrExportNotReady    = errors.New("export is not ready")
+	ErrExportLinkExpired = errors.New("export download link has expired")
+)
+
+// ダウンロード用のリンクに含める秘密の値のバイト数
+const exportDownloadTokenSize = 32
+
+// 作成中のまま残ったジョブを中断されたとみなすまでの時間
+const exportJobStaleAfter = time.Hour
+
+// DataExporter はユーザーの個人データをまとめたアーカイブをバックグラウンドで作成する
+// 依頼を受けたジョブは ProcessPending で順に処理し、ダウンロードの期限が過ぎたアーカイブは PurgeExpired で削除する
+type DataExporter struct {
+	jobRepo     repository.ExportJobRepository
+	userRepo    repository.UserRepository
+	groupRepo   repository.GroupRepository
+	eventRepo   repository.EventRepository
+	historyRepo repository.LocationHistoryRepository
+	titleRepo   repository.TitleRepository
+	blobStore   service.BlobStore
+	linkTTL     time.Duration
+	wake        chan struct{}
+}
+
+func NewDataExporter(jobRepo repository.ExportJobRepository, userRepo repository.UserRepository, groupRepo repository.GroupRepository, eventRepo repository.EventRepository, historyRepo repository.LocationHistoryRepository, titleRepo repository.TitleRepository, blobStore service.BlobStore, linkTTL time.Duration) *DataExporter {
+	return &DataExporter{
+		jobRepo:     jobRepo,
+		userRepo:    userRepo,
+		groupRepo:   groupRepo,
+		eventRepo:   eventRepo,
+		historyRepo: historyRepo,
+		titleRepo:   titleRepo,
+		blobStore:   blobStore,
+		linkTTL:     linkTTL,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Wake は新しいジョブが依頼されたときに通知する
+func (e *DataExporter) Wake() <-chan struct{} {
+	return e.wake
+}
+
+// RequestExport はアーカイブの作成を依頼する
+// 作成中のジョブやダウンロードできるアーカイブがあれば、新しく作らずにそのジョブを返す
+func (e *DataExporter) RequestExport(userID entity.UserID) (*entity.ExportJob, error) {
+	latest, err := e.jobRepo.FindLatestJobByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ジョブの取得に失敗しました: %w", err)
+	}
+	now := time.Now()
+	if latest != nil && (latest.IsActive() || latest.IsDownloadable(now)) {
+		return latest, nil
+	}
+
+	token := make([]byte, exportDownloadTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("ダウンロード用トークンの生成に失敗しました: %w", err)
+	}
+
+	job, err := e.jobRepo.CreateJob(entity.ExportJob{
+		UserID:        userID,
+		Status:        entity.ExportJobPending,
+		DownloadToken: hex.EncodeToString(token),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ジョブの作成に失敗しました: %w", err)
+	}
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+// FindJob はユーザー本人のジョブを返す
+func (e *DataExporter) FindJob(userID entity.UserID, jobID entity.ExportJobID) (*entity.ExportJob, error) {
+	job, err := e.jobRepo.FindJobByJobID(jobID)
+	if err != nil || job == nil || job.UserID != userID {
+		return nil, ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+// OpenArchive はダウンロード用のリンクに含まれるトークンを確かめ、アーカイブを開く
+func (e *DataExporter) OpenArchive(jobID entity.ExportJobID, token string, now time.Time) (io.ReadCloser, *entity.ExportJob, error) {
+	job, err := e.jobRepo.FindJobByJobID(jobID)
+	if err != nil || job == nil {
+		return nil, nil, ErrExportJobNotFound
+	}
+	if subtle.ConstantTimeCompare([]byte(job.DownloadToken), []byte(token)) != 1 {
+		return nil, nil, ErrExportJobNotFound
+	}
+	if job.IsActive() || job.Status == entity.ExportJobFailed {
+		return nil, nil, ErrExportNotReady
+	}
+	if !job.IsDownloadable(now) {
+		return nil, nil, ErrExportLinkExpired
+	}
+
+	archive, err := e.blobStore.Open(job.BlobKey)
+	if errors.Is(err, service.ErrBlobNotFound) {
+		return nil, nil, ErrExportLinkExpired
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("アーカイブの読み込みに失敗しました: %w", err)
+	}
+
+	return archive, job, nil
+}
+
+// ProcessPending は作成待ちのジョブを古い順にすべて処理する
+// 1 つのジョブで失敗してもそのジョブを失敗として記録し、残りは続ける
+// 作成中のままサーバーが止まったジョブは失敗として記録し、改めて依頼できるようにする
+func (e *DataExporter) ProcessPending() error {
+	var errs []error
+
+	running, err := e.jobRepo.FindJobsByStatus(entity.ExportJobRunning)
+	if err != nil {
+		return fmt.Errorf("ジョブの取得に失敗しました: %w", err)
+	}
+	for _, job := range running {
+		if job.StartedAt != nil && time.Since(*job.StartedAt) > exportJobStaleAfter {
+			if err := e.jobRepo.FailJob(job.JobID, "作成が中断されました"); err != nil {
+				errs = append(errs, fmt.Errorf("job %s: %w", job.JobID, err))
+			}
+		}
+	}
+
+	jobs, err := e.jobRepo.FindJobsByStatus(entity.ExportJobPending)
+	if err != nil {
+		return fmt.Errorf("ジョブの取得に失敗しました: %w", err)
+	}
+
+	for _, job := range jobs {
+		claimed, err := e.jobRepo.ClaimJob(job.JobID, time.Now())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.JobID, err))
+			continue
+		}
+		// 他のインスタンスが処理している
+		if !claimed {
+			continue
+		}
+
+		if err := e.build(job); err != nil {
+			log.Printf("WARN: Failed to build data export %s: %v", job.JobID, err)
+			if err := e.jobRepo.FailJob(job.JobID, err.Error()); err != nil {
+				errs = append(errs, fmt.Errorf("job %s: %w", job.JobID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PurgeExpired はダウンロードの期限が過ぎたアーカイブを削除する
+func (e *DataExporter) PurgeExpired(now time.Time) error {
+	jobs, err := e.jobRepo.FindExpiredJobs(now)
+	if err != nil {
+		return fmt.Errorf("ジョブの取得に失敗しました: %w", err)
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		if err := e.blobStore.Delete(job.BlobKey); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.JobID, err))
+			continue
+		}
+		if err := e.jobRepo.MarkJobExpired(job.JobID); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.JobID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// build はユーザーのデータを集めてアーカイブを保存し、ジョブを完了にする
+func (e *DataExporter) build(job entity.ExportJob) error {
+	data, err := e.collect(job.UserID)
+	if err != nil {
+		return err
+	}
+
+	// アカウントが大きくてもメモリに載せきらないよう、書き出しながら保存する
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeDataExportArchive(writer, data))
+	}()
+
+	key := fmt.Sprintf("exports/%s/%s.zip", job.UserID, job.JobID)
+	size, err := e.blobStore.Put(key, reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("アーカイブの保存に失敗しました: %w", err)
+	}
+
+	completedAt := time.Now()
+	if err := e.jobRepo.CompleteJob(job.JobID, key, size, completedAt, completedAt.Add(e.linkTTL)); err != nil {
+		return fmt.Errorf("ジョブの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+func (e *DataExporter) collect(userID entity.UserID) (*dataExport, error) {
+	user, err := e.userRepo.FindUserByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの取得に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	groups, err := e.groupRepo.FindGroupsByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("グループの取得に失敗しました: %w", err)
+	}
+
+	events, err := e.eventRepo.FindEventsByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの取得に失敗しました: %w", err)
+	}
+
+	locations, err := e.historyRepo.FindHistoryByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("位置履歴の取得に失敗しました: %w", err)
+	}
+
+	titles, err := e.titleRepo.FindTitlesByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("称号の取得に失敗しました: %w", err)
+	}
+
+	return newDataExport(user, groups, events, locations, titles, time.Now()), nil
+}
@@ -12,13 +12,17 @@ type DeleteEventUseCase interface {
 
 type DeleteEventUseCaseImpl struct {
 	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	cache     *LeaderboardCache
 	eventID   *entity.EventID
 	authID    *entity.UserID
 }
 
-func NewDeleteEventUseCase(eventRepo repository.EventRepository, eventID *entity.EventID, authID *entity.UserID) *DeleteEventUseCaseImpl {
+func NewDeleteEventUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, cache *LeaderboardCache, eventID *entity.EventID, authID *entity.UserID) *DeleteEventUseCaseImpl {
 	return &DeleteEventUseCaseImpl{
 		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		cache:     cache,
 		eventID:   eventID,
 		authID:    authID,
 	}
@@ -37,5 +41,19 @@ func (uc *DeleteEventUseCaseImpl) Execute() (*entity.Event, error) {
 		return nil, fmt.Errorf("not authorized to delete this event")
 	}
 
-	return uc.eventRepo.DeleteEvent(*event)
+	// 削除するとグループから辿れなくなるため、先にグループを調べておく
+	group, err := uc.groupRepo.FindGroupByEventID(event.EventID)
+	if err != nil {
+		group = nil
+	}
+
+	deleted, err := uc.eventRepo.DeleteEvent(*event)
+	if err != nil {
+		return nil, err
+	}
+	if group != nil {
+		uc.cache.InvalidateGroup(group.GroupID)
+	}
+
+	return deleted, nil
 }
\ No newline at end of file
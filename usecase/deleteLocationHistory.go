@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type DeleteLocationHistoryUseCase interface {
+	Execute() (int64, error)
+}
+
+type DeleteLocationHistoryUseCaseImpl struct {
+	locationRepo repository.LocationRepository
+	historyRepo  repository.LocationHistoryRepository
+	userID       entity.UserID
+}
+
+func NewDeleteLocationHistoryUseCase(locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, userID entity.UserID) *DeleteLocationHistoryUseCaseImpl {
+	return &DeleteLocationHistoryUseCaseImpl{
+		locationRepo: locationRepo,
+		historyRepo:  historyRepo,
+		userID:       userID,
+	}
+}
+
+// Execute は位置履歴と最新位置をすべて削除し、削除した履歴の件数を返す
+func (uc *DeleteLocationHistoryUseCaseImpl) Execute() (int64, error) {
+	deleted, err := uc.historyRepo.DeleteHistoryByUserID(uc.userID)
+	if err != nil {
+		return 0, fmt.Errorf("位置履歴の削除に失敗しました: %w", err)
+	}
+
+	if _, err := uc.locationRepo.DeleteLocation(entity.UserLocation{UserID: uc.userID}); err != nil {
+		return deleted, fmt.Errorf("最新位置の削除に失敗しました: %w", err)
+	}
+
+	return deleted, nil
+}
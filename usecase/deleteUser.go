@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUserManagesGroup = errors.New("user manages a group")
+
+// 退会したユーザーの記録を置き換える ID に使う乱数のバイト数
+const anonymousIDSize = 12
+
+type DeleteUserResponse struct {
+	UserID entity.UserID `json:"user_id"`
+	// 管理者を他のメンバーに引き継いだグループ
+	TransferredGroups []entity.GroupID `json:"transferred_groups"`
+	// メンバーから外したグループの数
+	LeftGroups int64 `json:"left_groups"`
+	// 投票や作成者を匿名の ID に置き換えたイベントの数
+	AnonymizedEvents int64 `json:"anonymized_events"`
+	// これから始まるイベントから投票やキャンセル待ちを取り除いた数
+	RemovedFromEvents int64 `json:"removed_from_events"`
+	AnonymizedScores  int64 `json:"anonymized_scores"`
+	DeletedTitles     int64 `json:"deleted_titles"`
+	DeletedLocations  int64 `json:"deleted_locations"`
+	DeletedDevices    int64 `json:"deleted_devices"`
+}
+
+type DeleteUserUseCase interface {
+	Execute() (*DeleteUserResponse, error)
+}
+
+type DeleteUserUseCaseImpl struct {
+	userRepo     repository.UserRepository
+	groupRepo    repository.GroupRepository
+	eventRepo    repository.EventRepository
+	scoreRepo    repository.ScoreRepository
+	titleRepo    repository.TitleRepository
+	locationRepo repository.LocationRepository
+	historyRepo  repository.LocationHistoryRepository
+	auditRepo    repository.AuditLogRepository
+	deviceRepo   repository.DeviceRepository
+	settingsRepo repository.NotificationSettingsRepository
+	queueRepo    repository.NotificationQueueRepository
+	blobStore    service.BlobStore
+	cache        *LeaderboardCache
+	userID       entity.UserID
+	actor        string
+}
+
+// NewDeleteUserUseCase はユーザーを退会させる
+// 管理しているグループは最も古くから所属している他のメンバーに引き継ぎ、引き継げるメンバーがいなければ退会できない
+// 終了したイベントの記録は匿名の ID に置き換えて残し、過去のランキングや精算が変わらないようにする
+// actor には監査ログに記録する操作の主体を渡す
+func NewDeleteUserUseCase(userRepo repository.UserRepository, groupRepo repository.GroupRepository, eventRepo repository.EventRepository, scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, auditRepo repository.AuditLogRepository, deviceRepo repository.DeviceRepository, settingsRepo repository.NotificationSettingsRepository, queueRepo repository.NotificationQueueRepository, blobStore service.BlobStore, cache *LeaderboardCache, userID entity.UserID, actor string) *DeleteUserUseCaseImpl {
+	return &DeleteUserUseCaseImpl{
+		userRepo:     userRepo,
+		groupRepo:    groupRepo,
+		eventRepo:    eventRepo,
+		scoreRepo:    scoreRepo,
+		titleRepo:    titleRepo,
+		locationRepo: locationRepo,
+		historyRepo:  historyRepo,
+		auditRepo:    auditRepo,
+		deviceRepo:   deviceRepo,
+		settingsRepo: settingsRepo,
+		queueRepo:    queueRepo,
+		blobStore:    blobStore,
+		cache:        cache,
+		userID:       userID,
+		actor:        actor,
+	}
+}
+
+func (uc *DeleteUserUseCaseImpl) Execute() (*DeleteUserResponse, error) {
+	user, err := uc.userRepo.FindUserByUserID(uc.userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの取得に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	groups, err := uc.groupRepo.FindGroupsByUserID(uc.userID)
+	if err != nil {
+		return nil, fmt.Errorf("グループの取得に失敗しました: %w", err)
+	}
+
+	// 何も変更しないうちに、すべてのグループを引き継げるか確かめる
+	successors := make(map[entity.GroupID]entity.UserID)
+	for _, group := range groups {
+		if group.GroupManagerID != uc.userID {
+			continue
+		}
+		successor := findSuccessor(group, uc.userID)
+		if successor == "" {
+			return nil, fmt.Errorf("%w: %s", ErrUserManagesGroup, group.GroupID)
+		}
+		successors[group.GroupID] = successor
+	}
+
+	// 途中で失敗してやり直した場合も記録が同じ ID にまとまるよう、何か変更する前に ID を保存する
+	anonymousID := user.AnonymousID
+	if anonymousID == "" {
+		candidate, err := newAnonymousUserID()
+		if err != nil {
+			return nil, err
+		}
+		if anonymousID, err = uc.userRepo.ReserveAnonymousID(uc.userID, candidate); err != nil {
+			return nil, fmt.Errorf("匿名の ID の保存に失敗しました: %w", err)
+		}
+	}
+
+	// 以前に所属していたグループのイベントの記録も書き換えるため、すべてのリーダーボードを破棄する
+	// 途中で失敗した場合も、書き換えた分が反映されるよう終了時に破棄する
+	defer uc.cache.InvalidateAll()
+
+	// 途中で失敗してもやり直せるよう、ユーザー本体は最後に削除する
+	response := &DeleteUserResponse{UserID: uc.userID, TransferredGroups: []entity.GroupID{}}
+	for _, group := range groups {
+		successor, ok := successors[group.GroupID]
+		if !ok {
+			continue
+		}
+		if err := uc.groupRepo.TransferGroupManager(group.GroupID, uc.userID, successor); err != nil {
+			return nil, fmt.Errorf("管理者の引き継ぎに失敗しました: %w", err)
+		}
+		response.TransferredGroups = append(response.TransferredGroups, group.GroupID)
+	}
+
+	if response.LeftGroups, err = uc.groupRepo.RemoveMemberFromGroups(uc.userID); err != nil {
+		return nil, fmt.Errorf("グループからの脱退に失敗しました: %w", err)
+	}
+
+	if response.AnonymizedEvents, err = uc.eventRepo.AnonymizeUserInEvents(uc.userID, anonymousID, time.Now()); err != nil {
+		return nil, fmt.Errorf("イベントの記録の匿名化に失敗しました: %w", err)
+	}
+	// 匿名化しなかったこれからのイベントには参加しない
+	if response.RemovedFromEvents, err = uc.eventRepo.RemoveUserFromEvents(uc.userID); err != nil {
+		return nil, fmt.Errorf("投票の削除に失敗しました: %w", err)
+	}
+
+	if response.AnonymizedScores, err = uc.scoreRepo.ReassignUser(uc.userID, anonymousID); err != nil {
+		return nil, fmt.Errorf("スコアの匿名化に失敗しました: %w", err)
+	}
+	if response.DeletedTitles, err = uc.titleRepo.DeleteTitlesByUserID(uc.userID); err != nil {
+		return nil, fmt.Errorf("称号の削除に失敗しました: %w", err)
+	}
+
+	if response.DeletedLocations, err = NewDeleteLocationHistoryUseCase(uc.locationRepo, uc.historyRepo, uc.userID).Execute(); err != nil {
+		return nil, err
+	}
+	if response.DeletedDevices, err = uc.deviceRepo.DeleteDevicesByUserID(uc.userID); err != nil {
+		return nil, fmt.Errorf("端末の削除に失敗しました: %w", err)
+	}
+	if err := uc.settingsRepo.DeleteSettingsByUserID(uc.userID); err != nil {
+		return nil, fmt.Errorf("通知の設定の削除に失敗しました: %w", err)
+	}
+	if err := uc.queueRepo.DeleteQueuedNotificationsByUserID(uc.userID); err != nil {
+		return nil, fmt.Errorf("保留中の通知の削除に失敗しました: %w", err)
+	}
+	if err := deleteUserIcons(uc.blobStore, uc.userID); err != nil {
+		return nil, err
+	}
+
+	// 監査ログを残せなかった場合はユーザーを削除せず、やり直せるようにする
+	_, err = uc.auditRepo.AppendAuditLog(entity.AuditLog{
+		Action:       entity.AuditActionUserDeleted,
+		TargetUserID: uc.userID,
+		Actor:        uc.actor,
+		Details: map[string]int64{
+			"transferred_groups":  int64(len(response.TransferredGroups)),
+			"left_groups":         response.LeftGroups,
+			"anonymized_events":   response.AnonymizedEvents,
+			"removed_from_events": response.RemovedFromEvents,
+			"anonymized_scores":   response.AnonymizedScores,
+			"deleted_titles":      response.DeletedTitles,
+			"deleted_locations":   response.DeletedLocations,
+			"deleted_devices":     response.DeletedDevices,
+		},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("監査ログの記録に失敗しました: %w", err)
+	}
+
+	if _, err := uc.userRepo.DeleteUser(*user); err != nil {
+		return nil, fmt.Errorf("ユーザーの削除に失敗しました: %w", err)
+	}
+
+	return response, nil
+}
+
+// findSuccessor は管理者の次に古くから所属しているメンバーを返す。いなければ空を返す
+func findSuccessor(group *entity.Group, userID entity.UserID) entity.UserID {
+	for _, memberID := range group.GroupMembers {
+		if memberID != userID && !memberID.IsAnonymous() {
+			return memberID
+		}
+	}
+	return ""
+}
+
+func newAnonymousUserID() (entity.UserID, error) {
+	token := make([]byte, anonymousIDSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("匿名の ID の生成に失敗しました: %w", err)
+	}
+	return entity.NewAnonymousUserID(hex.EncodeToString(token)), nil
+}
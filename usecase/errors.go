@@ -0,0 +1,11 @@
+package usecase
+
+import "errors"
+
+// プレゼンテーション層でステータスコードを判定するための共通エラー
+var (
+	ErrEventNotFound  = errors.New("event not found")
+	ErrNotGroupMember = errors.New("not a group member")
+	ErrGroupNotFound  = errors.New("group not found")
+	ErrUserNotFound   = errors.New("user not found")
+)
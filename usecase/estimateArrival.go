@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var ErrUnknownTravelMode = errors.New("unknown travel mode")
+
+type TravelMode string
+
+const (
+	TravelModeWalking TravelMode = "walking"
+	TravelModeTransit TravelMode = "transit"
+	TravelModeCar     TravelMode = "car"
+)
+
+// TravelSpeedProfile は移動手段ごとの平均速度（km/h）
+type TravelSpeedProfile map[TravelMode]float64
+
+// DefaultTravelSpeedProfile は都市部での乗り換えや信号待ちを含めた平均的な移動速度
+func DefaultTravelSpeedProfile() TravelSpeedProfile {
+	return TravelSpeedProfile{
+		TravelModeWalking: 4.8,
+		TravelModeTransit: 20,
+		TravelModeCar:     25,
+	}
+}
+
+const earthRadiusMeters = 6371000.0
+
+// 最新位置がこれより古ければ、今の位置とはみなさず到着予測を出さない
+const etaLocationMaxAge = 10 * time.Minute
+
+// haversineDistance は 2 点間の大円距離をメートルで返す
+func haversineDistance(lat1 entity.Latitude, lng1 entity.Longitude, lat2 entity.Latitude, lng2 entity.Longitude) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	phi1 := toRad(float64(lat1))
+	phi2 := toRad(float64(lat2))
+	dPhi := toRad(float64(lat2 - lat1))
+	dLambda := toRad(float64(lng2 - lng1))
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
+
+type ParticipantETA struct {
+	UserID               entity.UserID   `json:"user_id"`
+	Name                 entity.UserName `json:"name"`
+	IsArrival            bool            `json:"is_arrival"`
+	HasLocation          bool            `json:"has_location"`
+	DistanceMeters       float64         `json:"distance_meters"`
+	ETAMinutes           int             `json:"eta_minutes"`
+	EstimatedArrivalTime *time.Time      `json:"estimated_arrival_time,omitempty"`
+	PredictedLateMinutes int             `json:"predicted_late_minutes"` // 負の値は開始前に到着する見込み
+	LocationRecordedAt   *time.Time      `json:"location_recorded_at,omitempty"`
+}
+
+type EstimateArrivalResponse struct {
+	EventID        entity.EventID   `json:"event_id"`
+	TravelMode     TravelMode       `json:"travel_mode"`
+	EventStartTime time.Time        `json:"event_start_time"`
+	Participants   []ParticipantETA `json:"participants"`
+}
+
+type EstimateArrivalUseCase interface {
+	Execute() (*EstimateArrivalResponse, error)
+}
+
+type EstimateArrivalUseCaseImpl struct {
+	eventRepo    repository.EventRepository
+	groupRepo    repository.GroupRepository
+	userRepo     repository.UserRepository
+	locationRepo repository.LocationRepository
+	speedProfile TravelSpeedProfile
+	eventID      entity.EventID
+	requesterID  entity.UserID
+	travelMode   TravelMode
+}
+
+func NewEstimateArrivalUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, locationRepo repository.LocationRepository, speedProfile TravelSpeedProfile, eventID entity.EventID, requesterID entity.UserID, travelMode TravelMode) *EstimateArrivalUseCaseImpl {
+	return &EstimateArrivalUseCaseImpl{
+		eventRepo:    eventRepo,
+		groupRepo:    groupRepo,
+		userRepo:     userRepo,
+		locationRepo: locationRepo,
+		speedProfile: speedProfile,
+		eventID:      eventID,
+		requesterID:  requesterID,
+		travelMode:   travelMode,
+	}
+}
+
+func (uc *EstimateArrivalUseCaseImpl) Execute() (*EstimateArrivalResponse, error) {
+	speedKmh, ok := uc.speedProfile[uc.travelMode]
+	if !ok || speedKmh <= 0 {
+		return nil, ErrUnknownTravelMode
+	}
+
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	group, err := findEventGroup(uc.groupRepo, event.EventID, uc.requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startTime := time.Time(event.EventStartDateTime)
+	// 位置履歴と同じく、開始前の移動を記録し始める時刻からの位置だけを使う
+	windowFrom := startTime.Add(-locationTrailLeadTime)
+	windowTo := time.Time(event.EventEndDateTime)
+	speedMetersPerMinute := speedKmh * 1000 / 60
+
+	participants := make([]ParticipantETA, 0, len(event.VotedMembers))
+	for _, member := range event.VotedMembers {
+		if member.Vote != entity.VoteAttend {
+			continue
+		}
+
+		eta := ParticipantETA{
+			UserID:    member.UserID,
+			IsArrival: member.IsArrival,
+		}
+
+		user, err := uc.userRepo.FindUserByUserID(member.UserID)
+		if err != nil {
+			user = nil
+		}
+		if user != nil {
+			eta.Name = user.UserName
+		}
+
+		// 到着済みのメンバーは実際の到着時刻で遅刻を確定させる
+		if member.IsArrival {
+			arrivalTime := member.ArrivalDateTime
+			eta.EstimatedArrivalTime = &arrivalTime
+			eta.PredictedLateMinutes = lateMinutes(startTime, arrivalTime)
+			participants = append(participants, eta)
+			continue
+		}
+
+		// 共有を停止しているグループには位置から求めた値を見せない。停止しているか確かめられないときも同じ
+		if user == nil || !user.SharesLocationWith(group.GroupID) {
+			participants = append(participants, eta)
+			continue
+		}
+
+		location, err := uc.locationRepo.FindLocationByUserID(member.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("位置情報の取得に失敗しました: %w", err)
+		}
+		// イベントの前後に送られた位置や古くなった位置からは予測しない
+		if location == nil || location.RecordedAt.Before(windowFrom) || location.RecordedAt.After(windowTo) || now.Sub(location.RecordedAt) > etaLocationMaxAge {
+			participants = append(participants, eta)
+			continue
+		}
+
+		distance := haversineDistance(location.Latitude, location.Longitude, event.Latitude, event.Longitude)
+		travelMinutes := int(math.Ceil(distance / speedMetersPerMinute))
+		arrivalTime := now.Add(time.Duration(travelMinutes) * time.Minute)
+		recordedAt := location.RecordedAt
+
+		eta.HasLocation = true
+		eta.DistanceMeters = math.Round(distance)
+		eta.ETAMinutes = travelMinutes
+		eta.EstimatedArrivalTime = &arrivalTime
+		eta.PredictedLateMinutes = lateMinutes(startTime, arrivalTime)
+		eta.LocationRecordedAt = &recordedAt
+
+		participants = append(participants, eta)
+	}
+
+	return &EstimateArrivalResponse{
+		EventID:        event.EventID,
+		TravelMode:     uc.travelMode,
+		EventStartTime: startTime,
+		Participants:   participants,
+	}, nil
+}
+
+// lateMinutes は開始時刻からの遅れを分単位で返す。遅刻は 1 分未満でも 1 分として数える
+func lateMinutes(startTime, arrivalTime time.Time) int {
+	return int(math.Ceil(arrivalTime.Sub(startTime).Minutes()))
+}
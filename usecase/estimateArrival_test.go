@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 経線に沿った緯度 1 度分の距離
+const metersPerLatitudeDegree = 2 * math.Pi * earthRadiusMeters / 360
+
+func TestHaversineDistance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lat1     entity.Latitude
+		lng1     entity.Longitude
+		lat2     entity.Latitude
+		lng2     entity.Longitude
+		expected float64
+	}{
+		{name: "同じ地点は 0", lat1: 35.681236, lng1: 139.767125, lat2: 35.681236, lng2: 139.767125, expected: 0},
+		{name: "経線に沿った 1 度", lat1: 35, lng1: 139, lat2: 36, lng2: 139, expected: metersPerLatitudeDegree},
+		{name: "赤道に沿った 1 度", lat1: 0, lng1: 139, lat2: 0, lng2: 140, expected: metersPerLatitudeDegree},
+		{name: "日付変更線をまたぐ", lat1: 0, lng1: 179.5, lat2: 0, lng2: -179.5, expected: metersPerLatitudeDegree},
+		{name: "東京駅から新宿駅", lat1: 35.681236, lng1: 139.767125, lat2: 35.690921, lng2: 139.700258, expected: 6140},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			distance := haversineDistance(tc.lat1, tc.lng1, tc.lat2, tc.lng2)
+			reversed := haversineDistance(tc.lat2, tc.lng2, tc.lat1, tc.lng1)
+
+			// 結果の検証
+			assert.InDelta(t, tc.expected, distance, 10)
+			assert.InDelta(t, distance, reversed, 1e-6)
+		})
+	}
+}
+
+func TestLateMinutes(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		arrival  time.Time
+		expected int
+	}{
+		{name: "開始ちょうどは遅刻しない", arrival: start, expected: 0},
+		{name: "1 秒でも遅れたら 1 分", arrival: start.Add(time.Second), expected: 1},
+		{name: "1 分ちょうどは 1 分", arrival: start.Add(time.Minute), expected: 1},
+		{name: "1 分を少しでも過ぎたら 2 分", arrival: start.Add(time.Minute + time.Nanosecond), expected: 2},
+		{name: "30 秒前は 0 分", arrival: start.Add(-30 * time.Second), expected: 0},
+		{name: "1 分前ちょうどは -1 分", arrival: start.Add(-time.Minute), expected: -1},
+		{name: "1 分 30 秒前は -1 分", arrival: start.Add(-90 * time.Second), expected: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, lateMinutes(start, tc.arrival))
+		})
+	}
+}
+
+func TestEstimateArrivalUseCase(t *testing.T) {
+	const (
+		eventLatitude  entity.Latitude  = 35.681236
+		eventLongitude entity.Longitude = 139.767125
+	)
+	// 徒歩 (80 m/分) で 9.875 分、切り上げて 10 分の距離
+	nearLatitude := eventLatitude + entity.Latitude(790/metersPerLatitudeDegree)
+
+	newUseCase := func(start time.Time, users []*entity.User, locations []entity.UserLocation, requesterID entity.UserID, travelMode TravelMode) *EstimateArrivalUseCaseImpl {
+		event := &entity.Event{
+			EventID:            "event-id",
+			Latitude:           eventLatitude,
+			Longitude:          eventLongitude,
+			EventStartDateTime: entity.StartDateTIme(start),
+			EventEndDateTime:   entity.EndDateTime(start.Add(2 * time.Hour)),
+		}
+		group := &entity.Group{GroupID: "group-id", GroupMembers: entity.GroupMembers{"requester-id"}, GroupEvents: entity.GroupEvents{"event-id"}}
+		userRepo := &userRepoStub{users: map[entity.UserID]*entity.User{}}
+		for _, user := range users {
+			event.VotedMembers = append(event.VotedMembers, entity.VotedMember{UserID: user.UserID, Vote: entity.VoteAttend})
+			group.GroupMembers = append(group.GroupMembers, user.UserID)
+			userRepo.users[user.UserID] = user
+		}
+		locationRepo := newLocationRepoStub()
+		for _, location := range locations {
+			locationRepo.locations[location.UserID] = location
+		}
+		return NewEstimateArrivalUseCase(newEventRepoStub(event), &groupRepoStub{groups: []*entity.Group{group}}, userRepo, locationRepo, DefaultTravelSpeedProfile(), "event-id", requesterID, travelMode)
+	}
+
+	t.Run("正常系: 最新位置から到着時刻と遅刻の見込みを出す", func(t *testing.T) {
+		now := time.Now()
+		// 到着は今から 10 分後なので、開始を 5 分 30 秒後にすると 4 分 30 秒遅れ、切り上げて 5 分になる
+		start := now.Add(5*time.Minute + 30*time.Second)
+		uc := newUseCase(start, []*entity.User{{UserID: "member-id", UserName: "メンバー"}}, []entity.UserLocation{{UserID: "member-id", Latitude: nearLatitude, Longitude: eventLongitude, RecordedAt: now.Add(-time.Minute)}}, "requester-id", TravelModeWalking)
+
+		// テスト実行
+		result, err := uc.Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, result.Participants, 1)
+		eta := result.Participants[0]
+		assert.Equal(t, entity.UserName("メンバー"), eta.Name)
+		assert.True(t, eta.HasLocation)
+		assert.Equal(t, float64(790), eta.DistanceMeters)
+		assert.Equal(t, 10, eta.ETAMinutes)
+		assert.Equal(t, 5, eta.PredictedLateMinutes)
+	})
+
+	t.Run("正常系: 到着済みのメンバーは実際の到着時刻で遅刻を確定させる", func(t *testing.T) {
+		now := time.Now()
+		start := now.Add(-10 * time.Minute)
+		uc := newUseCase(start, []*entity.User{{UserID: "member-id"}}, nil, "requester-id", TravelModeWalking)
+		eventRepo := uc.eventRepo.(*eventRepoStub)
+		eventRepo.events["event-id"].VotedMembers[0].IsArrival = true
+		eventRepo.events["event-id"].VotedMembers[0].ArrivalDateTime = start.Add(3*time.Minute + time.Second)
+
+		result, err := uc.Execute()
+
+		assert.NoError(t, err)
+		assert.False(t, result.Participants[0].HasLocation)
+		assert.Equal(t, 4, result.Participants[0].PredictedLateMinutes)
+	})
+
+	t.Run("正常系: 位置から予測できないメンバーは見込みを出さない", func(t *testing.T) {
+		now := time.Now()
+
+		testCases := []struct {
+			name     string
+			start    time.Time
+			user     *entity.User
+			location entity.UserLocation
+		}{
+			{
+				name:     "グループでの共有を停止している",
+				start:    now.Add(time.Hour),
+				user:     &entity.User{UserID: "member-id", LocationOptOutGroups: []entity.GroupID{"group-id"}},
+				location: entity.UserLocation{UserID: "member-id", Latitude: nearLatitude, Longitude: eventLongitude, RecordedAt: now},
+			},
+			{
+				name:     "最新位置が古い",
+				start:    now.Add(time.Hour),
+				user:     &entity.User{UserID: "member-id"},
+				location: entity.UserLocation{UserID: "member-id", Latitude: nearLatitude, Longitude: eventLongitude, RecordedAt: now.Add(-etaLocationMaxAge - time.Second)},
+			},
+			{
+				name:     "最新位置がイベントの記録を始める前のもの",
+				start:    now.Add(locationTrailLeadTime + time.Minute),
+				user:     &entity.User{UserID: "member-id"},
+				location: entity.UserLocation{UserID: "member-id", Latitude: nearLatitude, Longitude: eventLongitude, RecordedAt: now},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				uc := newUseCase(tc.start, []*entity.User{tc.user}, []entity.UserLocation{tc.location}, "requester-id", TravelModeWalking)
+
+				result, err := uc.Execute()
+
+				assert.NoError(t, err)
+				eta := result.Participants[0]
+				assert.False(t, eta.HasLocation)
+				assert.Zero(t, eta.DistanceMeters)
+				assert.Nil(t, eta.EstimatedArrivalTime)
+				assert.Nil(t, eta.LocationRecordedAt)
+			})
+		}
+	})
+
+	t.Run("異常系: グループのメンバーでなければ見られない", func(t *testing.T) {
+		uc := newUseCase(time.Now().Add(time.Hour), nil, nil, "outsider-id", TravelModeWalking)
+
+		_, err := uc.Execute()
+
+		assert.ErrorIs(t, err, ErrNotGroupMember)
+	})
+
+	t.Run("異常系: 知らない移動手段", func(t *testing.T) {
+		uc := newUseCase(time.Now().Add(time.Hour), nil, nil, "requester-id", "bicycle")
+
+		_, err := uc.Execute()
+
+		assert.ErrorIs(t, err, ErrUnknownTravelMode)
+	})
+}
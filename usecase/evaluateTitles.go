@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"time"
+)
+
+type EvaluateTitlesResponse struct {
+	Awarded []entity.Title `json:"awarded"`
+	Revoked []entity.Title `json:"revoked"`
+}
+
+type EvaluateTitlesUseCase interface {
+	Execute() (*EvaluateTitlesResponse, error)
+}
+
+type EvaluateTitlesUseCaseImpl struct {
+	scoreRepo repository.ScoreRepository
+	titleRepo repository.TitleRepository
+	userRepo  repository.UserRepository
+	rules     []TitleRule
+	groupID   entity.GroupID
+	seasonID  entity.SeasonID
+}
+
+// NewEvaluateTitlesUseCase はグループの成績から称号を付け直し、変化のあったユーザーのエイリアスを更新する
+// 新たに獲得した称号には seasonID のシーズンで獲得したことを記録する
+func NewEvaluateTitlesUseCase(scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, userRepo repository.UserRepository, rules []TitleRule, groupID entity.GroupID, seasonID entity.SeasonID) *EvaluateTitlesUseCaseImpl {
+	return &EvaluateTitlesUseCaseImpl{
+		scoreRepo: scoreRepo,
+		titleRepo: titleRepo,
+		userRepo:  userRepo,
+		rules:     rules,
+		groupID:   groupID,
+		seasonID:  seasonID,
+	}
+}
+
+func (uc *EvaluateTitlesUseCaseImpl) Execute() (*EvaluateTitlesResponse, error) {
+	scores, err := uc.scoreRepo.FindScoresByGroupID(uc.groupID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("スコアの取得に失敗しました: %w", err)
+	}
+	stats := collectTitleStats(scores)
+
+	existing, err := uc.titleRepo.FindTitlesByGroupID(uc.groupID)
+	if err != nil {
+		return nil, fmt.Errorf("称号の取得に失敗しました: %w", err)
+	}
+	current := make(map[entity.TitleID]entity.Title, len(existing))
+	for _, title := range existing {
+		current[title.TitleID] = title
+	}
+
+	now := time.Now()
+	response := &EvaluateTitlesResponse{Awarded: []entity.Title{}, Revoked: []entity.Title{}}
+	changedUsers := make(map[entity.UserID]bool)
+
+	for _, rule := range uc.rules {
+		qualified := rule.qualifiedUsers(stats)
+
+		for userID := range qualified {
+			titleID := entity.NewTitleID(uc.groupID, userID, rule.Name)
+			if title, ok := current[titleID]; ok && title.IsActive() {
+				continue
+			}
+
+			title := entity.Title{
+				TitleID:     titleID,
+				UserID:      userID,
+				GroupID:     uc.groupID,
+				SeasonID:    uc.seasonID,
+				Name:        rule.Name,
+				Description: rule.Description,
+				AwardedAt:   now,
+			}
+			if err := uc.titleRepo.SaveTitle(title); err != nil {
+				return nil, fmt.Errorf("称号の保存に失敗しました: %w", err)
+			}
+			response.Awarded = append(response.Awarded, title)
+			changedUsers[userID] = true
+		}
+
+		for _, title := range existing {
+			if title.Name != rule.Name || !title.IsActive() || qualified[title.UserID] {
+				continue
+			}
+
+			title.RevokedAt = &now
+			if err := uc.titleRepo.SaveTitle(title); err != nil {
+				return nil, fmt.Errorf("称号の保存に失敗しました: %w", err)
+			}
+			response.Revoked = append(response.Revoked, title)
+			changedUsers[title.UserID] = true
+		}
+	}
+
+	for userID := range changedUsers {
+		if err := uc.refreshAlias(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// refreshAlias は保持している称号からユーザーの希望に沿ってエイリアスを選び直す
+func (uc *EvaluateTitlesUseCaseImpl) refreshAlias(userID entity.UserID) error {
+	titles, err := uc.titleRepo.FindTitlesByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("称号の取得に失敗しました: %w", err)
+	}
+	user, err := uc.userRepo.FindUserByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("ユーザーの取得に失敗しました: %w", err)
+	}
+	var preference entity.Alias
+	if user != nil {
+		preference = user.AliasPreference
+	}
+
+	alias := selectAlias(uc.rules, titles, preference)
+	if err := uc.userRepo.UpdateAlias(userID, alias); err != nil {
+		return fmt.Errorf("エイリアスの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// selectAlias は希望の称号を保持していればそれを、なければ規則の並びで最も優先される保持中の称号を返す
+func selectAlias(rules []TitleRule, titles []entity.Title, preference entity.Alias) entity.Alias {
+	if preference != "" && holdsTitle(titles, preference) {
+		return preference
+	}
+	for _, rule := range rules {
+		if holdsTitle(titles, rule.Name) {
+			return rule.Name
+		}
+	}
+	return ""
+}
+
+func holdsTitle(titles []entity.Title, name entity.Alias) bool {
+	for _, title := range titles {
+		if title.Name == name && title.IsActive() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"time"
+)
+
+// GroupArchiveVersion は GroupArchive の形式の版。形式を変えたときに増やす
+const GroupArchiveVersion = 1
+
+// GroupArchive はグループとそのイベントを別の環境に移すための JSON の形式
+// チェックイン用の秘密の値、支払いの記録、スコア、繰り返し、シーズンは含まない
+type GroupArchive struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Group      entity.Group   `json:"group"`
+	Events     []entity.Event `json:"events"`
+}
+
+type ExportGroupUseCase interface {
+	Execute() (*GroupArchive, error)
+}
+
+type ExportGroupUseCaseImpl struct {
+	groupRepo repository.GroupRepository
+	eventRepo repository.EventRepository
+	groupID   entity.GroupID
+}
+
+func NewExportGroupUseCase(groupRepo repository.GroupRepository, eventRepo repository.EventRepository, groupID entity.GroupID) *ExportGroupUseCaseImpl {
+	return &ExportGroupUseCaseImpl{
+		groupRepo: groupRepo,
+		eventRepo: eventRepo,
+		groupID:   groupID,
+	}
+}
+
+func (uc *ExportGroupUseCaseImpl) Execute() (*GroupArchive, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	events, err := uc.eventRepo.FindEventsByEventIDs(group.GroupEvents)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの取得に失敗しました: %w", err)
+	}
+
+	return &GroupArchive{
+		Version:    GroupArchiveVersion,
+		ExportedAt: time.Now(),
+		Group:      *group,
+		Events:     events,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCalendarToken = errors.New("invalid calendar token")
+
+type CalendarFeed struct {
+	User   *entity.User
+	Events []entity.Event
+}
+
+type FetchCalendarFeedUseCase interface {
+	Execute() (*CalendarFeed, error)
+}
+
+type FetchCalendarFeedUseCaseImpl struct {
+	userRepo  repository.UserRepository
+	groupRepo repository.GroupRepository
+	eventRepo repository.EventRepository
+	userID    entity.UserID
+	token     entity.CalendarToken
+}
+
+// NewFetchCalendarFeedUseCase はカレンダー購読用に、ユーザーが所属するすべてのグループのイベントを返す
+// カレンダーアプリは認証ヘッダーを送れないため、ユーザーごとのトークンで本人であることを確認する
+func NewFetchCalendarFeedUseCase(userRepo repository.UserRepository, groupRepo repository.GroupRepository, eventRepo repository.EventRepository, userID entity.UserID, token entity.CalendarToken) *FetchCalendarFeedUseCaseImpl {
+	return &FetchCalendarFeedUseCaseImpl{
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+		eventRepo: eventRepo,
+		userID:    userID,
+		token:     token,
+	}
+}
+
+func (uc *FetchCalendarFeedUseCaseImpl) Execute() (*CalendarFeed, error) {
+	user, err := uc.userRepo.FindUserByCalendarToken(uc.token)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの取得に失敗しました: %w", err)
+	}
+	if user == nil || user.UserID != uc.userID {
+		return nil, ErrInvalidCalendarToken
+	}
+
+	groups, err := uc.groupRepo.FindGroupsByUserID(user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの所属グループ取得中にエラーが発生しました: %w", err)
+	}
+
+	groupIDs := make([]entity.GroupID, 0, len(groups))
+	for _, group := range groups {
+		groupIDs = append(groupIDs, group.GroupID)
+	}
+
+	events, err := NewFetchEventInfoUsecase(uc.groupRepo, uc.eventRepo, groupIDs).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CalendarFeed{User: user, Events: events}, nil
+}
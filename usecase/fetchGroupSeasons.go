@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type FetchGroupSeasonsUseCase interface {
+	Execute() ([]entity.Season, error)
+}
+
+type FetchGroupSeasonsUseCaseImpl struct {
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	userID     entity.UserID
+	groupID    entity.GroupID
+}
+
+func NewFetchGroupSeasonsUseCase(groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, userID entity.UserID, groupID entity.GroupID) *FetchGroupSeasonsUseCaseImpl {
+	return &FetchGroupSeasonsUseCaseImpl{
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		userID:     userID,
+		groupID:    groupID,
+	}
+}
+
+func (uc *FetchGroupSeasonsUseCaseImpl) Execute() ([]entity.Season, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.userID) {
+		return nil, ErrNotGroupMember
+	}
+
+	seasons, err := uc.seasonRepo.FindSeasonsByGroupID(group.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("シーズンの取得に失敗しました: %w", err)
+	}
+
+	return seasons, nil
+}
+
+// findGroupSeason はグループに属するシーズンを取得する
+func findGroupSeason(seasonRepo repository.SeasonRepository, groupID entity.GroupID, seasonID entity.SeasonID) (*entity.Season, error) {
+	season, err := seasonRepo.FindSeasonBySeasonID(seasonID)
+	if err != nil || season == nil || season.GroupID != groupID {
+		return nil, ErrSeasonNotFound
+	}
+	return season, nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+)
+
+type LocationTrailResponse struct {
+	EventID entity.EventID              `json:"event_id"`
+	UserID  entity.UserID               `json:"user_id"`
+	Trail   []entity.LocationTrailPoint `json:"trail"`
+}
+
+type FetchLocationTrailUseCase interface {
+	Execute() (*LocationTrailResponse, error)
+}
+
+type FetchLocationTrailUseCaseImpl struct {
+	eventRepo   repository.EventRepository
+	groupRepo   repository.GroupRepository
+	historyRepo repository.LocationHistoryRepository
+	requesterID entity.UserID
+	eventID     entity.EventID
+	userID      entity.UserID
+}
+
+func NewFetchLocationTrailUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, historyRepo repository.LocationHistoryRepository, requesterID entity.UserID, eventID entity.EventID, userID entity.UserID) *FetchLocationTrailUseCaseImpl {
+	return &FetchLocationTrailUseCaseImpl{
+		eventRepo:   eventRepo,
+		groupRepo:   groupRepo,
+		historyRepo: historyRepo,
+		requesterID: requesterID,
+		eventID:     eventID,
+		userID:      userID,
+	}
+}
+
+// Execute はランキングの確認用に、同じグループのメンバーへ参加者の移動経路を返す
+func (uc *FetchLocationTrailUseCaseImpl) Execute() (*LocationTrailResponse, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if _, err := findEventGroup(uc.groupRepo, event.EventID, uc.requesterID); err != nil {
+		return nil, err
+	}
+
+	trail, err := uc.historyRepo.FindTrail(event.EventID, uc.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocationTrailResponse{
+		EventID: event.EventID,
+		UserID:  uc.userID,
+		Trail:   trail,
+	}, nil
+}
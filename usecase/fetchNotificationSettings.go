@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type FetchNotificationSettingsUseCase interface {
+	Execute() (*entity.NotificationSettings, error)
+}
+
+type FetchNotificationSettingsUseCaseImpl struct {
+	settingsRepo repository.NotificationSettingsRepository
+	userID       entity.UserID
+}
+
+// NewFetchNotificationSettingsUseCase は通知の設定を、設定していない種類も埋めて返す
+func NewFetchNotificationSettingsUseCase(settingsRepo repository.NotificationSettingsRepository, userID entity.UserID) *FetchNotificationSettingsUseCaseImpl {
+	return &FetchNotificationSettingsUseCaseImpl{
+		settingsRepo: settingsRepo,
+		userID:       userID,
+	}
+}
+
+func (uc *FetchNotificationSettingsUseCaseImpl) Execute() (*entity.NotificationSettings, error) {
+	settings, err := uc.settingsRepo.FindSettingsByUserID(uc.userID)
+	if err != nil {
+		return nil, fmt.Errorf("通知の設定の取得に失敗しました: %w", err)
+	}
+	if settings == nil {
+		return entity.DefaultNotificationSettings(uc.userID), nil
+	}
+
+	settings.FillDefaults()
+	return settings, nil
+}
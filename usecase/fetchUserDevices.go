@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type FetchUserDevicesResponse struct {
+	Devices []entity.Device `json:"devices"`
+}
+
+type FetchUserDevicesUseCase interface {
+	Execute() (*FetchUserDevicesResponse, error)
+}
+
+type FetchUserDevicesUseCaseImpl struct {
+	deviceRepo repository.DeviceRepository
+	userID     entity.UserID
+}
+
+// NewFetchUserDevicesUseCase はユーザーが登録している端末を最近使った順に返す
+func NewFetchUserDevicesUseCase(deviceRepo repository.DeviceRepository, userID entity.UserID) *FetchUserDevicesUseCaseImpl {
+	return &FetchUserDevicesUseCaseImpl{
+		deviceRepo: deviceRepo,
+		userID:     userID,
+	}
+}
+
+func (uc *FetchUserDevicesUseCaseImpl) Execute() (*FetchUserDevicesResponse, error) {
+	devices, err := uc.deviceRepo.FindDevicesByUserID(uc.userID)
+	if err != nil {
+		return nil, fmt.Errorf("端末の取得に失敗しました: %w", err)
+	}
+	return &FetchUserDevicesResponse{Devices: devices}, nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+var (
+	ErrIconNotFound    = errors.New("icon not found")
+	ErrInvalidIconSize = errors.New("invalid icon size")
+)
+
+type FetchUserIconUseCase interface {
+	Execute() (io.ReadCloser, error)
+}
+
+type FetchUserIconUseCaseImpl struct {
+	blobStore service.BlobStore
+	userID    entity.UserID
+	size      int
+}
+
+// NewFetchUserIconUseCase はアップロードされたアイコンを size の大きさで開く
+func NewFetchUserIconUseCase(blobStore service.BlobStore, userID entity.UserID, size int) *FetchUserIconUseCaseImpl {
+	return &FetchUserIconUseCaseImpl{
+		blobStore: blobStore,
+		userID:    userID,
+		size:      size,
+	}
+}
+
+func (uc *FetchUserIconUseCaseImpl) Execute() (io.ReadCloser, error) {
+	if !slices.Contains(IconSizes, uc.size) {
+		return nil, ErrInvalidIconSize
+	}
+
+	icon, err := uc.blobStore.Open(userIconKey(uc.userID, uc.size))
+	if errors.Is(err, service.ErrBlobNotFound) {
+		return nil, ErrIconNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("アイコンの読み込みに失敗しました: %w", err)
+	}
+	return icon, nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type UserStats struct {
+	EventsVoted         int     `json:"events_voted"`
+	EventsAttended      int     `json:"events_attended"`
+	NoShows             int     `json:"no_shows"`
+	AverageLateMinutes  float64 `json:"average_late_minutes"`
+	MedianLateMinutes   float64 `json:"median_late_minutes"`
+	OnTimeRate          float64 `json:"on_time_rate"`
+	LongestOnTimeStreak int     `json:"longest_on_time_streak"`
+	WorstLateMinutes    int     `json:"worst_late_minutes"`
+}
+
+type GroupUserStats struct {
+	GroupID   entity.GroupID   `json:"group_id"`
+	GroupName entity.GroupName `json:"group_name"`
+	UserStats
+}
+
+type FetchUserStatsResponse struct {
+	UserID entity.UserID `json:"user_id"`
+	UserStats
+	Groups []GroupUserStats `json:"groups"`
+}
+
+type FetchUserStatsUseCase interface {
+	Execute() (*FetchUserStatsResponse, error)
+}
+
+type FetchUserStatsUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	userID    entity.UserID
+}
+
+// NewFetchUserStatsUseCase はユーザーの遅刻に関する統計を返す
+// 集計はデータベース側で行い、中央値と連続記録だけをここで計算する
+func NewFetchUserStatsUseCase(eventRepo repository.EventRepository, userID entity.UserID) *FetchUserStatsUseCaseImpl {
+	return &FetchUserStatsUseCaseImpl{
+		eventRepo: eventRepo,
+		userID:    userID,
+	}
+}
+
+func (uc *FetchUserStatsUseCaseImpl) Execute() (*FetchUserStatsResponse, error) {
+	overall, groups, err := uc.eventRepo.AggregateUserStats(uc.userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("統計の集計に失敗しました: %w", err)
+	}
+
+	response := &FetchUserStatsResponse{
+		UserID:    uc.userID,
+		UserStats: summarizeUserStats(*overall),
+		Groups:    make([]GroupUserStats, 0, len(groups)),
+	}
+	for _, group := range groups {
+		response.Groups = append(response.Groups, GroupUserStats{
+			GroupID:   group.GroupID,
+			GroupName: group.GroupName,
+			UserStats: summarizeUserStats(group),
+		})
+	}
+
+	return response, nil
+}
+
+func summarizeUserStats(stats entity.UserEventStats) UserStats {
+	summary := UserStats{
+		EventsVoted:      stats.VotedCount,
+		EventsAttended:   stats.ArrivedCount,
+		NoShows:          stats.NoShowCount,
+		WorstLateMinutes: stats.WorstLateMinutes,
+	}
+
+	if len(stats.LateMinutes) > 0 {
+		summary.AverageLateMinutes = roundMinutes(float64(stats.TotalLateMinutes) / float64(len(stats.LateMinutes)))
+		summary.MedianLateMinutes = median(stats.LateMinutes)
+	}
+
+	// 到着したイベントと欠席したイベントのうち、時間通りだった割合
+	if judged := len(stats.OnTimeSequence); judged > 0 {
+		summary.OnTimeRate = roundMinutes(float64(stats.OnTimeCount) / float64(judged))
+	}
+
+	streak := 0
+	for _, onTime := range stats.OnTimeSequence {
+		if !onTime {
+			streak = 0
+			continue
+		}
+		streak++
+		if streak > summary.LongestOnTimeStreak {
+			summary.LongestOnTimeStreak = streak
+		}
+	}
+
+	return summary
+}
+
+func median(values []int) float64 {
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[middle])
+	}
+	return float64(sorted[middle-1]+sorted[middle]) / 2
+}
+
+// roundMinutes は小数第 2 位までに丸める
+func roundMinutes(value float64) float64 {
+	return math.Round(value*100) / 100
+}
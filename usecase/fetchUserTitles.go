@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type FetchUserTitlesResponse struct {
+	UserID entity.UserID  `json:"user_id"`
+	Titles []entity.Title `json:"titles"`
+}
+
+type FetchUserTitlesUseCase interface {
+	Execute() (*FetchUserTitlesResponse, error)
+}
+
+type FetchUserTitlesUseCaseImpl struct {
+	titleRepo repository.TitleRepository
+	userID    entity.UserID
+	seasonID  entity.SeasonID
+}
+
+// NewFetchUserTitlesUseCase はユーザーがこれまでに獲得した称号を、剥奪されたものも含めて返す
+// seasonID を指定した場合はそのシーズンに獲得した称号だけを返す
+func NewFetchUserTitlesUseCase(titleRepo repository.TitleRepository, userID entity.UserID, seasonID entity.SeasonID) *FetchUserTitlesUseCaseImpl {
+	return &FetchUserTitlesUseCaseImpl{
+		titleRepo: titleRepo,
+		userID:    userID,
+		seasonID:  seasonID,
+	}
+}
+
+func (uc *FetchUserTitlesUseCaseImpl) Execute() (*FetchUserTitlesResponse, error) {
+	titles, err := uc.titleRepo.FindTitlesByUserID(uc.userID)
+	if err != nil {
+		return nil, fmt.Errorf("称号の取得に失敗しました: %w", err)
+	}
+
+	if uc.seasonID != "" {
+		filtered := make([]entity.Title, 0, len(titles))
+		for _, title := range titles {
+			if title.SeasonID == uc.seasonID {
+				filtered = append(filtered, title)
+			}
+		}
+		titles = filtered
+	}
+
+	return &FetchUserTitlesResponse{
+		UserID: uc.userID,
+		Titles: titles,
+	}, nil
+}
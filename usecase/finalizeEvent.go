@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEventNotEnded         = errors.New("event has not ended yet")
+	ErrEventAlreadyFinalized = errors.New("event already finalized")
+	ErrPendingArrivalReview  = errors.New("arrival reviews are still pending")
+)
+
+type FinalizeEventResponse struct {
+	EventID     entity.EventID `json:"event_id"`
+	FinalizedAt time.Time      `json:"finalized_at"`
+	Scores      []entity.Score `json:"scores"`
+	// このイベントの結果で新たに獲得・剥奪された称号
+	Titles *EvaluateTitlesResponse `json:"titles"`
+}
+
+type FinalizeEventUseCase interface {
+	Execute() (*FinalizeEventResponse, error)
+}
+
+type FinalizeEventUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+	scoreRepo repository.ScoreRepository
+	titleRepo repository.TitleRepository
+	cache     *LeaderboardCache
+	notifier  service.Notifier
+	userID    entity.UserID
+	eventID   entity.EventID
+}
+
+// NewFinalizeEventUseCase は終了したイベントの結果を確定し、参加者のポイントを記録して称号を付け直す
+// 確定したら参加者に順位が出たことを通知する
+func NewFinalizeEventUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, cache *LeaderboardCache, notifier service.Notifier, userID entity.UserID, eventID entity.EventID) *FinalizeEventUseCaseImpl {
+	return &FinalizeEventUseCaseImpl{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		scoreRepo: scoreRepo,
+		titleRepo: titleRepo,
+		cache:     cache,
+		notifier:  notifier,
+		userID:    userID,
+		eventID:   eventID,
+	}
+}
+
+func (uc *FinalizeEventUseCaseImpl) Execute() (*FinalizeEventResponse, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if event.EventAuthorID != uc.userID {
+		return nil, ErrNotEventAuthor
+	}
+	if event.FinalizedAt != nil {
+		return nil, ErrEventAlreadyFinalized
+	}
+
+	now := time.Now()
+	if now.Before(time.Time(event.EventEndDateTime)) {
+		return nil, ErrEventNotEnded
+	}
+
+	// 確認待ちの到着が残っていると欠席扱いになってしまうため、先に確認してもらう
+	for _, member := range event.VotedMembers {
+		if member.IsArrival && member.ArrivalFlag != nil && member.ArrivalFlag.Status == entity.ArrivalReviewPending {
+			return nil, ErrPendingArrivalReview
+		}
+	}
+
+	group, err := uc.groupRepo.FindGroupByEventID(event.EventID)
+	if err != nil {
+		return nil, ErrGroupNotFound
+	}
+
+	ranking, err := NewGetArrivalRankingUseCase(uc.eventRepo, &event.EventID, uc.userRepo).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("到着ランキングの取得に失敗しました: %w", err)
+	}
+
+	scores := scoreEvent(event, group.GroupID, group.Scoring(), ranking.Ranking, now)
+	if err := uc.scoreRepo.SaveScores(scores); err != nil {
+		return nil, fmt.Errorf("スコアの保存に失敗しました: %w", err)
+	}
+
+	// 確定前に失敗した場合は、スコアと称号を再計算できるようにやり直せばよい
+	titles, err := NewEvaluateTitlesUseCase(uc.scoreRepo, uc.titleRepo, uc.userRepo, DefaultTitleRules(), group.GroupID, event.SeasonID).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.eventRepo.UpdateEventFields(event.EventID, entity.EventUpdate{FinalizedAt: &now}); err != nil {
+		return nil, fmt.Errorf("イベントの更新に失敗しました: %w", err)
+	}
+	uc.cache.InvalidateGroup(group.GroupID)
+
+	notifyUsers(uc.notifier, attendingRecipients(event, ""), entity.Notification{
+		Kind:    entity.NotificationRankingPosted,
+		GroupID: group.GroupID,
+		Title:   "順位が確定しました",
+		Body:    fmt.Sprintf("「%s」の結果を確認しましょう", event.EventTitle),
+		Data:    map[string]string{"event_id": string(event.EventID)},
+	})
+
+	return &FinalizeEventResponse{
+		EventID:     event.EventID,
+		FinalizedAt: now,
+		Scores:      scores,
+		Titles:      titles,
+	}, nil
+}
@@ -57,8 +57,8 @@ func (uc *GetArrivalRankingUseCaseImpl) Execute() (*GetArrivalRankingResponse, e
 
 	var userIDs []entity.UserID
 	for _, member := range event.VotedMembers {
-		if !member.IsArrival {
-			continue // 到着していないメンバーはスキップ
+		if !member.HasConfirmedArrival() {
+			continue // 到着していない・到着が確認待ちのメンバーはスキップ
 		}
 		userIDs = append(userIDs, member.UserID)
 	}
@@ -79,12 +79,16 @@ func (uc *GetArrivalRankingUseCaseImpl) Execute() (*GetArrivalRankingResponse, e
 	// ランキングの作成
 	var ranking []ArrivalRank
 	for _, member := range event.VotedMembers {
-		if !member.IsArrival {
+		if !member.HasConfirmedArrival() {
 			continue // 参加していないメンバーはスキップ
 		}
 
 		// ユーザー情報を取得
 		user, exists := userMap[member.UserID]
+		if !exists && member.UserID.IsAnonymous() {
+			// 退会したユーザーも順位が変わらないよう残す
+			user, exists = entity.DeletedUser(member.UserID), true
+		}
 		if !exists {
 			continue // ユーザー情報がない場合はスキップ
 		}
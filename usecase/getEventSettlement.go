@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"time"
+)
+
+type GetEventSettlementUseCase interface {
+	Execute() (*EventSettlement, error)
+}
+
+type GetEventSettlementUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+	userID    entity.UserID
+	eventID   entity.EventID
+}
+
+// NewGetEventSettlementUseCase はイベントの費用の分担と支払い状況を返す。参照できるのはグループのメンバーのみ
+func NewGetEventSettlementUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, userID entity.UserID, eventID entity.EventID) *GetEventSettlementUseCaseImpl {
+	return &GetEventSettlementUseCaseImpl{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		userID:    userID,
+		eventID:   eventID,
+	}
+}
+
+func (uc *GetEventSettlementUseCaseImpl) Execute() (*EventSettlement, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if _, err := findEventGroup(uc.groupRepo, event.EventID, uc.userID); err != nil {
+		return nil, err
+	}
+
+	settlement := settleEvent(event, time.Now())
+	for i := range settlement.Shares {
+		user, err := uc.userRepo.FindUserByUserID(settlement.Shares[i].UserID)
+		if err != nil || user == nil {
+			continue
+		}
+		settlement.Shares[i].Name = user.UserName
+		settlement.Shares[i].Alias = user.Alias
+	}
+
+	return settlement, nil
+}
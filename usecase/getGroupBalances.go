@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type MemberBalance struct {
+	UserID entity.UserID   `json:"user_id"`
+	Name   entity.UserName `json:"name"`
+	Alias  entity.Alias    `json:"alias"`
+	// 正の場合は受け取る額、負の場合は支払う額
+	Net entity.Cost `json:"net"`
+}
+
+// Debt は From が To に支払っていない額。同じ 2 人の間の貸し借りは相殺してある
+type Debt struct {
+	From   entity.UserID `json:"from"`
+	To     entity.UserID `json:"to"`
+	Amount entity.Cost   `json:"amount"`
+}
+
+type GetGroupBalancesResponse struct {
+	GroupID  entity.GroupID  `json:"group_id"`
+	Balances []MemberBalance `json:"balances"`
+	Debts    []Debt          `json:"debts"`
+}
+
+type GetGroupBalancesUseCase interface {
+	Execute() (*GetGroupBalancesResponse, error)
+}
+
+type GetGroupBalancesUseCaseImpl struct {
+	groupRepo repository.GroupRepository
+	eventRepo repository.EventRepository
+	userRepo  repository.UserRepository
+	userID    entity.UserID
+	groupID   entity.GroupID
+}
+
+// NewGetGroupBalancesUseCase はグループの終了したイベントの未払い分を集計し、誰が誰にいくら支払うかを返す
+func NewGetGroupBalancesUseCase(groupRepo repository.GroupRepository, eventRepo repository.EventRepository, userRepo repository.UserRepository, userID entity.UserID, groupID entity.GroupID) *GetGroupBalancesUseCaseImpl {
+	return &GetGroupBalancesUseCaseImpl{
+		groupRepo: groupRepo,
+		eventRepo: eventRepo,
+		userRepo:  userRepo,
+		userID:    userID,
+		groupID:   groupID,
+	}
+}
+
+type debtPair struct {
+	from entity.UserID
+	to   entity.UserID
+}
+
+func (uc *GetGroupBalancesUseCaseImpl) Execute() (*GetGroupBalancesResponse, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.userID) {
+		return nil, ErrNotGroupMember
+	}
+
+	events, err := uc.eventRepo.FindEventsByEventIDs(group.GroupEvents)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの取得に失敗しました: %w", err)
+	}
+
+	now := time.Now()
+	owed := make(map[debtPair]entity.Cost)
+	for i := range events {
+		settlement := settleEvent(&events[i], now)
+		// 終了前のイベントは金額が決まっていないため含めない
+		if settlement.Provisional {
+			continue
+		}
+		for _, share := range settlement.Shares {
+			if share.Paid || share.Amount <= 0 {
+				continue
+			}
+			owed[debtPair{from: share.UserID, to: settlement.PayeeID}] += share.Amount
+		}
+	}
+
+	net := make(map[entity.UserID]entity.Cost)
+	debts := []Debt{}
+	for pair, amount := range owed {
+		reverse := owed[debtPair{from: pair.to, to: pair.from}]
+		if amount <= reverse {
+			continue
+		}
+		debts = append(debts, Debt{From: pair.from, To: pair.to, Amount: amount - reverse})
+		net[pair.from] -= amount - reverse
+		net[pair.to] += amount - reverse
+	}
+	sort.Slice(debts, func(i, j int) bool {
+		if debts[i].Amount != debts[j].Amount {
+			return debts[i].Amount > debts[j].Amount
+		}
+		if debts[i].From != debts[j].From {
+			return debts[i].From < debts[j].From
+		}
+		return debts[i].To < debts[j].To
+	})
+
+	balances := make([]MemberBalance, 0, len(net))
+	for userID, amount := range net {
+		balance := MemberBalance{UserID: userID, Net: amount}
+		if user, err := uc.userRepo.FindUserByUserID(userID); err == nil && user != nil {
+			balance.Name = user.UserName
+			balance.Alias = user.Alias
+		}
+		balances = append(balances, balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Net != balances[j].Net {
+			return balances[i].Net > balances[j].Net
+		}
+		return balances[i].UserID < balances[j].UserID
+	})
+
+	return &GetGroupBalancesResponse{
+		GroupID:  group.GroupID,
+		Balances: balances,
+		Debts:    debts,
+	}, nil
+}
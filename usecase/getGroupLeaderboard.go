@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var ErrUnknownLeaderboardMetric = errors.New("unknown leaderboard metric")
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardMetricTotalLateMinutes   LeaderboardMetric = "total_late_minutes"
+	LeaderboardMetricAverageLateMinutes LeaderboardMetric = "average_late_minutes"
+	LeaderboardMetricOnTimeRate         LeaderboardMetric = "on_time_rate"
+	LeaderboardMetricAttendance         LeaderboardMetric = "attendance"
+)
+
+// 期間を指定しない場合は今日までの 30 日間を対象にする
+const defaultLeaderboardPeriod = 30 * 24 * time.Hour
+
+// value はメンバーの成績から指標の値を求める。値を持たない場合は false を返す
+func (m LeaderboardMetric) value(stats entity.UserEventStats) (float64, bool) {
+	switch m {
+	case LeaderboardMetricTotalLateMinutes:
+		return float64(stats.TotalLateMinutes), true
+	case LeaderboardMetricAverageLateMinutes:
+		if len(stats.LateMinutes) == 0 {
+			return 0, false
+		}
+		return roundMinutes(float64(stats.TotalLateMinutes) / float64(len(stats.LateMinutes))), true
+	case LeaderboardMetricOnTimeRate:
+		if len(stats.OnTimeSequence) == 0 {
+			return 0, false
+		}
+		return roundMinutes(float64(stats.OnTimeCount) / float64(len(stats.OnTimeSequence))), true
+	case LeaderboardMetricAttendance:
+		return float64(stats.ArrivedCount), true
+	}
+	return 0, false
+}
+
+// higherIsBetter は値が大きいほど上位になる指標かどうかを返す
+func (m LeaderboardMetric) higherIsBetter() bool {
+	return m == LeaderboardMetricOnTimeRate || m == LeaderboardMetricAttendance
+}
+
+func (m LeaderboardMetric) valid() bool {
+	switch m {
+	case LeaderboardMetricTotalLateMinutes, LeaderboardMetricAverageLateMinutes, LeaderboardMetricOnTimeRate, LeaderboardMetricAttendance:
+		return true
+	}
+	return false
+}
+
+type LeaderboardEntry struct {
+	Rank   int     `json:"rank"`
+	Member Member  `json:"member"`
+	Value  float64 `json:"value"`
+	// 前の期間に記録がない場合は nil
+	PreviousRank  *int     `json:"previous_rank,omitempty"`
+	PreviousValue *float64 `json:"previous_value,omitempty"`
+	// 正の値は順位が上がったことを表す
+	RankDelta  *int     `json:"rank_delta,omitempty"`
+	ValueDelta *float64 `json:"value_delta,omitempty"`
+}
+
+type GetGroupLeaderboardResponse struct {
+	GroupID      entity.GroupID     `json:"group_id"`
+	Metric       LeaderboardMetric  `json:"metric"`
+	From         time.Time          `json:"from"`
+	To           time.Time          `json:"to"`
+	PreviousFrom time.Time          `json:"previous_from"`
+	PreviousTo   time.Time          `json:"previous_to"`
+	Entries      []LeaderboardEntry `json:"entries"`
+}
+
+type GetGroupLeaderboardUseCase interface {
+	Execute() (*GetGroupLeaderboardResponse, error)
+}
+
+type GetGroupLeaderboardUseCaseImpl struct {
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	seasonRepo repository.SeasonRepository
+	cache      *LeaderboardCache
+	userID     entity.UserID
+	groupID    entity.GroupID
+	metric     LeaderboardMetric
+	seasonID   entity.SeasonID
+	from       time.Time
+	to         time.Time
+}
+
+// NewGetGroupLeaderboardUseCase は期間内の指標でグループのメンバーを順位付けし、直前の同じ長さの期間と比較する
+// シーズンを指定した場合はシーズンの期間を対象にする
+func NewGetGroupLeaderboardUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, seasonRepo repository.SeasonRepository, cache *LeaderboardCache, userID entity.UserID, groupID entity.GroupID, metric LeaderboardMetric, seasonID entity.SeasonID, from time.Time, to time.Time) *GetGroupLeaderboardUseCaseImpl {
+	return &GetGroupLeaderboardUseCaseImpl{
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		seasonRepo: seasonRepo,
+		cache:      cache,
+		userID:     userID,
+		groupID:    groupID,
+		metric:     metric,
+		seasonID:   seasonID,
+		from:       from,
+		to:         to,
+	}
+}
+
+func (uc *GetGroupLeaderboardUseCaseImpl) Execute() (*GetGroupLeaderboardResponse, error) {
+	if !uc.metric.valid() {
+		return nil, ErrUnknownLeaderboardMetric
+	}
+
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.userID) {
+		return nil, ErrNotGroupMember
+	}
+
+	now := time.Now()
+	from, to := leaderboardPeriod(uc.from, uc.to, now)
+	if uc.seasonID != "" {
+		season, err := findGroupSeason(uc.seasonRepo, group.GroupID, uc.seasonID)
+		if err != nil {
+			return nil, err
+		}
+		from, to = season.StartDateTime, season.EndDateTime
+	}
+
+	key := newLeaderboardCacheKey(group.GroupID, uc.metric, from, to)
+	if cached, ok := uc.cache.get(key, now); ok {
+		return cached, nil
+	}
+
+	previousFrom := from.Add(-to.Sub(from))
+
+	current, err := uc.groupRepo.AggregateMemberStats(group.GroupID, from, to, now)
+	if err != nil {
+		return nil, fmt.Errorf("成績の集計に失敗しました: %w", err)
+	}
+	previous, err := uc.groupRepo.AggregateMemberStats(group.GroupID, previousFrom, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("成績の集計に失敗しました: %w", err)
+	}
+
+	currentRanks := uc.rank(current)
+	previousRanks := uc.rank(previous)
+	previousByUser := make(map[entity.UserID]rankedValue, len(previousRanks))
+	for _, ranked := range previousRanks {
+		previousByUser[ranked.userID] = ranked
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(currentRanks))
+	for _, ranked := range currentRanks {
+		entry := LeaderboardEntry{
+			Rank:   ranked.rank,
+			Member: uc.member(ranked.userID),
+			Value:  ranked.value,
+		}
+		if prev, ok := previousByUser[ranked.userID]; ok {
+			previousRank, previousValue := prev.rank, prev.value
+			rankDelta := prev.rank - ranked.rank
+			valueDelta := roundMinutes(ranked.value - prev.value)
+			entry.PreviousRank = &previousRank
+			entry.PreviousValue = &previousValue
+			entry.RankDelta = &rankDelta
+			entry.ValueDelta = &valueDelta
+		}
+		entries = append(entries, entry)
+	}
+
+	response := &GetGroupLeaderboardResponse{
+		GroupID:      group.GroupID,
+		Metric:       uc.metric,
+		From:         from,
+		To:           to,
+		PreviousFrom: previousFrom,
+		PreviousTo:   from,
+		Entries:      entries,
+	}
+	uc.cache.set(key, response, now)
+
+	return response, nil
+}
+
+// leaderboardPeriod は指定された期間を補完する。キャッシュが効くよう、省略された終了は日単位で区切る
+func leaderboardPeriod(from time.Time, to time.Time, now time.Time) (time.Time, time.Time) {
+	if to.IsZero() {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		to = today.AddDate(0, 0, 1)
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultLeaderboardPeriod)
+	}
+	return from, to
+}
+
+type rankedValue struct {
+	userID entity.UserID
+	value  float64
+	rank   int
+}
+
+// rank は指標の値で順位を付ける。同じ値のメンバーは同順位とする
+func (uc *GetGroupLeaderboardUseCaseImpl) rank(stats []entity.UserEventStats) []rankedValue {
+	values := make([]rankedValue, 0, len(stats))
+	for _, memberStats := range stats {
+		// 参加と投票したイベントがないメンバーは順位を付けない
+		if memberStats.AttendVoteCount == 0 {
+			continue
+		}
+		value, ok := uc.metric.value(memberStats)
+		if !ok {
+			continue
+		}
+		values = append(values, rankedValue{userID: memberStats.UserID, value: value})
+	}
+
+	higherIsBetter := uc.metric.higherIsBetter()
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].value != values[j].value {
+			if higherIsBetter {
+				return values[i].value > values[j].value
+			}
+			return values[i].value < values[j].value
+		}
+		return values[i].userID < values[j].userID
+	})
+
+	for i := range values {
+		if i > 0 && values[i].value == values[i-1].value {
+			values[i].rank = values[i-1].rank
+			continue
+		}
+		values[i].rank = i + 1
+	}
+
+	return values
+}
+
+func (uc *GetGroupLeaderboardUseCaseImpl) member(userID entity.UserID) Member {
+	member := Member{ID: userID}
+
+	user, err := uc.userRepo.FindUserByUserID(userID)
+	if err != nil || user == nil {
+		return member
+	}
+	member.Name = user.UserName
+	member.Icon = user.UserIcon
+	return member
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardPeriod(t *testing.T) {
+	tomorrow := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		from         time.Time
+		to           time.Time
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{name: "正常系: 省略すると翌日0時までの既定の期間", expectedFrom: tomorrow.Add(-defaultLeaderboardPeriod), expectedTo: tomorrow},
+		{name: "正常系: 終了だけを省略すると翌日0時まで", from: from, expectedFrom: from, expectedTo: tomorrow},
+		{name: "正常系: 開始だけを省略すると終了から既定の期間", to: to, expectedFrom: to.Add(-defaultLeaderboardPeriod), expectedTo: to},
+		{name: "正常系: 両方指定するとそのまま", from: from, to: to, expectedFrom: from, expectedTo: to},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// 同じ日のうちは時刻が変わっても同じ期間になる
+			for _, now := range []time.Time{
+				time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 15, 23, 59, 59, 0, time.UTC),
+			} {
+				// テスト実行
+				gotFrom, gotTo := leaderboardPeriod(tc.from, tc.to, now)
+
+				// 結果の検証
+				assert.True(t, tc.expectedFrom.Equal(gotFrom))
+				assert.True(t, tc.expectedTo.Equal(gotTo))
+			}
+		})
+	}
+}
+
+func TestLeaderboardCacheInvalidate(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	keyA := newLeaderboardCacheKey("group-a", LeaderboardMetricTotalLateMinutes, now.Add(-time.Hour), now)
+	keyB := newLeaderboardCacheKey("group-b", LeaderboardMetricTotalLateMinutes, now.Add(-time.Hour), now)
+
+	cache := NewLeaderboardCache(time.Hour)
+	cache.set(keyA, &GetGroupLeaderboardResponse{GroupID: "group-a"}, now)
+	cache.set(keyB, &GetGroupLeaderboardResponse{GroupID: "group-b"}, now)
+
+	// グループを指定した破棄は他のグループに影響しない
+	cache.InvalidateGroup("group-a")
+	_, ok := cache.get(keyA, now)
+	assert.False(t, ok)
+	_, ok = cache.get(keyB, now)
+	assert.True(t, ok)
+
+	cache.InvalidateAll()
+	_, ok = cache.get(keyB, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type Standing struct {
+	Rank             int             `json:"rank"`
+	UserID           entity.UserID   `json:"user_id"`
+	Name             entity.UserName `json:"name"`
+	Alias            entity.Alias    `json:"alias"`
+	Points           entity.Points   `json:"points"`
+	Events           int             `json:"events"`
+	OnTimeCount      int             `json:"on_time_count"`
+	LateCount        int             `json:"late_count"`
+	NoShowCount      int             `json:"no_show_count"`
+	TotalLateMinutes int             `json:"total_late_minutes"`
+}
+
+type GetGroupStandingsResponse struct {
+	GroupID     entity.GroupID     `json:"group_id"`
+	ScoringRule entity.ScoringRule `json:"scoring_rule"`
+	// シーズンを指定した場合のみ設定される
+	SeasonID   entity.SeasonID `json:"season_id,omitempty"`
+	SeasonFrom time.Time       `json:"season_from"`
+	SeasonTo   time.Time       `json:"season_to"`
+	AllTime    []Standing      `json:"all_time"`
+	Seasonal   []Standing      `json:"seasonal"`
+}
+
+type GetGroupStandingsUseCase interface {
+	Execute() (*GetGroupStandingsResponse, error)
+}
+
+type GetGroupStandingsUseCaseImpl struct {
+	groupRepo  repository.GroupRepository
+	userRepo   repository.UserRepository
+	scoreRepo  repository.ScoreRepository
+	seasonRepo repository.SeasonRepository
+	userID     entity.UserID
+	groupID    entity.GroupID
+	seasonID   entity.SeasonID
+	seasonFrom time.Time
+	seasonTo   time.Time
+}
+
+// NewGetGroupStandingsUseCase はグループの通算と期間内の順位表を返す
+// シーズンを指定した場合はそのシーズンの順位表を、締めたシーズンなら締めた時点の順位表を返す
+// どちらも指定しない場合は今年 1 年間を期間とする
+func NewGetGroupStandingsUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, scoreRepo repository.ScoreRepository, seasonRepo repository.SeasonRepository, userID entity.UserID, groupID entity.GroupID, seasonID entity.SeasonID, seasonFrom time.Time, seasonTo time.Time) *GetGroupStandingsUseCaseImpl {
+	return &GetGroupStandingsUseCaseImpl{
+		groupRepo:  groupRepo,
+		userRepo:   userRepo,
+		scoreRepo:  scoreRepo,
+		seasonRepo: seasonRepo,
+		userID:     userID,
+		groupID:    groupID,
+		seasonID:   seasonID,
+		seasonFrom: seasonFrom,
+		seasonTo:   seasonTo,
+	}
+}
+
+func (uc *GetGroupStandingsUseCaseImpl) Execute() (*GetGroupStandingsResponse, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.userID) {
+		return nil, ErrNotGroupMember
+	}
+
+	var season *entity.Season
+	if uc.seasonID != "" {
+		season, err = findGroupSeason(uc.seasonRepo, group.GroupID, uc.seasonID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seasonFrom, seasonTo := uc.seasonFrom, uc.seasonTo
+	if season != nil {
+		seasonFrom, seasonTo = season.StartDateTime, season.EndDateTime
+	} else if seasonFrom.IsZero() && seasonTo.IsZero() {
+		now := time.Now()
+		seasonFrom = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		seasonTo = seasonFrom.AddDate(1, 0, 0)
+	}
+
+	scores, err := uc.scoreRepo.FindScoresByGroupID(group.GroupID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("スコアの取得に失敗しました: %w", err)
+	}
+
+	users := findScoreUsers(uc.userRepo, scores)
+
+	response := &GetGroupStandingsResponse{
+		GroupID:     group.GroupID,
+		ScoringRule: group.Scoring(),
+		SeasonFrom:  seasonFrom,
+		SeasonTo:    seasonTo,
+		AllTime:     buildStandings(scores, users),
+	}
+
+	switch {
+	case season != nil && season.IsClosed():
+		response.SeasonID = season.SeasonID
+		response.Seasonal = fromSeasonStandings(season.FinalStandings)
+	case season != nil:
+		response.SeasonID = season.SeasonID
+		seasonal := make([]entity.Score, 0, len(scores))
+		for _, score := range scores {
+			if score.SeasonID == season.SeasonID {
+				seasonal = append(seasonal, score)
+			}
+		}
+		response.Seasonal = buildStandings(seasonal, users)
+	default:
+		seasonal := make([]entity.Score, 0, len(scores))
+		for _, score := range scores {
+			if !seasonFrom.IsZero() && score.EventStartDateTime.Before(seasonFrom) {
+				continue
+			}
+			if !seasonTo.IsZero() && !score.EventStartDateTime.Before(seasonTo) {
+				continue
+			}
+			seasonal = append(seasonal, score)
+		}
+		response.Seasonal = buildStandings(seasonal, users)
+	}
+
+	return response, nil
+}
+
+// findScoreUsers はスコアに含まれるユーザーを取得する。見つからないユーザーは nil になる
+func findScoreUsers(userRepo repository.UserRepository, scores []entity.Score) map[entity.UserID]*entity.User {
+	users := make(map[entity.UserID]*entity.User)
+	for _, score := range scores {
+		if _, ok := users[score.UserID]; ok {
+			continue
+		}
+		if score.UserID.IsAnonymous() {
+			users[score.UserID] = entity.DeletedUser(score.UserID)
+			continue
+		}
+		user, err := userRepo.FindUserByUserID(score.UserID)
+		if err != nil {
+			user = nil
+		}
+		users[score.UserID] = user
+	}
+	return users
+}
+
+// buildStandings はスコアをユーザーごとに合計し、ポイントの高い順に並べる
+// 同点の場合は遅刻の合計時間が短い方を上位とし、それも同じなら同順位とする
+func buildStandings(scores []entity.Score, users map[entity.UserID]*entity.User) []Standing {
+	totals := make(map[entity.UserID]*Standing)
+	for _, score := range scores {
+		standing, ok := totals[score.UserID]
+		if !ok {
+			standing = &Standing{UserID: score.UserID}
+			if user := users[score.UserID]; user != nil {
+				standing.Name = user.UserName
+				standing.Alias = user.Alias
+			}
+			totals[score.UserID] = standing
+		}
+
+		standing.Points += score.Points
+		standing.Events++
+		standing.TotalLateMinutes += score.LateMinutes
+		switch score.Outcome {
+		case entity.ScoreOutcomeOnTime:
+			standing.OnTimeCount++
+		case entity.ScoreOutcomeLate:
+			standing.LateCount++
+		case entity.ScoreOutcomeNoShow:
+			standing.NoShowCount++
+		}
+	}
+
+	standings := make([]Standing, 0, len(totals))
+	for _, standing := range totals {
+		standings = append(standings, *standing)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		if standings[i].TotalLateMinutes != standings[j].TotalLateMinutes {
+			return standings[i].TotalLateMinutes < standings[j].TotalLateMinutes
+		}
+		return standings[i].UserID < standings[j].UserID
+	})
+
+	for i := range standings {
+		if i > 0 && standings[i].Points == standings[i-1].Points && standings[i].TotalLateMinutes == standings[i-1].TotalLateMinutes {
+			standings[i].Rank = standings[i-1].Rank
+			continue
+		}
+		standings[i].Rank = i + 1
+	}
+
+	return standings
+}
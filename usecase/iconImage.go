@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image type")
+	ErrImageTooLarge    = errors.New("image dimensions too large")
+)
+
+// IconSizes は保存するアイコンの一辺のピクセル数。クライアントは表示する大きさに近いものを選ぶ
+var IconSizes = []int{64, 128, 256}
+
+// DefaultIconSize はサイズを指定しなかったときに返すアイコンの大きさ
+const DefaultIconSize = 256
+
+const (
+	// 展開するとメモリを使い切るような画像を読み込まないための上限
+	iconMaxSide   = 8000
+	iconMaxPixels = 40_000_000
+	iconQuality   = 85
+)
+
+// iconContentTypes は受け付ける画像の種類
+var iconContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// renderIcons はアップロードされた画像を中央で正方形に切り抜き、IconSizes の大きさの JPEG にする
+// EXIF の向きは画素に反映してから捨てるため、出力に撮影場所などのメタデータは残らない
+func renderIcons(data []byte) (map[int][]byte, error) {
+	if !iconContentTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if config.Width > iconMaxSide || config.Height > iconMaxSide || config.Width*config.Height > iconMaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	square := cropSquare(orient(src, exifOrientation(data)))
+
+	icons := make(map[int][]byte, len(IconSizes))
+	for _, size := range IconSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resizeSquare(square, size), &jpeg.Options{Quality: iconQuality}); err != nil {
+			return nil, fmt.Errorf("アイコンの変換に失敗しました: %w", err)
+		}
+		icons[size] = buf.Bytes()
+	}
+	return icons, nil
+}
+
+// orient は EXIF の Orientation に従って画像を正しい向きにした RGBA 画像を返す
+func orient(src image.Image, orientation int) *image.RGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	if orientation < 2 || orientation > 8 {
+		return rgba
+	}
+
+	// 5〜8 は縦横が入れ替わる
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], rgba.Pix[rgba.PixOffset(x, y):rgba.PixOffset(x, y)+4])
+		}
+	}
+	return dst
+}
+
+// cropSquare は短い辺に合わせて中央を正方形に切り抜く
+func cropSquare(src *image.RGBA) *image.RGBA {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	return src.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.RGBA)
+}
+
+// resizeSquare は正方形の画像を size に縮小する。各画素は元の画像の対応する範囲の平均をとる
+// 透明な部分は白の背景に重ねる
+func resizeSquare(src *image.RGBA, size int) *image.RGBA {
+	b := src.Bounds()
+	side := b.Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		sy0 := y * side / size
+		sy1 := max(sy0+1, (y+1)*side/size)
+		for x := 0; x < size; x++ {
+			sx0 := x * side / size
+			sx1 := max(sx0+1, (x+1)*side/size)
+
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := src.RGBAAt(b.Min.X+sx, b.Min.Y+sy)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					bl += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			// RGBA は乗算済みのため、白に重ねるには透明な分だけ白を足せばよい
+			white := 255 - a/n
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r/n + white),
+				G: uint8(g/n + white),
+				B: uint8(bl/n + white),
+				A: 255,
+			})
+		}
+	}
+	return dst
+}
+
+// exifOrientation は JPEG の EXIF から Orientation を読み取る。読み取れない場合は 1 (そのまま) を返す
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		// SOS 以降は画像データのため、EXIF は出てこない
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// exifSegment は Orientation だけを持つ APP1 セグメントを作る
+func exifSegment(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+	return app1Segment(append([]byte("Exif\x00\x00"), tiff...))
+}
+
+func app1Segment(payload []byte) []byte {
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// withSegment は JPEG の SOI の直後にセグメントを差し込む
+func withSegment(jpg []byte, segment []byte) []byte {
+	data := append([]byte{}, jpg[:2]...)
+	data = append(data, segment...)
+	return append(data, jpg[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// pngHeader は画素データを持たず、IHDR で大きさだけを宣言する PNG を作る
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr, width)
+	binary.BigEndian.PutUint32(ihdr[4:], height)
+	ihdr[8] = 8
+	ihdr[9] = 6
+
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(ihdr)))
+	chunk = append(chunk, "IHDR"...)
+	chunk = append(chunk, ihdr...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	return append([]byte("\x89PNG\r\n\x1a\n"), chunk...)
+}
+
+func TestExifOrientation(t *testing.T) {
+	jpg := encodeJPEG(t, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+
+	for orientation := uint16(1); orientation <= 8; orientation++ {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			t.Run(fmt.Sprintf("正常系: %s の Orientation %d", order, orientation), func(t *testing.T) {
+				assert.Equal(t, int(orientation), exifOrientation(withSegment(jpg, exifSegment(order, orientation))))
+			})
+		}
+	}
+
+	valid := exifSegment(binary.LittleEndian, 6)
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "異常系: EXIF がない", data: jpg},
+		{name: "異常系: JPEG ではない", data: pngHeader(1, 1)},
+		{name: "異常系: SOI だけ", data: jpg[:2]},
+		{name: "異常系: 空のデータ", data: nil},
+		{name: "異常系: セグメントの途中で切れている", data: withSegment(jpg[:2], valid[:len(valid)-4])},
+		{name: "異常系: セグメントの長さが 2 未満", data: withSegment(jpg, []byte{0xFF, 0xE1, 0, 1})},
+		{name: "異常系: マーカーの前に 0xFF がない", data: withSegment(jpg, []byte{0x00, 0xE1, 0, 2})},
+		{name: "異常系: 画像データの後にある EXIF は読まない", data: append([]byte{0xFF, 0xD8, 0xFF, 0xDA, 0, 2}, valid...)},
+		{name: "異常系: Exif の識別子がない", data: withSegment(jpg, app1Segment([]byte("XMP\x00\x00\x00II*\x00\x08\x00\x00\x00")))},
+		{name: "異常系: TIFF ヘッダーが短い", data: withSegment(jpg, app1Segment([]byte("Exif\x00\x00II*\x00")))},
+		{name: "異常系: バイトオーダーが不明", data: withSegment(jpg, app1Segment(append([]byte("Exif\x00\x00XX"), valid[12:]...)))},
+		{name: "異常系: IFD の位置がデータの外", data: withSegment(jpg, app1Segment([]byte("Exif\x00\x00II*\x00\xFF\x00\x00\x00")))},
+		{name: "異常系: IFD のエントリーが途中で切れている", data: withSegment(jpg, app1Segment(append([]byte{}, valid[4:len(valid)-4]...)))},
+		{name: "異常系: Orientation が 0", data: withSegment(jpg, exifSegment(binary.LittleEndian, 0))},
+		{name: "異常系: Orientation が 9", data: withSegment(jpg, exifSegment(binary.BigEndian, 9))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行・結果の検証: 読み取れない場合はそのままの向きとして扱う
+			assert.Equal(t, 1, exifOrientation(tc.data))
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 3x2 の画像の左上と右上に印をつけ、向きを変えた後の位置を調べる
+	const w, h = 3, 2
+	topLeft := color.RGBA{R: 255, A: 255}
+	topRight := color.RGBA{G: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	src.SetRGBA(0, 0, topLeft)
+	src.SetRGBA(w-1, 0, topRight)
+
+	testCases := []struct {
+		name             string
+		orientation      int
+		expectedSize     image.Point
+		expectedTopLeft  image.Point
+		expectedTopRight image.Point
+	}{
+		{name: "1: そのまま", orientation: 1, expectedSize: image.Pt(w, h), expectedTopLeft: image.Pt(0, 0), expectedTopRight: image.Pt(w-1, 0)},
+		{name: "2: 左右反転", orientation: 2, expectedSize: image.Pt(w, h), expectedTopLeft: image.Pt(w-1, 0), expectedTopRight: image.Pt(0, 0)},
+		{name: "3: 180度回転", orientation: 3, expectedSize: image.Pt(w, h), expectedTopLeft: image.Pt(w-1, h-1), expectedTopRight: image.Pt(0, h-1)},
+		{name: "4: 上下反転", orientation: 4, expectedSize: image.Pt(w, h), expectedTopLeft: image.Pt(0, h-1), expectedTopRight: image.Pt(w-1, h-1)},
+		{name: "5: 左上と右下を結ぶ線で反転", orientation: 5, expectedSize: image.Pt(h, w), expectedTopLeft: image.Pt(0, 0), expectedTopRight: image.Pt(0, w-1)},
+		{name: "6: 時計回りに90度回転", orientation: 6, expectedSize: image.Pt(h, w), expectedTopLeft: image.Pt(h-1, 0), expectedTopRight: image.Pt(h-1, w-1)},
+		{name: "7: 右上と左下を結ぶ線で反転", orientation: 7, expectedSize: image.Pt(h, w), expectedTopLeft: image.Pt(h-1, w-1), expectedTopRight: image.Pt(h-1, 0)},
+		{name: "8: 反時計回りに90度回転", orientation: 8, expectedSize: image.Pt(h, w), expectedTopLeft: image.Pt(0, w-1), expectedTopRight: image.Pt(0, 0)},
+		{name: "範囲外の値はそのまま", orientation: 9, expectedSize: image.Pt(w, h), expectedTopLeft: image.Pt(0, 0), expectedTopRight: image.Pt(w-1, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			dst := orient(src, tc.orientation)
+
+			// 結果の検証
+			assert.Equal(t, tc.expectedSize, dst.Bounds().Size())
+			assert.Equal(t, topLeft, dst.RGBAAt(tc.expectedTopLeft.X, tc.expectedTopLeft.Y))
+			assert.Equal(t, topRight, dst.RGBAAt(tc.expectedTopRight.X, tc.expectedTopRight.Y))
+		})
+	}
+}
+
+func TestResizeSquareTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{})
+	src.SetNRGBA(1, 0, color.NRGBA{R: 255, A: 128})
+
+	// テスト実行
+	dst := resizeSquare(orient(src, 1), 2)
+
+	// 結果の検証: 透明な部分は白、半透明の赤は白に重ねた薄い赤になる
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, dst.RGBAAt(0, 0))
+	tinted := dst.RGBAAt(1, 0)
+	assert.Equal(t, uint8(255), tinted.R)
+	assert.InDelta(t, 127, tinted.G, 1)
+	assert.InDelta(t, 127, tinted.B, 1)
+	assert.Equal(t, uint8(255), tinted.A)
+}
+
+func TestRenderIcons(t *testing.T) {
+	t.Run("正常系: 透明な PNG は白の背景になる", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 300, 200))))
+
+		// テスト実行
+		icons, err := renderIcons(buf.Bytes())
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, icons, len(IconSizes))
+		for _, size := range IconSizes {
+			img, err := jpeg.Decode(bytes.NewReader(icons[size]))
+			assert.NoError(t, err)
+			assert.Equal(t, image.Pt(size, size), img.Bounds().Size())
+
+			r, g, b, _ := img.At(size/2, size/2).RGBA()
+			assert.Greater(t, r>>8, uint32(250))
+			assert.Greater(t, g>>8, uint32(250))
+			assert.Greater(t, b>>8, uint32(250))
+		}
+	})
+
+	t.Run("正常系: EXIF は出力に残らない", func(t *testing.T) {
+		data := withSegment(encodeJPEG(t, image.NewRGBA(image.Rect(0, 0, 40, 20))), exifSegment(binary.BigEndian, 6))
+
+		// テスト実行
+		icons, err := renderIcons(data)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		for _, size := range IconSizes {
+			assert.Equal(t, 1, exifOrientation(icons[size]))
+			assert.False(t, bytes.Contains(icons[size], []byte("Exif\x00\x00")))
+		}
+	})
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		expectedError error
+	}{
+		{name: "異常系: 幅が上限を超える", data: pngHeader(iconMaxSide+1, 1), expectedError: ErrImageTooLarge},
+		{name: "異常系: 高さが上限を超える", data: pngHeader(1, iconMaxSide+1), expectedError: ErrImageTooLarge},
+		{name: "異常系: 画素数が上限を超える", data: pngHeader(7000, 7000), expectedError: ErrImageTooLarge},
+		// 上限ちょうどは大きさの検査を通り、画素データがないため読み込みで失敗する
+		{name: "異常系: 上限ちょうどの大きさ", data: pngHeader(iconMaxSide, iconMaxPixels/iconMaxSide), expectedError: ErrUnsupportedImage},
+		{name: "異常系: GIF は受け付けない", data: []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"), expectedError: ErrUnsupportedImage},
+		{name: "異常系: 画像ではない", data: []byte("not an image"), expectedError: ErrUnsupportedImage},
+		{name: "異常系: 空のデータ", data: nil, expectedError: ErrUnsupportedImage},
+		{name: "異常系: PNG のシグネチャだけ", data: []byte("\x89PNG\r\n\x1a\n"), expectedError: ErrUnsupportedImage},
+		{name: "異常系: 途中で切れた JPEG", data: encodeJPEG(t, image.NewRGBA(image.Rect(0, 0, 16, 16)))[:40], expectedError: ErrUnsupportedImage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			icons, err := renderIcons(tc.data)
+
+			// 結果の検証
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, icons)
+		})
+	}
+}
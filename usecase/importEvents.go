@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type EventImportStatus string
+
+const (
+	EventImportCreated EventImportStatus = "created"
+	EventImportSkipped EventImportStatus = "skipped"
+	EventImportFailed  EventImportStatus = "failed"
+)
+
+// EventImportItem は取り込むファイルの 1 件分。読み取りに失敗した場合は ParseErr が設定される
+type EventImportItem struct {
+	UID      string
+	Event    *entity.Event
+	ParseErr error
+}
+
+type EventImportResult struct {
+	UID     string            `json:"uid"`
+	Title   entity.EventTitle `json:"title,omitempty"`
+	Status  EventImportStatus `json:"status"`
+	EventID entity.EventID    `json:"event_id,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type ImportEventsResponse struct {
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Results []EventImportResult `json:"results"`
+}
+
+type ImportEventsUseCase interface {
+	Execute() (*ImportEventsResponse, error)
+}
+
+type ImportEventsUseCaseImpl struct {
+	eventRepo  repository.EventRepository
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	userID     entity.UserID
+	groupID    entity.GroupID
+	items      []EventImportItem
+}
+
+// NewImportEventsUseCase は取り込んだ予定をグループのイベントとして作成する
+// すでに同じ UID で取り込んだ予定は作成しないため、同じファイルを取り込み直しても重複しない
+func NewImportEventsUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, userID entity.UserID, groupID entity.GroupID, items []EventImportItem) *ImportEventsUseCaseImpl {
+	return &ImportEventsUseCaseImpl{
+		eventRepo:  eventRepo,
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		userID:     userID,
+		groupID:    groupID,
+		items:      items,
+	}
+}
+
+func (uc *ImportEventsUseCaseImpl) Execute() (*ImportEventsResponse, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if !isGroupMember(group, uc.userID) {
+		return nil, ErrNotGroupMember
+	}
+
+	uids := make([]string, 0, len(uc.items))
+	for _, item := range uc.items {
+		if item.UID != "" {
+			uids = append(uids, item.UID)
+		}
+	}
+
+	existing, err := uc.eventRepo.FindEventsByImportUIDs(group.GroupEvents, uids)
+	if err != nil {
+		return nil, fmt.Errorf("取り込み済みのイベントの取得に失敗しました: %w", err)
+	}
+	imported := make(map[string]entity.EventID, len(existing))
+	for _, event := range existing {
+		imported[event.ImportUID] = event.EventID
+	}
+
+	response := &ImportEventsResponse{Results: make([]EventImportResult, 0, len(uc.items))}
+	for _, item := range uc.items {
+		result := uc.importItem(item, imported)
+		switch result.Status {
+		case EventImportCreated:
+			response.Created++
+		case EventImportSkipped:
+			response.Skipped++
+		default:
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+func (uc *ImportEventsUseCaseImpl) importItem(item EventImportItem, imported map[string]entity.EventID) EventImportResult {
+	result := EventImportResult{UID: item.UID, Status: EventImportFailed}
+	if item.Event != nil {
+		result.Title = item.Event.EventTitle
+	}
+
+	switch {
+	case item.ParseErr != nil:
+		result.Error = item.ParseErr.Error()
+		return result
+	case item.UID == "":
+		result.Error = "UID がない予定は取り込めません"
+		return result
+	}
+
+	// 取り込み済みの予定と、同じファイル内で先に出てきた同じ UID の予定は飛ばす
+	if eventID, ok := imported[item.UID]; ok {
+		result.Status = EventImportSkipped
+		result.EventID = eventID
+		return result
+	}
+
+	event := *item.Event
+	event.EventAuthorID = uc.userID
+	event.ImportUID = item.UID
+	if event.VotedMembers == nil {
+		event.VotedMembers = []entity.VotedMember{}
+	}
+
+	// まとめて取り込んだ予定の数だけ通知が届かないよう、取り込みでは通知しない
+	created, err := NewCreateEventUseCase(uc.eventRepo, uc.groupRepo, uc.seasonRepo, nil, &event, uc.groupID).Execute()
+	if err != nil {
+		result.Error = fmt.Sprintf("イベントの作成に失敗しました: %v", err)
+		return result
+	}
+
+	imported[item.UID] = created.EventID
+	result.Status = EventImportCreated
+	result.EventID = created.EventID
+	return result
+}
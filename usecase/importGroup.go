@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedArchive = errors.New("unsupported group archive version")
+
+type ImportGroupResponse struct {
+	Group *entity.Group `json:"group"`
+	// 書き出し元のイベントIDから作成したイベントIDへの対応
+	EventIDs map[entity.EventID]entity.EventID `json:"event_ids"`
+}
+
+type ImportGroupUseCase interface {
+	Execute() (*ImportGroupResponse, error)
+}
+
+type ImportGroupUseCaseImpl struct {
+	groupRepo repository.GroupRepository
+	eventRepo repository.EventRepository
+	archive   *GroupArchive
+	groupName entity.GroupName
+}
+
+// NewImportGroupUseCase は書き出したグループを新しいIDで作成し直す。メンバーと投票のユーザーIDはそのまま引き継ぐ
+// groupName が空でなければ、書き出し元と同じ環境に複製できるようグループ名を置き換える
+func NewImportGroupUseCase(groupRepo repository.GroupRepository, eventRepo repository.EventRepository, archive *GroupArchive, groupName entity.GroupName) *ImportGroupUseCaseImpl {
+	return &ImportGroupUseCaseImpl{
+		groupRepo: groupRepo,
+		eventRepo: eventRepo,
+		archive:   archive,
+		groupName: groupName,
+	}
+}
+
+func (uc *ImportGroupUseCaseImpl) Execute() (*ImportGroupResponse, error) {
+	if uc.archive.Version != GroupArchiveVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedArchive, uc.archive.Version)
+	}
+
+	group := uc.archive.Group
+	if uc.groupName != "" {
+		group.GroupName = uc.groupName
+	}
+	// イベントは作成しながら追加していく
+	group.GroupEvents = entity.GroupEvents{}
+
+	createdGroup, err := uc.groupRepo.CreateGroup(group)
+	if err != nil {
+		return nil, fmt.Errorf("グループの作成に失敗しました: %w", err)
+	}
+
+	response := &ImportGroupResponse{
+		Group:    createdGroup,
+		EventIDs: make(map[entity.EventID]entity.EventID, len(uc.archive.Events)),
+	}
+	for _, event := range uc.archive.Events {
+		sourceID := event.EventID
+		event.Version = 0
+		// 繰り返しとシーズンは書き出していないため、単独のイベントとして作成する
+		event.SeriesID = ""
+		event.RecurrenceID = nil
+		event.SeasonID = ""
+
+		createdEvent, err := uc.eventRepo.CreateEventInGroup(event, createdGroup.GroupID)
+		if err != nil {
+			return response, fmt.Errorf("イベント %s の作成に失敗しました: %w", sourceID, err)
+		}
+		response.EventIDs[sourceID] = createdEvent.EventID
+		createdGroup.GroupEvents = append(createdGroup.GroupEvents, createdEvent.EventID)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const calendarTokenSize = 32
+
+type IssueCalendarTokenUseCase interface {
+	Execute() (entity.CalendarToken, error)
+}
+
+type IssueCalendarTokenUseCaseImpl struct {
+	userRepo repository.UserRepository
+	userID   entity.UserID
+}
+
+// NewIssueCalendarTokenUseCase はカレンダー購読用のトークンを発行する。発行し直すと以前のトークンは使えなくなる
+func NewIssueCalendarTokenUseCase(userRepo repository.UserRepository, userID entity.UserID) *IssueCalendarTokenUseCaseImpl {
+	return &IssueCalendarTokenUseCaseImpl{
+		userRepo: userRepo,
+		userID:   userID,
+	}
+}
+
+func (uc *IssueCalendarTokenUseCaseImpl) Execute() (entity.CalendarToken, error) {
+	secret := make([]byte, calendarTokenSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("カレンダー用トークンの生成に失敗しました: %w", err)
+	}
+	token := entity.CalendarToken(hex.EncodeToString(secret))
+
+	if err := uc.userRepo.SetCalendarToken(uc.userID, token); err != nil {
+		return "", fmt.Errorf("カレンダー用トークンの保存に失敗しました: %w", err)
+	}
+
+	return token, nil
+}
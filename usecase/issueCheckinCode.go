@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"time"
+)
+
+type CheckinCodeResponse struct {
+	EventID   entity.EventID `json:"event_id"`
+	Code      string         `json:"code" example:"492039"`
+	Payload   string         `json:"payload" example:"chikokulympic://checkin?code=492039&event_id=xxx"`
+	ExpiresAt time.Time      `json:"expires_at"`
+}
+
+type IssueCheckinCodeUseCase interface {
+	Execute() (*CheckinCodeResponse, error)
+}
+
+type IssueCheckinCodeUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	policy    CheckinCodePolicy
+	userID    entity.UserID
+	eventID   entity.EventID
+}
+
+// NewIssueCheckinCodeUseCase はイベント作成者が会場で表示する現在のチェックインコードを返す
+// シークレットはイベントごとに初回の表示時に生成する
+func NewIssueCheckinCodeUseCase(eventRepo repository.EventRepository, policy CheckinCodePolicy, userID entity.UserID, eventID entity.EventID) *IssueCheckinCodeUseCaseImpl {
+	return &IssueCheckinCodeUseCaseImpl{
+		eventRepo: eventRepo,
+		policy:    policy,
+		userID:    userID,
+		eventID:   eventID,
+	}
+}
+
+func (uc *IssueCheckinCodeUseCaseImpl) Execute() (*CheckinCodeResponse, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if event.EventAuthorID != uc.userID {
+		return nil, ErrNotEventAuthor
+	}
+
+	if event.CheckinSecret == "" {
+		secret, err := generateCheckinSecret()
+		if err != nil {
+			return nil, err
+		}
+		// 同時に初めて表示した場合も同じシークレットを使うよう、保存されたものを使う
+		saved, err := uc.eventRepo.SetCheckinSecretIfMissing(event.EventID, secret)
+		if err != nil {
+			return nil, fmt.Errorf("チェックイン用シークレットの保存に失敗しました: %w", err)
+		}
+		event = saved
+	}
+
+	counter := uc.policy.counterAt(time.Now())
+	code, err := uc.policy.codeAt(event.CheckinSecret, counter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckinCodeResponse{
+		EventID:   event.EventID,
+		Code:      code,
+		Payload:   checkinPayload(event.EventID, code),
+		ExpiresAt: time.Unix((counter+1)*uc.policy.stepSeconds(), 0),
+	}, nil
+}
@@ -13,14 +13,16 @@ type JoinGroupUseCase interface {
 type JoinGroupUseCaseImpl struct {
 	groupRepo repository.GroupRepository
 	userRepo  repository.UserRepository
+	cache     *LeaderboardCache
 	userID    entity.UserID
 	group     entity.Group
 }
 
-func NewJoinGroupUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, userID entity.UserID, group entity.Group) *JoinGroupUseCaseImpl {
+func NewJoinGroupUseCase(groupRepo repository.GroupRepository, userRepo repository.UserRepository, cache *LeaderboardCache, userID entity.UserID, group entity.Group) *JoinGroupUseCaseImpl {
 	return &JoinGroupUseCaseImpl{
 		groupRepo: groupRepo,
 		userRepo:  userRepo,
+		cache:     cache,
 		userID:    userID,
 		group:     group,
 	}
@@ -63,6 +65,7 @@ func (uc *JoinGroupUseCaseImpl) Execute() (*entity.GroupID, error) {
 	if err != nil {
 		return nil, err
 	}
+	uc.cache.InvalidateGroup(updatedGroup.GroupID)
 
 	return &updatedGroup.GroupID, nil
 }
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"sync"
+	"time"
+)
+
+// LeaderboardCache はグループのリーダーボードの計算結果を保持する
+// 集計対象は確定済みのイベントだけなので、イベントの確定時にグループ単位で破棄すれば古い結果は返らない
+type LeaderboardCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[leaderboardCacheKey]leaderboardCacheEntry
+}
+
+type leaderboardCacheKey struct {
+	groupID entity.GroupID
+	metric  LeaderboardMetric
+	from    int64
+	to      int64
+}
+
+type leaderboardCacheEntry struct {
+	response  *GetGroupLeaderboardResponse
+	expiresAt time.Time
+}
+
+// NewLeaderboardCache はキャッシュを返す。メンバーの名前やアイコンの変更は ttl が過ぎるまで反映されない
+func NewLeaderboardCache(ttl time.Duration) *LeaderboardCache {
+	return &LeaderboardCache{
+		ttl:     ttl,
+		entries: make(map[leaderboardCacheKey]leaderboardCacheEntry),
+	}
+}
+
+func newLeaderboardCacheKey(groupID entity.GroupID, metric LeaderboardMetric, from time.Time, to time.Time) leaderboardCacheKey {
+	return leaderboardCacheKey{groupID: groupID, metric: metric, from: from.UnixNano(), to: to.UnixNano()}
+}
+
+func (lc *LeaderboardCache) get(key leaderboardCacheKey, now time.Time) (*GetGroupLeaderboardResponse, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry, ok := lc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if now.After(entry.expiresAt) {
+		delete(lc.entries, key)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+func (lc *LeaderboardCache) set(key leaderboardCacheKey, response *GetGroupLeaderboardResponse, now time.Time) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.entries[key] = leaderboardCacheEntry{response: response, expiresAt: now.Add(lc.ttl)}
+
+	// 期限切れのエントリが溜まり続けないよう掃除する
+	if len(lc.entries) > 1024 {
+		for k, entry := range lc.entries {
+			if now.After(entry.expiresAt) {
+				delete(lc.entries, k)
+			}
+		}
+	}
+}
+
+// InvalidateAll はすべてのグループのリーダーボードを破棄する
+func (lc *LeaderboardCache) InvalidateAll() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.entries = make(map[leaderboardCacheKey]leaderboardCacheEntry)
+}
+
+// InvalidateGroup はグループのリーダーボードをすべて破棄する
+func (lc *LeaderboardCache) InvalidateGroup(groupID entity.GroupID) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for key := range lc.entries {
+		if key.groupID == groupID {
+			delete(lc.entries, key)
+		}
+	}
+}
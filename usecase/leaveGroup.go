@@ -13,13 +13,15 @@ type LeaveGroupUseCase interface {
 
 type LeaveGroupUseCaseImpl struct {
 	groupRepo repository.GroupRepository
+	cache     *LeaderboardCache
 	userID    entity.UserID
 	groupID   entity.GroupID
 }
 
-func NewLeaveGroupUseCase(groupRepo repository.GroupRepository, userID entity.UserID, groupID entity.GroupID) *LeaveGroupUseCaseImpl {
+func NewLeaveGroupUseCase(groupRepo repository.GroupRepository, cache *LeaderboardCache, userID entity.UserID, groupID entity.GroupID) *LeaveGroupUseCaseImpl {
 	return &LeaveGroupUseCaseImpl{
 		groupRepo: groupRepo,
+		cache:     cache,
 		userID:    userID,
 		groupID:   groupID,
 	}
@@ -94,6 +96,9 @@ func (uc *LeaveGroupUseCaseImpl) Execute() error {
 		return fmt.Errorf("user is not a member of the group")
 	}
 
-	_, err = uc.groupRepo.UpdateGroup(*groupFound)
-	return err
+	if _, err := uc.groupRepo.UpdateGroup(*groupFound); err != nil {
+		return err
+	}
+	uc.cache.InvalidateGroup(groupFound.GroupID)
+	return nil
 }
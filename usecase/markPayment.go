@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrNotSettlementParticipant = errors.New("user has no share to pay in this event")
+
+type MarkPaymentUseCase interface {
+	Execute() (*SettlementShare, error)
+}
+
+type MarkPaymentUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	authorID  entity.UserID
+	eventID   entity.EventID
+	userID    entity.UserID
+	paid      bool
+}
+
+// NewMarkPaymentUseCase は参加者の支払いを支払い済み・未払いにする。変更できるのは費用を立て替えたイベントの作成者のみ
+func NewMarkPaymentUseCase(eventRepo repository.EventRepository, authorID entity.UserID, eventID entity.EventID, userID entity.UserID, paid bool) *MarkPaymentUseCaseImpl {
+	return &MarkPaymentUseCaseImpl{
+		eventRepo: eventRepo,
+		authorID:  authorID,
+		eventID:   eventID,
+		userID:    userID,
+		paid:      paid,
+	}
+}
+
+func (uc *MarkPaymentUseCaseImpl) Execute() (*SettlementShare, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+	if event.EventAuthorID != uc.authorID {
+		return nil, ErrNotEventAuthor
+	}
+
+	// 作成者自身の分は立て替えた時点で支払い済みのため変更できない
+	share := settleEvent(event, time.Now()).findShare(uc.userID)
+	if share == nil || uc.userID == event.EventAuthorID {
+		return nil, ErrNotSettlementParticipant
+	}
+
+	// 支払い済みの記録をもう一度付けても支払日時は変えない
+	if _, ok := event.Payments[uc.userID]; ok == uc.paid {
+		return share, nil
+	}
+
+	var payment *entity.Payment
+	share.Paid, share.PaidAt = false, nil
+	if uc.paid {
+		payment = &entity.Payment{PaidAt: time.Now(), MarkedBy: uc.authorID}
+		share.Paid, share.PaidAt = true, &payment.PaidAt
+	}
+
+	if err := uc.eventRepo.SetPayment(event.EventID, uc.userID, payment); err != nil {
+		return nil, fmt.Errorf("支払い状況の更新に失敗しました: %w", err)
+	}
+
+	return share, nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventSeriesMaterializer は繰り返しイベントの回を、一定期間先まで通常のイベントとして作成する
+type EventSeriesMaterializer struct {
+	seriesRepo repository.EventSeriesRepository
+	eventRepo  repository.EventRepository
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	window     time.Duration
+}
+
+func NewEventSeriesMaterializer(seriesRepo repository.EventSeriesRepository, eventRepo repository.EventRepository, groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, window time.Duration) *EventSeriesMaterializer {
+	return &EventSeriesMaterializer{
+		seriesRepo: seriesRepo,
+		eventRepo:  eventRepo,
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		window:     window,
+	}
+}
+
+// MaterializeDue は now から window 先までの回が揃っていない繰り返しをすべて進める
+// 1 つの繰り返しで失敗しても残りは続け、失敗はまとめて返す
+func (m *EventSeriesMaterializer) MaterializeDue(now time.Time) error {
+	horizon := now.Add(m.window)
+
+	seriesList, err := m.seriesRepo.FindSeriesDueForMaterialization(horizon)
+	if err != nil {
+		return fmt.Errorf("繰り返しイベントの取得に失敗しました: %w", err)
+	}
+
+	var errs []error
+	for i := range seriesList {
+		if _, err := m.materialize(&seriesList[i], horizon); err != nil {
+			errs = append(errs, fmt.Errorf("series %s: %w", seriesList[i].SeriesID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// materialize は作成済みの範囲から horizon より前に始まる回を作成し、新しく作成した回を返す
+func (m *EventSeriesMaterializer) materialize(series *entity.EventSeries, horizon time.Time) ([]entity.Event, error) {
+	from := series.MaterializedUntil
+	if from.Before(series.StartDateTime) {
+		from = series.StartDateTime
+	}
+
+	starts, exhausted := expandRecurrence(series.Rule, series.StartDateTime, series.Location(), from, horizon)
+
+	created := make([]entity.Event, 0, len(starts))
+	occurrenceIDs := make([]entity.EventID, 0, len(starts))
+	for _, start := range starts {
+		occurrence := series.Occurrence(start)
+
+		seasonID, err := findEventSeason(m.seasonRepo, series.GroupID, start)
+		if err != nil {
+			return created, err
+		}
+		occurrence.SeasonID = seasonID
+
+		event, isNew, err := m.eventRepo.UpsertOccurrence(occurrence)
+		if err != nil {
+			return created, fmt.Errorf("繰り返しイベントの回の作成に失敗しました: %w", err)
+		}
+		if isNew {
+			created = append(created, *event)
+		}
+		occurrenceIDs = append(occurrenceIDs, event.EventID)
+	}
+
+	// 前回グループへの追加前に失敗した回も含め、範囲内のすべての回を追加する
+	if len(occurrenceIDs) > 0 {
+		if err := m.groupRepo.AddGroupEvents(series.GroupID, occurrenceIDs); err != nil {
+			return created, err
+		}
+	}
+
+	if err := m.seriesRepo.SetMaterializedUntil(series.SeriesID, horizon, exhausted); err != nil {
+		return created, err
+	}
+	series.MaterializedUntil = horizon
+	series.Completed = exhausted
+
+	return created, nil
+}
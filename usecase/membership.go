@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+// findEventGroup はイベントが属するグループを取得し、ユーザーがそのグループのメンバーであることを確認する
+func findEventGroup(groupRepo repository.GroupRepository, eventID entity.EventID, userID entity.UserID) (*entity.Group, error) {
+	group, err := groupRepo.FindGroupByEventID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotGroupMember, err)
+	}
+
+	if !isGroupMember(group, userID) {
+		return nil, ErrNotGroupMember
+	}
+
+	return group, nil
+}
+
+func isGroupMember(group *entity.Group, userID entity.UserID) bool {
+	if group.GroupManagerID == userID {
+		return true
+	}
+	for _, memberID := range group.GroupMembers {
+		if memberID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/service"
+	"fmt"
+	"log"
+)
+
+// notifyUsers は userIDs のそれぞれに通知する。通知に失敗しても元の操作は取り消さない
+// notifier が nil の場合は通知しない
+func notifyUsers(notifier service.Notifier, userIDs []entity.UserID, notification entity.Notification) {
+	if notifier == nil {
+		return
+	}
+	for _, userID := range userIDs {
+		if err := notifier.Notify(userID, notification); err != nil {
+			log.Printf("WARN: Failed to notify %s [%s]: %v", userID, notification.Kind, err)
+		}
+	}
+}
+
+// groupRecipients はグループの管理者とメンバーのうち、except 以外のユーザーを返す
+func groupRecipients(group *entity.Group, except entity.UserID) []entity.UserID {
+	recipients := []entity.UserID{}
+	if group.GroupManagerID != "" && group.GroupManagerID != except {
+		recipients = append(recipients, group.GroupManagerID)
+	}
+	for _, memberID := range group.GroupMembers {
+		if memberID != except && memberID != group.GroupManagerID {
+			recipients = append(recipients, memberID)
+		}
+	}
+	return recipients
+}
+
+// attendingRecipients はイベントに参加で投票したメンバーのうち、except 以外のユーザーを返す
+func attendingRecipients(event *entity.Event, except entity.UserID) []entity.UserID {
+	recipients := []entity.UserID{}
+	for _, member := range event.VotedMembers {
+		if member.Vote == entity.VoteAttend && member.UserID != except {
+			recipients = append(recipients, member.UserID)
+		}
+	}
+	return recipients
+}
+
+// notifyNewEvent は作成者以外のグループのメンバーにイベントが作成されたことを通知する
+func notifyNewEvent(notifier service.Notifier, group *entity.Group, event *entity.Event) {
+	notifyUsers(notifier, groupRecipients(group, event.EventAuthorID), entity.Notification{
+		Kind:    entity.NotificationNewEvent,
+		GroupID: group.GroupID,
+		Title:   "新しいイベントが作成されました",
+		Body:    fmt.Sprintf("「%s」への参加を投票してください", event.EventTitle),
+		Data:    map[string]string{"event_id": string(event.EventID)},
+	})
+}
+
+// notifyMemberArrived は到着したメンバー以外の参加者に到着を通知する
+func notifyMemberArrived(notifier service.Notifier, groupID entity.GroupID, event *entity.Event, user *entity.User) {
+	notifyUsers(notifier, attendingRecipients(event, user.UserID), entity.Notification{
+		Kind:    entity.NotificationMemberArrived,
+		GroupID: groupID,
+		Title:   fmt.Sprintf("%sさんが到着しました", user.UserName),
+		Body:    fmt.Sprintf("「%s」の会場に到着しました", event.EventTitle),
+		Data:    map[string]string{"event_id": string(event.EventID), "user_id": string(user.UserID)},
+	})
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"testing"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingNotifier struct {
+	sent map[entity.UserID][]entity.Notification
+}
+
+func (n *recordingNotifier) Notify(userID entity.UserID, notification entity.Notification) error {
+	n.sent[userID] = append(n.sent[userID], notification)
+	return nil
+}
+
+func TestNotifyNewEvent(t *testing.T) {
+	group := &entity.Group{
+		GroupID:        "group-id",
+		GroupManagerID: "manager-id",
+		GroupMembers:   entity.GroupMembers{"manager-id", "author-id", "member-id"},
+	}
+	event := &entity.Event{EventID: "event-id", EventTitle: "ランチ", EventAuthorID: "author-id"}
+	notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+
+	// テスト実行
+	notifyNewEvent(notifier, group, event)
+
+	// 結果の検証: 作成者以外に一度ずつ通知する
+	assert.Len(t, notifier.sent, 2)
+	assert.Len(t, notifier.sent["manager-id"], 1)
+	assert.Len(t, notifier.sent["member-id"], 1)
+	assert.Equal(t, entity.NotificationNewEvent, notifier.sent["member-id"][0].Kind)
+	assert.Equal(t, entity.GroupID("group-id"), notifier.sent["member-id"][0].GroupID)
+	assert.Equal(t, "event-id", notifier.sent["member-id"][0].Data["event_id"])
+
+	// notifier がなければ何もしない
+	assert.NotPanics(t, func() { notifyNewEvent(nil, group, event) })
+}
+
+func TestNotifyMemberArrived(t *testing.T) {
+	event := &entity.Event{
+		EventID:    "event-id",
+		EventTitle: "ランチ",
+		VotedMembers: []entity.VotedMember{
+			{UserID: "arrived-id", Vote: entity.VoteAttend},
+			{UserID: "attending-id", Vote: entity.VoteAttend},
+			{UserID: "absent-id", Vote: "不参加"},
+		},
+	}
+	notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+
+	// テスト実行
+	notifyMemberArrived(notifier, "group-id", event, &entity.User{UserID: "arrived-id", UserName: "山田"})
+
+	// 結果の検証: 到着した本人と参加しないメンバーには通知しない
+	assert.Len(t, notifier.sent, 1)
+	assert.Len(t, notifier.sent["attending-id"], 1)
+	assert.Equal(t, entity.NotificationMemberArrived, notifier.sent["attending-id"][0].Kind)
+	assert.Equal(t, "arrived-id", notifier.sent["attending-id"][0].Data["user_id"])
+}
@@ -3,24 +3,38 @@ package usecase
 import (
 	"chikokulympic-api/domain/entity"
 	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
 	"fmt"
+	"time"
 )
 
+// 同時に投票された場合に読み込みからやり直す回数の上限
+const maxParticipationAttempts = 5
+
+var ErrVoteConflict = errors.New("event was updated by too many concurrent votes")
+
 type PostParticipationUseCase interface {
 	Execute() (*entity.Event, error)
 }
 type PostParticipationUseCaseImpl struct {
 	eventRepo repository.EventRepository
 	groupRepo repository.GroupRepository
+	notifier  service.Notifier
 	userID    *entity.UserID
 	eventID   *entity.EventID
 	vote      *entity.Vote
 }
 
-func NewPostParticipationUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userID *entity.UserID, eventID *entity.EventID, vote *entity.Vote) *PostParticipationUseCaseImpl {
+// NewPostParticipationUseCase はイベントへの投票を記録する
+// 上限人数に達したイベントへの参加はキャンセル待ちに並び、参加者が投票を変えると並んだ順に繰り上がる
+// 上限人数のないイベントはメンバーの投票だけを書き換え、上限人数のあるイベントは版数を確かめて更新するため、
+// 同時に投票されても投票が失われたり、最後の 1 席を 2 人が取ったりすることはない
+func NewPostParticipationUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, notifier service.Notifier, userID *entity.UserID, eventID *entity.EventID, vote *entity.Vote) *PostParticipationUseCaseImpl {
 	return &PostParticipationUseCaseImpl{
 		eventRepo: eventRepo,
 		groupRepo: groupRepo,
+		notifier:  notifier,
 		userID:    userID,
 		eventID:   eventID,
 		vote:      vote,
@@ -28,61 +42,149 @@ func NewPostParticipationUseCase(eventRepo repository.EventRepository, groupRepo
 }
 
 func (uc *PostParticipationUseCaseImpl) Execute() (*entity.Event, error) {
-	event, err := uc.eventRepo.FindEventByEventID(*uc.eventID)
-	if err != nil {
-		return nil, err
-	}
-	if event == nil {
-		return nil, fmt.Errorf("event not found")
+	var groupID entity.GroupID
+	for attempt := 0; attempt < maxParticipationAttempts; attempt++ {
+		event, err := uc.eventRepo.FindEventByEventID(*uc.eventID)
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			return nil, fmt.Errorf("event not found")
+		}
+
+		if attempt == 0 {
+			if groupID, err = uc.checkMembership(event.EventID); err != nil {
+				return nil, err
+			}
+		}
+
+		if event.Capacity == 0 && len(event.Waitlist) == 0 {
+			updatedEvent, err := uc.eventRepo.UpsertVote(event.EventID, *uc.userID, *uc.vote)
+			if err != nil {
+				return nil, fmt.Errorf("投票情報の更新に失敗しました: %v", err)
+			}
+			return updatedEvent, nil
+		}
+
+		promoted := applyVote(event, *uc.userID, *uc.vote, time.Now())
+
+		updatedEvent, err := uc.eventRepo.UpdateEventIfVersion(*event)
+		if errors.Is(err, repository.ErrEventVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("投票情報の更新に失敗しました: %v", err)
+		}
+
+		uc.notifyPromoted(updatedEvent, groupID, promoted)
+		return updatedEvent, nil
 	}
 
-	var isGroupMember bool = false
+	return nil, ErrVoteConflict
+}
 
+// checkMembership はイベントのグループに所属しているか確かめ、そのグループの ID を返す
+func (uc *PostParticipationUseCaseImpl) checkMembership(eventID entity.EventID) (entity.GroupID, error) {
 	groups, err := uc.groupRepo.FindGroupsByUserID(*uc.userID)
 	if err != nil {
-		return nil, fmt.Errorf("ユーザーの所属グループ取得中にエラーが発生しました: %v", err)
+		return "", fmt.Errorf("ユーザーの所属グループ取得中にエラーが発生しました: %v", err)
 	}
 
 	for _, group := range groups {
-		for _, eventID := range group.GroupEvents {
-			if eventID == event.EventID {
-				isGroupMember = true
-				break
+		for _, id := range group.GroupEvents {
+			if id == eventID {
+				return group.GroupID, nil
 			}
 		}
-		if isGroupMember {
+	}
+
+	return "", fmt.Errorf("not a group member. cannot vote")
+}
+
+// notifyPromoted は繰り上がったメンバーに通知する。通知に失敗しても投票は取り消さない
+func (uc *PostParticipationUseCaseImpl) notifyPromoted(event *entity.Event, groupID entity.GroupID, promoted []entity.UserID) {
+	notifyUsers(uc.notifier, promoted, entity.Notification{
+		Kind:    entity.NotificationWaitlistPromoted,
+		GroupID: groupID,
+		Title:   "キャンセル待ちから繰り上がりました",
+		Body:    fmt.Sprintf("「%s」に参加できるようになりました", event.EventTitle),
+		Data:    map[string]string{"event_id": string(event.EventID)},
+	})
+}
+
+// applyVote はイベントに投票を反映し、キャンセル待ちから繰り上がったメンバーを返す
+// すでに参加またはキャンセル待ちのメンバーがもう一度参加と投票しても順番は変わらない
+func applyVote(event *entity.Event, userID entity.UserID, vote entity.Vote, now time.Time) []entity.UserID {
+	memberIndex := -1
+	for i, member := range event.VotedMembers {
+		if member.UserID == userID {
+			memberIndex = i
 			break
 		}
 	}
+	wasAttending := memberIndex >= 0 && event.VotedMembers[memberIndex].Vote == entity.VoteAttend
 
-	if !isGroupMember {
-		return nil, fmt.Errorf("not a group member. cannot vote")
+	if vote == entity.VoteAttend {
+		if wasAttending || isWaitlisted(event, userID) {
+			return nil
+		}
+		// 先に並んでいるメンバーがいる間は空きがあっても追い越さない
+		if event.IsFull() || len(event.Waitlist) > 0 {
+			// キャンセル待ちの間は参加以外の投票も残さない
+			if memberIndex >= 0 {
+				event.VotedMembers = append(event.VotedMembers[:memberIndex], event.VotedMembers[memberIndex+1:]...)
+			}
+			event.Waitlist = append(event.Waitlist, entity.WaitlistEntry{UserID: userID, RequestedAt: now})
+			return nil
+		}
 	}
 
-	found := false
+	removeFromWaitlist(event, userID)
+	setVote(event, userID, vote)
+
+	if !wasAttending || vote == entity.VoteAttend {
+		return nil
+	}
+
+	var promoted []entity.UserID
+	for len(event.Waitlist) > 0 && !event.IsFull() {
+		next := event.Waitlist[0]
+		event.Waitlist = event.Waitlist[1:]
+		setVote(event, next.UserID, entity.VoteAttend)
+		promoted = append(promoted, next.UserID)
+	}
+	return promoted
+}
+
+// setVote はメンバーの投票を書き換える。投票を変えても到着の記録は残す
+func setVote(event *entity.Event, userID entity.UserID, vote entity.Vote) {
 	for i, member := range event.VotedMembers {
-		if member.UserID == *uc.userID {
-			event.VotedMembers[i] = entity.VotedMember{
-				UserID: *uc.userID,
-				Vote:   *uc.vote,
-			}
-			found = true
-			break
+		if member.UserID == userID {
+			event.VotedMembers[i].Vote = vote
+			return
 		}
 	}
 
-	if !found {
-		newMember := entity.VotedMember{
-			UserID: *uc.userID,
-			Vote:   *uc.vote,
+	event.VotedMembers = append(event.VotedMembers, entity.VotedMember{
+		UserID: userID,
+		Vote:   vote,
+	})
+}
+
+func isWaitlisted(event *entity.Event, userID entity.UserID) bool {
+	for _, entry := range event.Waitlist {
+		if entry.UserID == userID {
+			return true
 		}
-		event.VotedMembers = append(event.VotedMembers, newMember)
 	}
+	return false
+}
 
-	updatedEvent, err := uc.eventRepo.UpdateEvent(*event)
-	if err != nil {
-		return nil, fmt.Errorf("投票情報の更新に失敗しました: %v", err)
+func removeFromWaitlist(event *entity.Event, userID entity.UserID) {
+	for i, entry := range event.Waitlist {
+		if entry.UserID == userID {
+			event.Waitlist = append(event.Waitlist[:i], event.Waitlist[i+1:]...)
+			return
+		}
 	}
-
-	return updatedEvent, nil
 }
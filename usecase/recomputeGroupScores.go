@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type RecomputeGroupScoresResponse struct {
+	GroupID entity.GroupID `json:"group_id"`
+	// スコアを計算し直した確定済みのイベントの数
+	Events int                     `json:"events"`
+	Scores int                     `json:"scores"`
+	Titles *EvaluateTitlesResponse `json:"titles"`
+}
+
+type RecomputeGroupScoresUseCase interface {
+	Execute() (*RecomputeGroupScoresResponse, error)
+}
+
+type RecomputeGroupScoresUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+	scoreRepo repository.ScoreRepository
+	titleRepo repository.TitleRepository
+	cache     *LeaderboardCache
+	groupID   entity.GroupID
+}
+
+// NewRecomputeGroupScoresUseCase はグループの確定済みのイベントのスコアを現在のポイント計算の設定で計算し直し、称号を付け直す
+// cache が nil の場合はキャッシュを破棄しない
+func NewRecomputeGroupScoresUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, scoreRepo repository.ScoreRepository, titleRepo repository.TitleRepository, cache *LeaderboardCache, groupID entity.GroupID) *RecomputeGroupScoresUseCaseImpl {
+	return &RecomputeGroupScoresUseCaseImpl{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		scoreRepo: scoreRepo,
+		titleRepo: titleRepo,
+		cache:     cache,
+		groupID:   groupID,
+	}
+}
+
+func (uc *RecomputeGroupScoresUseCaseImpl) Execute() (*RecomputeGroupScoresResponse, error) {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	events, err := uc.eventRepo.FindEventsByEventIDs(group.GroupEvents)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの取得に失敗しました: %w", err)
+	}
+
+	response := &RecomputeGroupScoresResponse{GroupID: group.GroupID}
+	for i := range events {
+		event := &events[i]
+		if event.FinalizedAt == nil {
+			continue
+		}
+
+		ranking, err := NewGetArrivalRankingUseCase(uc.eventRepo, &event.EventID, uc.userRepo).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("到着ランキングの取得に失敗しました: %w", err)
+		}
+
+		// 確定した時刻を使い、確定時と同じ条件で計算する
+		scores := scoreEvent(event, group.GroupID, group.Scoring(), ranking.Ranking, *event.FinalizedAt)
+		if err := uc.scoreRepo.SaveScores(scores); err != nil {
+			return nil, fmt.Errorf("スコアの保存に失敗しました: %w", err)
+		}
+		response.Events++
+		response.Scores += len(scores)
+	}
+
+	titles, err := NewEvaluateTitlesUseCase(uc.scoreRepo, uc.titleRepo, uc.userRepo, DefaultTitleRules(), group.GroupID, "").Execute()
+	if err != nil {
+		return nil, err
+	}
+	response.Titles = titles
+
+	if uc.cache != nil {
+		uc.cache.InvalidateGroup(group.GroupID)
+	}
+
+	return response, nil
+}
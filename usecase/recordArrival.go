@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrNotAttending       = errors.New("user has not voted to attend this event")
+	ErrAlreadyArrived     = errors.New("arrival already recorded")
+	ErrOutsideEventWindow = errors.New("outside of the event window")
+	ErrArrivalNotVerified = errors.New("arrival could not be verified")
+)
+
+// 端末の時計のずれとして許容する範囲
+const arrivalClockSkew = 1 * time.Minute
+
+// ArrivalPolicy は到着報告の妥当性を判定する基準
+type ArrivalPolicy struct {
+	GeofenceRadiusMeters float64
+	MinSamplesInGeofence int
+	MinDwell             time.Duration
+	MaxSpeedKmh          float64
+	// 端末から送られたサンプルは、受け取った時刻からこれより前のものを使わない
+	MaxSampleAge time.Duration
+}
+
+// DefaultArrivalPolicy は GPS の誤差と新幹線程度の移動速度を許容する基準
+func DefaultArrivalPolicy() ArrivalPolicy {
+	return ArrivalPolicy{
+		GeofenceRadiusMeters: 150,
+		MinSamplesInGeofence: 3,
+		MinDwell:             1 * time.Minute,
+		MaxSpeedKmh:          320,
+		MaxSampleAge:         30 * time.Minute,
+	}
+}
+
+type ArrivalVerification struct {
+	ArrivalDateTime time.Time
+	Reasons         []string
+}
+
+// ReviewFlag は疑わしい点があった場合に確認待ちの印を返し、なければ nil を返す
+func (v *ArrivalVerification) ReviewFlag(now time.Time) *entity.ArrivalFlag {
+	if len(v.Reasons) == 0 {
+		return nil
+	}
+	return &entity.ArrivalFlag{
+		Status:    entity.ArrivalReviewPending,
+		Reasons:   v.Reasons,
+		FlaggedAt: now,
+	}
+}
+
+// Verify は位置のサンプル列から到着時刻を判定する
+// 前のサンプルからあり得ない速度で移動したサンプルは瞬間移動として除外し、その事実を Reasons に残す
+// ジオフェンス内に連続して MinSamplesInGeofence 件以上、MinDwell 以上滞在した最初の時点を到着とみなす
+func (p ArrivalPolicy) Verify(samples []entity.UserLocation, latitude entity.Latitude, longitude entity.Longitude) (*ArrivalVerification, error) {
+	sorted := make([]entity.UserLocation, len(samples))
+	copy(sorted, samples)
+	// 同時刻のサンプルは報告された順に判定する
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RecordedAt.Before(sorted[j].RecordedAt)
+	})
+
+	var (
+		reasons   []string
+		prev      *entity.UserLocation
+		dwellFrom *entity.UserLocation
+		dwellLen  int
+	)
+
+	for i := range sorted {
+		sample := sorted[i]
+
+		if prev != nil {
+			elapsed := sample.RecordedAt.Sub(prev.RecordedAt)
+			distance := haversineDistance(prev.Latitude, prev.Longitude, sample.Latitude, sample.Longitude)
+			if elapsed <= 0 {
+				if distance > p.GeofenceRadiusMeters {
+					reasons = append(reasons, fmt.Sprintf("%s に同時刻の離れた位置が報告されました", sample.RecordedAt.Format(time.RFC3339)))
+				}
+				continue
+			}
+
+			speedKmh := distance / elapsed.Seconds() * 3.6
+			if speedKmh > p.MaxSpeedKmh {
+				reasons = append(reasons, fmt.Sprintf("%s に時速%.0fkmの移動が検出されました", sample.RecordedAt.Format(time.RFC3339), speedKmh))
+				continue
+			}
+		}
+		prev = &sorted[i]
+
+		if haversineDistance(sample.Latitude, sample.Longitude, latitude, longitude) > p.GeofenceRadiusMeters {
+			dwellFrom = nil
+			dwellLen = 0
+			continue
+		}
+
+		if dwellFrom == nil {
+			dwellFrom = &sorted[i]
+		}
+		dwellLen++
+
+		if dwellLen >= p.MinSamplesInGeofence && sample.RecordedAt.Sub(dwellFrom.RecordedAt) >= p.MinDwell {
+			return &ArrivalVerification{
+				ArrivalDateTime: dwellFrom.RecordedAt,
+				Reasons:         reasons,
+			}, nil
+		}
+	}
+
+	return nil, ErrArrivalNotVerified
+}
+
+// Observed はサーバーが受け取った位置で、到着した時刻以降に会場にいたことを確かめられるかを返す
+// 端末から送られたサンプルは時刻も位置も書き換えられるため、それだけで判定した到着は確認待ちにする
+func (p ArrivalPolicy) Observed(arrivalDateTime time.Time, observed []entity.UserLocation, latitude entity.Latitude, longitude entity.Longitude) bool {
+	for _, sample := range observed {
+		if sample.RecordedAt.Before(arrivalDateTime.Add(-arrivalClockSkew)) {
+			continue
+		}
+		if haversineDistance(sample.Latitude, sample.Longitude, latitude, longitude) <= p.GeofenceRadiusMeters {
+			return true
+		}
+	}
+	return false
+}
+
+type RecordArrivalResponse struct {
+	EventID         entity.EventID      `json:"event_id"`
+	UserID          entity.UserID       `json:"user_id"`
+	ArrivalDateTime time.Time           `json:"arrival_date_time"`
+	ArrivalFlag     *entity.ArrivalFlag `json:"arrival_flag,omitempty"`
+}
+
+type RecordArrivalUseCase interface {
+	Execute() (*RecordArrivalResponse, error)
+}
+
+type RecordArrivalUseCaseImpl struct {
+	eventRepo   repository.EventRepository
+	groupRepo   repository.GroupRepository
+	historyRepo repository.LocationHistoryRepository
+	policy      ArrivalPolicy
+	notifier    service.Notifier
+	user        *entity.User
+	eventID     entity.EventID
+	samples     []entity.UserLocation
+}
+
+func NewRecordArrivalUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, historyRepo repository.LocationHistoryRepository, policy ArrivalPolicy, notifier service.Notifier, user *entity.User, eventID entity.EventID, samples []entity.UserLocation) *RecordArrivalUseCaseImpl {
+	return &RecordArrivalUseCaseImpl{
+		eventRepo:   eventRepo,
+		groupRepo:   groupRepo,
+		historyRepo: historyRepo,
+		policy:      policy,
+		notifier:    notifier,
+		user:        user,
+		eventID:     eventID,
+		samples:     samples,
+	}
+}
+
+func (uc *RecordArrivalUseCaseImpl) Execute() (*RecordArrivalResponse, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	group, err := findEventGroup(uc.groupRepo, event.EventID, uc.user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIndex := -1
+	for i, member := range event.VotedMembers {
+		if member.UserID == uc.user.UserID {
+			memberIndex = i
+			break
+		}
+	}
+	if memberIndex < 0 || event.VotedMembers[memberIndex].Vote != entity.VoteAttend {
+		return nil, ErrNotAttending
+	}
+	if event.VotedMembers[memberIndex].IsArrival {
+		return nil, ErrAlreadyArrived
+	}
+
+	now := time.Now()
+	windowStart := time.Time(event.EventStartDateTime).Add(-locationTrailLeadTime)
+	windowEnd := time.Time(event.EventEndDateTime)
+	if now.Before(windowStart) || now.After(windowEnd) {
+		return nil, ErrOutsideEventWindow
+	}
+
+	// 位置履歴には位置共有でサーバーが受け取った位置だけが、受け取った時刻で記録されている
+	// 端末から送られたサンプルと区別できるよう、サンプルは履歴に残さない
+	var observed []entity.UserLocation
+	if uc.user.SharesLocationWith(group.GroupID) {
+		trail, err := uc.historyRepo.FindTrail(event.EventID, uc.user.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range trail {
+			observed = append(observed, entity.UserLocation{
+				UserID:     point.UserID,
+				Latitude:   point.Latitude,
+				Longitude:  point.Longitude,
+				RecordedAt: point.RecordedAt,
+			})
+		}
+	}
+
+	// 端末から送られたサンプルは期間内で、受け取った時刻より後でも古すぎもしないものだけを採用する
+	samples := make([]entity.UserLocation, 0, len(observed)+len(uc.samples))
+	samples = append(samples, observed...)
+	for _, sample := range uc.samples {
+		if sample.RecordedAt.Before(windowStart) || sample.RecordedAt.Before(now.Add(-uc.policy.MaxSampleAge)) || sample.RecordedAt.After(now.Add(arrivalClockSkew)) {
+			continue
+		}
+		sample.UserID = uc.user.UserID
+		samples = append(samples, sample)
+	}
+
+	verification, err := uc.policy.Verify(samples, event.Latitude, event.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	if !uc.policy.Observed(verification.ArrivalDateTime, observed, event.Latitude, event.Longitude) {
+		verification.Reasons = append(verification.Reasons, "サーバーが受け取った位置では会場への到着を確かめられませんでした")
+	}
+
+	member := &event.VotedMembers[memberIndex]
+	member.IsArrival = true
+	member.ArrivalDateTime = verification.ArrivalDateTime
+	member.ArrivalFlag = verification.ReviewFlag(now)
+
+	if err := uc.eventRepo.UpdateVotedMember(event.EventID, *member); err != nil {
+		return nil, fmt.Errorf("到着情報の更新に失敗しました: %w", err)
+	}
+	// 確認待ちの到着は却下されることがあるため、他のメンバーには知らせない
+	if member.ArrivalFlag == nil {
+		notifyMemberArrived(uc.notifier, group.GroupID, event, uc.user)
+	}
+
+	return &RecordArrivalResponse{
+		EventID:         event.EventID,
+		UserID:          member.UserID,
+		ArrivalDateTime: member.ArrivalDateTime,
+		ArrivalFlag:     member.ArrivalFlag,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordArrivalUseCase(t *testing.T) {
+	const (
+		latitude  entity.Latitude  = 35.6812
+		longitude entity.Longitude = 139.7671
+		nearby    entity.Latitude  = latitude + 0.01
+	)
+
+	newUseCase := func(now time.Time, user *entity.User, trail []entity.LocationTrailPoint, samples []entity.UserLocation) (*RecordArrivalUseCaseImpl, *eventRepoStub, *recordingNotifier) {
+		event := &entity.Event{
+			EventID:            "event-id",
+			EventTitle:         "ランチ",
+			Latitude:           latitude,
+			Longitude:          longitude,
+			EventStartDateTime: entity.StartDateTIme(now.Add(-10 * time.Minute)),
+			EventEndDateTime:   entity.EndDateTime(now.Add(time.Hour)),
+			VotedMembers: []entity.VotedMember{
+				{UserID: "arrival-user-id", Vote: entity.VoteAttend},
+				{UserID: "member-id", Vote: entity.VoteAttend},
+			},
+		}
+		group := &entity.Group{GroupID: "group-id", GroupMembers: entity.GroupMembers{"arrival-user-id", "member-id"}, GroupEvents: entity.GroupEvents{"event-id"}}
+		eventRepo := newEventRepoStub(event)
+		notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+		uc := NewRecordArrivalUseCase(eventRepo, &groupRepoStub{groups: []*entity.Group{group}}, &historyRepoStub{points: trail}, DefaultArrivalPolicy(), notifier, user, "event-id", samples)
+		return uc, eventRepo, notifier
+	}
+
+	point := func(lat entity.Latitude, at time.Time) entity.LocationTrailPoint {
+		return entity.LocationTrailPoint{EventID: "event-id", UserID: "arrival-user-id", Latitude: lat, Longitude: longitude, RecordedAt: at}
+	}
+	sample := func(lat entity.Latitude, at time.Time) entity.UserLocation {
+		return entity.UserLocation{Latitude: lat, Longitude: longitude, RecordedAt: at}
+	}
+
+	t.Run("正常系: サーバーが受け取った位置で確かめられた到着はそのまま記録し、他の参加者に通知する", func(t *testing.T) {
+		now := time.Now()
+		trail := []entity.LocationTrailPoint{
+			point(nearby, now.Add(-20*time.Minute)),
+			point(latitude, now.Add(-3*time.Minute)),
+			point(latitude, now.Add(-2*time.Minute)),
+			point(latitude, now.Add(-time.Minute)),
+		}
+		uc, eventRepo, notifier := newUseCase(now, &entity.User{UserID: "arrival-user-id", UserName: "到着"}, trail, nil)
+
+		// テスト実行
+		result, err := uc.Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Nil(t, result.ArrivalFlag)
+		assert.True(t, now.Add(-3*time.Minute).Equal(result.ArrivalDateTime))
+		saved := eventRepo.events["event-id"].VotedMembers[0]
+		assert.True(t, saved.IsArrival)
+		assert.True(t, saved.HasConfirmedArrival())
+		assert.Len(t, notifier.sent["member-id"], 1)
+		assert.Equal(t, entity.NotificationMemberArrived, notifier.sent["member-id"][0].Kind)
+		assert.Empty(t, notifier.sent["arrival-user-id"])
+	})
+
+	t.Run("正常系: 端末から送られたサンプルだけで判定した到着は確認待ちにし、通知しない", func(t *testing.T) {
+		now := time.Now()
+		samples := []entity.UserLocation{
+			sample(latitude, now.Add(-3*time.Minute)),
+			sample(latitude, now.Add(-2*time.Minute)),
+			sample(latitude, now.Add(-time.Minute)),
+		}
+		uc, eventRepo, notifier := newUseCase(now, &entity.User{UserID: "arrival-user-id"}, nil, samples)
+
+		result, err := uc.Execute()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.ArrivalFlag)
+		assert.Equal(t, entity.ArrivalReviewPending, result.ArrivalFlag.Status)
+		assert.False(t, eventRepo.events["event-id"].VotedMembers[0].HasConfirmedArrival())
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("正常系: 共有を停止しているグループでは履歴を使わず、確認待ちにする", func(t *testing.T) {
+		now := time.Now()
+		trail := []entity.LocationTrailPoint{
+			point(latitude, now.Add(-3*time.Minute)),
+			point(latitude, now.Add(-2*time.Minute)),
+			point(latitude, now.Add(-time.Minute)),
+		}
+		user := &entity.User{UserID: "arrival-user-id", LocationOptOutGroups: []entity.GroupID{"group-id"}}
+		uc, _, _ := newUseCase(now, user, trail, nil)
+
+		_, err := uc.Execute()
+
+		assert.ErrorIs(t, err, ErrArrivalNotVerified)
+	})
+
+	t.Run("異常系: 受け取った時刻より後や古すぎるサンプルは使わない", func(t *testing.T) {
+		now := time.Now()
+		policy := DefaultArrivalPolicy()
+
+		testCases := []struct {
+			name    string
+			samples []entity.UserLocation
+		}{
+			{
+				name: "受け取った時刻より後",
+				samples: []entity.UserLocation{
+					sample(latitude, now.Add(2*time.Minute)),
+					sample(latitude, now.Add(3*time.Minute)),
+					sample(latitude, now.Add(4*time.Minute)),
+				},
+			},
+			{
+				name: "受け取った時刻より古すぎる",
+				samples: []entity.UserLocation{
+					sample(latitude, now.Add(-policy.MaxSampleAge-3*time.Minute)),
+					sample(latitude, now.Add(-policy.MaxSampleAge-2*time.Minute)),
+					sample(latitude, now.Add(-policy.MaxSampleAge-time.Minute)),
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				uc, eventRepo, _ := newUseCase(now, &entity.User{UserID: "arrival-user-id"}, nil, tc.samples)
+				// 古いサンプルも期間内に収まるよう、開始を早める
+				eventRepo.events["event-id"].EventStartDateTime = entity.StartDateTIme(now.Add(-time.Hour))
+
+				result, err := uc.Execute()
+
+				assert.ErrorIs(t, err, ErrArrivalNotVerified)
+				assert.Nil(t, result)
+				assert.False(t, eventRepo.events["event-id"].VotedMembers[0].IsArrival)
+			})
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrivalPolicyVerify(t *testing.T) {
+	policy := usecase.DefaultArrivalPolicy()
+
+	// イベント会場と、そこから約1.1km離れた地点・約11km離れた地点
+	const (
+		latitude  entity.Latitude  = 35.6812
+		longitude entity.Longitude = 139.7671
+		nearby    entity.Latitude  = latitude + 0.01
+		distant   entity.Latitude  = latitude + 0.1
+	)
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	sample := func(lat entity.Latitude, offset time.Duration) entity.UserLocation {
+		return entity.UserLocation{UserID: "arrival-user-id", Latitude: lat, Longitude: longitude, RecordedAt: base.Add(offset)}
+	}
+
+	testCases := []struct {
+		name            string
+		samples         []entity.UserLocation
+		expectedArrival time.Time
+		expectedReasons int
+		shouldError     bool
+	}{
+		{
+			name: "正常系: 会場に歩いて到着し滞在した",
+			samples: []entity.UserLocation{
+				sample(nearby, 0),
+				sample(latitude, 15*time.Minute),
+				sample(latitude, 16*time.Minute),
+				sample(latitude, 17*time.Minute),
+			},
+			expectedArrival: base.Add(15 * time.Minute),
+		},
+		{
+			name: "正常系: サンプルの順序が入れ替わっていても到着を判定する",
+			samples: []entity.UserLocation{
+				sample(latitude, 17*time.Minute),
+				sample(latitude, 15*time.Minute),
+				sample(nearby, 0),
+				sample(latitude, 16*time.Minute),
+			},
+			expectedArrival: base.Add(15 * time.Minute),
+		},
+		{
+			name: "正常系: 瞬間移動したサンプルを除外し、理由を残す",
+			samples: []entity.UserLocation{
+				sample(latitude, 0),
+				sample(distant, 1*time.Second),
+				sample(latitude, 30*time.Second),
+				sample(latitude, 1*time.Minute),
+			},
+			expectedArrival: base,
+			expectedReasons: 1,
+		},
+		{
+			name: "正常系: 同時刻に離れた位置が報告されたことを理由に残す",
+			samples: []entity.UserLocation{
+				sample(latitude, 0),
+				sample(nearby, 0),
+				sample(latitude, 30*time.Second),
+				sample(latitude, 1*time.Minute),
+			},
+			expectedArrival: base,
+			expectedReasons: 1,
+		},
+		{
+			name: "異常系: 遠くから瞬間移動した後のサンプルはすべて除外される",
+			samples: []entity.UserLocation{
+				sample(distant, 0),
+				sample(latitude, 10*time.Second),
+				sample(latitude, 1*time.Minute),
+				sample(latitude, 2*time.Minute),
+			},
+			shouldError: true,
+		},
+		{
+			name: "異常系: ジオフェンスの外にしかいない",
+			samples: []entity.UserLocation{
+				sample(nearby, 0),
+				sample(nearby, 5*time.Minute),
+				sample(nearby, 10*time.Minute),
+			},
+			shouldError: true,
+		},
+		{
+			name: "異常系: 滞在時間が足りない",
+			samples: []entity.UserLocation{
+				sample(latitude, 0),
+				sample(latitude, 20*time.Second),
+				sample(latitude, 40*time.Second),
+			},
+			shouldError: true,
+		},
+		{
+			name: "異常系: 滞在中のサンプル数が足りない",
+			samples: []entity.UserLocation{
+				sample(latitude, 0),
+				sample(latitude, 5*time.Minute),
+			},
+			shouldError: true,
+		},
+		{
+			name: "異常系: 途中でジオフェンスを出ると滞在がやり直しになる",
+			samples: []entity.UserLocation{
+				sample(latitude, 0),
+				sample(latitude, 1*time.Minute),
+				sample(nearby, 20*time.Minute),
+				sample(latitude, 40*time.Minute),
+				sample(latitude, 40*time.Minute+30*time.Second),
+			},
+			shouldError: true,
+		},
+		{
+			name:        "異常系: サンプルがない",
+			samples:     nil,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			verification, err := policy.Verify(tc.samples, latitude, longitude)
+
+			// 結果の検証
+			if tc.shouldError {
+				assert.ErrorIs(t, err, usecase.ErrArrivalNotVerified)
+				assert.Nil(t, verification)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tc.expectedArrival.Equal(verification.ArrivalDateTime))
+			assert.Len(t, verification.Reasons, tc.expectedReasons)
+
+			now := base.Add(time.Hour)
+			flag := verification.ReviewFlag(now)
+			if tc.expectedReasons == 0 {
+				assert.Nil(t, flag)
+				return
+			}
+			assert.NotNil(t, flag)
+			assert.Equal(t, entity.ArrivalReviewPending, flag.Status)
+			assert.Equal(t, verification.Reasons, flag.Reasons)
+			assert.True(t, now.Equal(flag.FlaggedAt))
+		})
+	}
+}
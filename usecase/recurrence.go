@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"sort"
+	"time"
+)
+
+// 1 回の展開で調べる週・月の上限。無期限のルールで回り続けないようにする
+const maxRecurrencePeriods = 1000
+
+// expandRecurrence は dtstart から始まる繰り返しのうち、[from, to) に始まる回の開始日時を返す
+// 曜日や日付は location で数え、時刻は dtstart と同じにする
+// ルール上の最後の回までたどり着いた場合は exhausted が true になる
+func expandRecurrence(rule entity.RecurrenceRule, dtstart time.Time, location *time.Location, from time.Time, to time.Time) (starts []time.Time, exhausted bool) {
+	start := dtstart.In(location)
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	count := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		var candidates []time.Time
+		switch rule.Frequency {
+		case entity.RecurrenceMonthly:
+			candidates = monthlyCandidates(rule, start, period*interval)
+		default:
+			candidates = weeklyCandidates(rule, start, period*interval)
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if rule.Until != nil && candidate.After(*rule.Until) {
+				return starts, true
+			}
+			if !candidate.Before(to) {
+				return starts, false
+			}
+			count++
+			if !candidate.Before(from) {
+				starts = append(starts, candidate)
+			}
+			if rule.Count > 0 && count >= rule.Count {
+				return starts, true
+			}
+		}
+	}
+
+	return starts, false
+}
+
+// weeklyCandidates は dtstart を含む週から weeks 週後の週の候補を返す。週は月曜日から始まる
+func weeklyCandidates(rule entity.RecurrenceRule, start time.Time, weeks int) []time.Time {
+	monday := start.Day() - mondayOffset(start.Weekday()) + weeks*7
+
+	weekdays := []time.Weekday{start.Weekday()}
+	if len(rule.ByDay) > 0 {
+		weekdays = weekdays[:0]
+		for _, day := range rule.ByDay {
+			weekdays = append(weekdays, day.Weekday)
+		}
+	}
+
+	candidates := make([]time.Time, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		candidates = append(candidates, atClockOf(start, start.Year(), start.Month(), monday+mondayOffset(weekday)))
+	}
+	return sortUniqueTimes(candidates)
+}
+
+// monthlyCandidates は dtstart を含む月から months か月後の月の候補を返す
+// BYDAY がない場合は dtstart と同じ日で、その日がない月は飛ばす
+func monthlyCandidates(rule entity.RecurrenceRule, start time.Time, months int) []time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(months), 1, 0, 0, 0, 0, start.Location())
+	year, month := first.Year(), first.Month()
+	days := daysInMonth(year, month)
+
+	if len(rule.ByDay) == 0 {
+		if start.Day() > days {
+			return nil
+		}
+		return []time.Time{atClockOf(start, year, month, start.Day())}
+	}
+
+	var candidates []time.Time
+	for _, day := range rule.ByDay {
+		firstDay := 1 + (int(day.Weekday)-int(first.Weekday())+7)%7
+		switch {
+		case day.Ordinal > 0:
+			d := firstDay + (day.Ordinal-1)*7
+			if d <= days {
+				candidates = append(candidates, atClockOf(start, year, month, d))
+			}
+		case day.Ordinal < 0:
+			lastDay := firstDay + (days-firstDay)/7*7
+			d := lastDay + (day.Ordinal+1)*7
+			if d >= 1 {
+				candidates = append(candidates, atClockOf(start, year, month, d))
+			}
+		default:
+			for d := firstDay; d <= days; d += 7 {
+				candidates = append(candidates, atClockOf(start, year, month, d))
+			}
+		}
+	}
+	return sortUniqueTimes(candidates)
+}
+
+// recurrenceStartsAt は dtstart がルールの最初の回になっているかを返す
+func recurrenceStartsAt(rule entity.RecurrenceRule, dtstart time.Time, location *time.Location) bool {
+	starts, _ := expandRecurrence(rule, dtstart, location, dtstart, dtstart.Add(time.Second))
+	return len(starts) == 1 && starts[0].Equal(dtstart)
+}
+
+// shiftRecurrenceDays は BYDAY の曜日を days 日ずらす。回の日付を動かしたときにルールを合わせるために使う
+func shiftRecurrenceDays(byDay []entity.RecurrenceDay, days int) []entity.RecurrenceDay {
+	if len(byDay) == 0 || days%7 == 0 {
+		return byDay
+	}
+	shifted := make([]entity.RecurrenceDay, 0, len(byDay))
+	for _, day := range byDay {
+		shifted = append(shifted, entity.RecurrenceDay{
+			Ordinal: day.Ordinal,
+			Weekday: time.Weekday(((int(day.Weekday)+days)%7 + 7) % 7),
+		})
+	}
+	return shifted
+}
+
+// calendarDaysBetween は location での暦日の差を返す
+func calendarDaysBetween(from time.Time, to time.Time, location *time.Location) int {
+	f := from.In(location)
+	t := to.In(location)
+	fromDate := time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func atClockOf(clock time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), clock.Location())
+}
+
+func sortUniqueTimes(times []time.Time) []time.Time {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	unique := times[:0]
+	for i, t := range times {
+		if i > 0 && t.Equal(times[i-1]) {
+			continue
+		}
+		unique = append(unique, t)
+	}
+	return unique
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	until := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected entity.RecurrenceRule
+		// String で書き戻した結果。空なら value と同じ
+		formatted string
+	}{
+		{
+			name:     "毎週金曜日",
+			value:    "FREQ=WEEKLY;BYDAY=FR",
+			expected: entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, ByDay: []entity.RecurrenceDay{{Weekday: time.Friday}}},
+		},
+		{
+			name:      "RRULE: の接頭辞と小文字を受け付ける",
+			value:     "RRULE:freq=weekly;byday=mo,we",
+			expected:  entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, ByDay: []entity.RecurrenceDay{{Weekday: time.Monday}, {Weekday: time.Wednesday}}},
+			formatted: "FREQ=WEEKLY;BYDAY=MO,WE",
+		},
+		{
+			name:     "隔週で 10 回",
+			value:    "FREQ=WEEKLY;INTERVAL=2;COUNT=10",
+			expected: entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 2, Count: 10},
+		},
+		{
+			name:     "毎月第 2 火曜日と最終金曜日",
+			value:    "FREQ=MONTHLY;BYDAY=2TU,-1FR",
+			expected: entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, ByDay: []entity.RecurrenceDay{{Ordinal: 2, Weekday: time.Tuesday}, {Ordinal: -1, Weekday: time.Friday}}},
+		},
+		{
+			name:     "UNTIL は日時で指定できる",
+			value:    "FREQ=MONTHLY;UNTIL=20260331T235959Z",
+			expected: entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, Until: &until},
+		},
+		{
+			name:      "日付だけの UNTIL はその日の終わりまでを含める",
+			value:     "FREQ=MONTHLY;UNTIL=20260331",
+			expected:  entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, Until: &until},
+			formatted: "FREQ=MONTHLY;UNTIL=20260331T235959Z",
+		},
+		{
+			name:      "週の始まりが月曜日なら受け付ける",
+			value:     "FREQ=WEEKLY;WKST=MO",
+			expected:  entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1},
+			formatted: "FREQ=WEEKLY",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// テスト実行
+			rule, err := entity.ParseRecurrenceRule(tc.value)
+
+			// 結果の検証
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, rule)
+			formatted := tc.formatted
+			if formatted == "" {
+				formatted = tc.value
+			}
+			assert.Equal(t, formatted, rule.String())
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		value string
+	}{
+		{name: "空", value: ""},
+		{name: "毎日は扱わない", value: "FREQ=DAILY"},
+		{name: "FREQ がない", value: "BYDAY=FR"},
+		{name: "INTERVAL が 0", value: "FREQ=WEEKLY;INTERVAL=0"},
+		{name: "COUNT が 0", value: "FREQ=WEEKLY;COUNT=0"},
+		{name: "COUNT と UNTIL を同時に指定した", value: "FREQ=WEEKLY;COUNT=3;UNTIL=20260331"},
+		{name: "毎週で第 n 曜日を指定した", value: "FREQ=WEEKLY;BYDAY=2TU"},
+		{name: "第 6 曜日", value: "FREQ=MONTHLY;BYDAY=6TU"},
+		{name: "知らない曜日", value: "FREQ=WEEKLY;BYDAY=XX"},
+		{name: "月曜日以外の週の始まり", value: "FREQ=WEEKLY;WKST=SU"},
+		{name: "扱わない項目", value: "FREQ=MONTHLY;BYMONTHDAY=15"},
+		{name: "値がない", value: "FREQ=WEEKLY;COUNT="},
+	}
+
+	for _, tc := range invalid {
+		t.Run("異常系: "+tc.name, func(t *testing.T) {
+			_, err := entity.ParseRecurrenceRule(tc.value)
+
+			assert.ErrorIs(t, err, entity.ErrInvalidRecurrenceRule)
+		})
+	}
+}
+
+func TestExpandRecurrence(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	jst := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 19, 0, 0, 0, tokyo)
+	}
+	until := time.Date(2026, 1, 21, 23, 59, 59, 0, time.UTC)
+
+	testCases := []struct {
+		name              string
+		rule              entity.RecurrenceRule
+		dtstart           time.Time
+		from              time.Time
+		to                time.Time
+		expected          []time.Time
+		expectedExhausted bool
+	}{
+		{
+			name:              "毎週複数の曜日",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, Count: 5, ByDay: []entity.RecurrenceDay{{Weekday: time.Monday}, {Weekday: time.Wednesday}, {Weekday: time.Friday}}},
+			dtstart:           jst(time.January, 7),
+			expected:          []time.Time{jst(time.January, 7), jst(time.January, 9), jst(time.January, 12), jst(time.January, 14), jst(time.January, 16)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "開始日が BYDAY にない場合は次の該当日から数える",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, Count: 2, ByDay: []entity.RecurrenceDay{{Weekday: time.Friday}}},
+			dtstart:           jst(time.January, 7),
+			expected:          []time.Time{jst(time.January, 9), jst(time.January, 16)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "隔週",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 2, Count: 3},
+			dtstart:           jst(time.January, 7),
+			expected:          []time.Time{jst(time.January, 7), jst(time.January, 21), jst(time.February, 4)},
+			expectedExhausted: true,
+		},
+		{
+			name:    "曜日は UTC ではなく繰り返しのタイムゾーンで数える",
+			rule:    entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, Count: 2, ByDay: []entity.RecurrenceDay{{Weekday: time.Wednesday}}},
+			dtstart: time.Date(2026, 1, 7, 0, 30, 0, 0, tokyo).UTC(),
+			expected: []time.Time{
+				time.Date(2026, 1, 7, 0, 30, 0, 0, tokyo),
+				time.Date(2026, 1, 14, 0, 30, 0, 0, tokyo),
+			},
+			expectedExhausted: true,
+		},
+		{
+			name:              "毎月第 2 火曜日",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, Count: 3, ByDay: []entity.RecurrenceDay{{Ordinal: 2, Weekday: time.Tuesday}}},
+			dtstart:           jst(time.January, 13),
+			expected:          []time.Time{jst(time.January, 13), jst(time.February, 10), jst(time.March, 10)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "毎月最終金曜日",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, Count: 3, ByDay: []entity.RecurrenceDay{{Ordinal: -1, Weekday: time.Friday}}},
+			dtstart:           jst(time.January, 30),
+			expected:          []time.Time{jst(time.January, 30), jst(time.February, 27), jst(time.March, 27)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "第 5 月曜日がない月は飛ばす",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, Count: 2, ByDay: []entity.RecurrenceDay{{Ordinal: 5, Weekday: time.Monday}}},
+			dtstart:           jst(time.January, 1),
+			expected:          []time.Time{time.Date(2026, 3, 30, 19, 0, 0, 0, tokyo), time.Date(2026, 6, 29, 19, 0, 0, 0, tokyo)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "31 日がない月は飛ばす",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 1, Count: 3},
+			dtstart:           jst(time.January, 31),
+			expected:          []time.Time{jst(time.January, 31), jst(time.March, 31), jst(time.May, 31)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "隔月",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceMonthly, Interval: 2, Count: 3},
+			dtstart:           jst(time.January, 15),
+			expected:          []time.Time{jst(time.January, 15), jst(time.March, 15), jst(time.May, 15)},
+			expectedExhausted: true,
+		},
+		{
+			name:              "UNTIL の日を含めて終わる",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, Until: &until},
+			dtstart:           jst(time.January, 7),
+			expected:          []time.Time{jst(time.January, 7), jst(time.January, 14), jst(time.January, 21)},
+			expectedExhausted: true,
+		},
+		{
+			name:     "無期限のルールは期間の分だけ返す",
+			rule:     entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1},
+			dtstart:  jst(time.January, 7),
+			from:     jst(time.January, 14),
+			to:       jst(time.January, 28),
+			expected: []time.Time{jst(time.January, 14), jst(time.January, 21)},
+		},
+		{
+			name:              "期間より前の回も COUNT に数える",
+			rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, Count: 3},
+			dtstart:           jst(time.January, 7),
+			from:              jst(time.January, 14),
+			expected:          []time.Time{jst(time.January, 14), jst(time.January, 21)},
+			expectedExhausted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := tc.from, tc.to
+			if from.IsZero() {
+				from = tc.dtstart
+			}
+			if to.IsZero() {
+				to = tc.dtstart.AddDate(2, 0, 0)
+			}
+
+			// テスト実行
+			starts, exhausted := expandRecurrence(tc.rule, tc.dtstart, tokyo, from, to)
+
+			// 結果の検証
+			assert.Len(t, starts, len(tc.expected))
+			for i := range starts {
+				if i < len(tc.expected) {
+					assert.True(t, tc.expected[i].Equal(starts[i]), "%d: expected %s, got %s", i, tc.expected[i], starts[i])
+				}
+			}
+			assert.Equal(t, tc.expectedExhausted, exhausted)
+		})
+	}
+}
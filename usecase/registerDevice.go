@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"time"
+)
+
+type RegisterDeviceUseCase interface {
+	Execute() (*entity.Device, error)
+}
+
+type RegisterDeviceUseCaseImpl struct {
+	deviceRepo repository.DeviceRepository
+	userID     entity.UserID
+	deviceID   entity.DeviceID
+	token      entity.FCMToken
+	platform   entity.DevicePlatform
+}
+
+// NewRegisterDeviceUseCase は端末のプッシュ通知のトークンを登録する
+// アプリの起動時やトークンの更新時に呼ばれ、登録済みの端末はトークンと最終利用日時を更新する
+func NewRegisterDeviceUseCase(deviceRepo repository.DeviceRepository, userID entity.UserID, deviceID entity.DeviceID, token entity.FCMToken, platform entity.DevicePlatform) *RegisterDeviceUseCaseImpl {
+	return &RegisterDeviceUseCaseImpl{
+		deviceRepo: deviceRepo,
+		userID:     userID,
+		deviceID:   deviceID,
+		token:      token,
+		platform:   platform,
+	}
+}
+
+func (uc *RegisterDeviceUseCaseImpl) Execute() (*entity.Device, error) {
+	now := time.Now()
+	device, err := uc.deviceRepo.SaveDevice(entity.Device{
+		UserID:     uc.userID,
+		DeviceID:   uc.deviceID,
+		Token:      uc.token,
+		Platform:   uc.platform,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("端末の登録に失敗しました: %w", err)
+	}
+	return device, nil
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+)
+
+// テスト用のリポジトリ。使うメソッドだけを実装し、それ以外はインターフェースの nil に任せる
+
+type eventRepoStub struct {
+	repository.EventRepository
+	mu     sync.Mutex
+	events map[entity.EventID]*entity.Event
+}
+
+func newEventRepoStub(events ...*entity.Event) *eventRepoStub {
+	r := &eventRepoStub{events: map[entity.EventID]*entity.Event{}}
+	for _, event := range events {
+		r.events[event.EventID] = event
+	}
+	return r
+}
+
+func (r *eventRepoStub) FindEventByEventID(eventID entity.EventID) (*entity.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event, ok := r.events[eventID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *event
+	copied.VotedMembers = append([]entity.VotedMember{}, event.VotedMembers...)
+	return &copied, nil
+}
+
+func (r *eventRepoStub) UpdateVotedMember(eventID entity.EventID, member entity.VotedMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event := r.events[eventID]
+	for i := range event.VotedMembers {
+		if event.VotedMembers[i].UserID == member.UserID {
+			event.VotedMembers[i] = member
+		}
+	}
+	return nil
+}
+
+func (r *eventRepoStub) SetCheckinSecretIfMissing(eventID entity.EventID, secret string) (*entity.Event, error) {
+	r.mu.Lock()
+	event := r.events[eventID]
+	if event.CheckinSecret == "" {
+		event.CheckinSecret = secret
+	}
+	r.mu.Unlock()
+	return r.FindEventByEventID(eventID)
+}
+
+func (r *eventRepoStub) AssignSeason(eventIDs []entity.EventID, seasonID entity.SeasonID, from time.Time, to time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var modified int64
+	for _, eventID := range eventIDs {
+		event, ok := r.events[eventID]
+		if !ok || event.SeasonID != "" {
+			continue
+		}
+		if start := time.Time(event.EventStartDateTime); start.Before(from) || !start.Before(to) {
+			continue
+		}
+		event.SeasonID = seasonID
+		modified++
+	}
+	return modified, nil
+}
+
+func (r *eventRepoStub) FindEventsBySeriesID(seriesID entity.EventSeriesID, from time.Time) ([]entity.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := []entity.Event{}
+	for _, event := range r.events {
+		if event.SeriesID == seriesID && event.RecurrenceID != nil && !event.RecurrenceID.Before(from) {
+			events = append(events, *event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].RecurrenceID.Before(*events[j].RecurrenceID) })
+	return events, nil
+}
+
+func (r *eventRepoStub) UpdateEventFields(eventID entity.EventID, update entity.EventUpdate) (*entity.Event, error) {
+	r.mu.Lock()
+	event, ok := r.events[eventID]
+	if !ok {
+		r.mu.Unlock()
+		return nil, nil
+	}
+	if update.EventTitle != nil {
+		event.EventTitle = *update.EventTitle
+	}
+	if update.EventStartDateTime != nil {
+		event.EventStartDateTime = *update.EventStartDateTime
+	}
+	if update.EventEndDateTime != nil {
+		event.EventEndDateTime = *update.EventEndDateTime
+	}
+	if update.EventClosingDateTime != nil {
+		event.EventClosingDateTime = *update.EventClosingDateTime
+	}
+	if update.SeasonID != nil {
+		event.SeasonID = *update.SeasonID
+	}
+	if update.SeriesID != nil {
+		event.SeriesID = *update.SeriesID
+	}
+	if update.RecurrenceID != nil {
+		recurrenceID := *update.RecurrenceID
+		event.RecurrenceID = &recurrenceID
+	}
+	event.Version++
+	r.mu.Unlock()
+	return r.FindEventByEventID(eventID)
+}
+
+type groupRepoStub struct {
+	repository.GroupRepository
+	groups []*entity.Group
+}
+
+func (r *groupRepoStub) FindGroupByGroupID(groupID entity.GroupID) (*entity.Group, error) {
+	for _, group := range r.groups {
+		if group.GroupID == groupID {
+			return group, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *groupRepoStub) FindGroupByEventID(eventID entity.EventID) (*entity.Group, error) {
+	for _, group := range r.groups {
+		for _, id := range group.GroupEvents {
+			if id == eventID {
+				return group, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+type locationRepoStub struct {
+	repository.LocationRepository
+	mu        sync.Mutex
+	locations map[entity.UserID]entity.UserLocation
+}
+
+func newLocationRepoStub() *locationRepoStub {
+	return &locationRepoStub{locations: map[entity.UserID]entity.UserLocation{}}
+}
+
+func (r *locationRepoStub) FindLocationByUserID(userID entity.UserID) (*entity.UserLocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	location, ok := r.locations[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &location, nil
+}
+
+func (r *locationRepoStub) UpdateLocation(location entity.UserLocation) (*entity.UserLocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.locations[location.UserID] = location
+	return &location, nil
+}
+
+type historyRepoStub struct {
+	repository.LocationHistoryRepository
+	mu     sync.Mutex
+	points []entity.LocationTrailPoint
+}
+
+func (r *historyRepoStub) AppendLocation(eventID entity.EventID, location entity.UserLocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.points = append(r.points, entity.LocationTrailPoint{
+		EventID:    eventID,
+		UserID:     location.UserID,
+		Latitude:   location.Latitude,
+		Longitude:  location.Longitude,
+		RecordedAt: location.RecordedAt,
+	})
+	return nil
+}
+
+func (r *historyRepoStub) FindTrail(eventID entity.EventID, userID entity.UserID) ([]entity.LocationTrailPoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	trail := []entity.LocationTrailPoint{}
+	for _, point := range r.points {
+		if point.EventID == eventID && point.UserID == userID {
+			trail = append(trail, point)
+		}
+	}
+	return trail, nil
+}
+
+type userRepoStub struct {
+	repository.UserRepository
+	users map[entity.UserID]*entity.User
+}
+
+func (r *userRepoStub) FindUserByUserID(userID entity.UserID) (*entity.User, error) {
+	return r.users[userID], nil
+}
+
+type scoreRepoStub struct {
+	repository.ScoreRepository
+	scores []entity.Score
+}
+
+func (r *scoreRepoStub) FindScoresBySeasonID(seasonID entity.SeasonID) ([]entity.Score, error) {
+	scores := []entity.Score{}
+	for _, score := range r.scores {
+		if score.SeasonID == seasonID {
+			scores = append(scores, score)
+		}
+	}
+	return scores, nil
+}
+
+func (r *scoreRepoStub) AssignSeason(groupID entity.GroupID, seasonID entity.SeasonID, from time.Time, to time.Time) (int64, error) {
+	var modified int64
+	for i := range r.scores {
+		score := &r.scores[i]
+		if score.GroupID != groupID || score.SeasonID != "" || score.EventStartDateTime.Before(from) || !score.EventStartDateTime.Before(to) {
+			continue
+		}
+		score.SeasonID = seasonID
+		modified++
+	}
+	return modified, nil
+}
+
+type seasonRepoStub struct {
+	repository.SeasonRepository
+	seasons []*entity.Season
+}
+
+func (r *seasonRepoStub) CreateSeason(season entity.Season) (*entity.Season, error) {
+	season.SeasonID = entity.SeasonID("season-" + strconv.Itoa(len(r.seasons)+1))
+	r.seasons = append(r.seasons, &season)
+	created := season
+	return &created, nil
+}
+
+func (r *seasonRepoStub) FindSeasonBySeasonID(seasonID entity.SeasonID) (*entity.Season, error) {
+	for _, season := range r.seasons {
+		if season.SeasonID == seasonID {
+			found := *season
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *seasonRepoStub) FindSeasonsByGroupID(groupID entity.GroupID) ([]entity.Season, error) {
+	seasons := []entity.Season{}
+	for _, season := range r.seasons {
+		if season.GroupID == groupID {
+			seasons = append(seasons, *season)
+		}
+	}
+	return seasons, nil
+}
+
+func (r *seasonRepoStub) FindSeasonByTime(groupID entity.GroupID, t time.Time) (*entity.Season, error) {
+	for _, season := range r.seasons {
+		if season.GroupID == groupID && !t.Before(season.StartDateTime) && t.Before(season.EndDateTime) {
+			found := *season
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *seasonRepoStub) CloseSeason(seasonID entity.SeasonID, closedAt time.Time, endDateTime time.Time, standings []entity.SeasonStanding) error {
+	for _, season := range r.seasons {
+		if season.SeasonID == seasonID {
+			season.ClosedAt = &closedAt
+			season.EndDateTime = endDateTime
+			season.FinalStandings = standings
+		}
+	}
+	return nil
+}
+
+type seriesRepoStub struct {
+	repository.EventSeriesRepository
+	series map[entity.EventSeriesID]*entity.EventSeries
+}
+
+func newSeriesRepoStub(series ...*entity.EventSeries) *seriesRepoStub {
+	r := &seriesRepoStub{series: map[entity.EventSeriesID]*entity.EventSeries{}}
+	for _, s := range series {
+		r.series[s.SeriesID] = s
+	}
+	return r
+}
+
+func (r *seriesRepoStub) CreateSeries(series entity.EventSeries) (*entity.EventSeries, error) {
+	series.SeriesID = entity.EventSeriesID("series-" + strconv.Itoa(len(r.series)+1))
+	r.series[series.SeriesID] = &series
+	created := series
+	return &created, nil
+}
+
+func (r *seriesRepoStub) FindSeriesBySeriesID(seriesID entity.EventSeriesID) (*entity.EventSeries, error) {
+	series, ok := r.series[seriesID]
+	if !ok {
+		return nil, nil
+	}
+	found := *series
+	return &found, nil
+}
+
+func (r *seriesRepoStub) UpdateSeries(series entity.EventSeries) (*entity.EventSeries, error) {
+	r.series[series.SeriesID] = &series
+	updated := series
+	return &updated, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNotEventAuthor      = errors.New("not the author of this event")
+	ErrArrivalNotFound     = errors.New("arrival not found")
+	ErrInvalidReviewAction = errors.New("invalid review action")
+)
+
+type ArrivalReviewAction string
+
+const (
+	ArrivalReviewActionApprove ArrivalReviewAction = "approve"
+	ArrivalReviewActionReject  ArrivalReviewAction = "reject"
+)
+
+type ReviewArrivalUseCase interface {
+	Execute() (*entity.VotedMember, error)
+}
+
+type ReviewArrivalUseCaseImpl struct {
+	eventRepo       repository.EventRepository
+	groupRepo       repository.GroupRepository
+	userRepo        repository.UserRepository
+	cache           *LeaderboardCache
+	notifier        service.Notifier
+	reviewerID      entity.UserID
+	eventID         entity.EventID
+	userID          entity.UserID
+	action          ArrivalReviewAction
+	arrivalDateTime *time.Time
+}
+
+// NewReviewArrivalUseCase はイベント作成者による到着の承認・却下を行う
+// 承認時に arrivalDateTime を指定すると到着時刻を上書きできる
+// 確認待ちだった到着を承認した場合は、到着時と同じように他の参加者に通知する
+func NewReviewArrivalUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, userRepo repository.UserRepository, cache *LeaderboardCache, notifier service.Notifier, reviewerID entity.UserID, eventID entity.EventID, userID entity.UserID, action ArrivalReviewAction, arrivalDateTime *time.Time) *ReviewArrivalUseCaseImpl {
+	return &ReviewArrivalUseCaseImpl{
+		eventRepo:       eventRepo,
+		groupRepo:       groupRepo,
+		userRepo:        userRepo,
+		cache:           cache,
+		notifier:        notifier,
+		reviewerID:      reviewerID,
+		eventID:         eventID,
+		userID:          userID,
+		action:          action,
+		arrivalDateTime: arrivalDateTime,
+	}
+}
+
+func (uc *ReviewArrivalUseCaseImpl) Execute() (*entity.VotedMember, error) {
+	if uc.action != ArrivalReviewActionApprove && uc.action != ArrivalReviewActionReject {
+		return nil, ErrInvalidReviewAction
+	}
+
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if event.EventAuthorID != uc.reviewerID {
+		return nil, ErrNotEventAuthor
+	}
+
+	// 確定後のポイントや称号は到着をもとに計算済みのため、到着を変えさせない
+	if event.FinalizedAt != nil {
+		return nil, ErrEventAlreadyFinalized
+	}
+
+	var member *entity.VotedMember
+	for i := range event.VotedMembers {
+		if event.VotedMembers[i].UserID == uc.userID {
+			member = &event.VotedMembers[i]
+			break
+		}
+	}
+	if member == nil || (!member.IsArrival && member.ArrivalFlag == nil) {
+		return nil, ErrArrivalNotFound
+	}
+
+	wasConfirmed := member.HasConfirmedArrival()
+	now := time.Now()
+	if member.ArrivalFlag == nil {
+		member.ArrivalFlag = &entity.ArrivalFlag{FlaggedAt: now}
+	}
+	member.ArrivalFlag.ReviewedBy = uc.reviewerID
+	member.ArrivalFlag.ReviewedAt = &now
+
+	switch uc.action {
+	case ArrivalReviewActionApprove:
+		member.ArrivalFlag.Status = entity.ArrivalReviewApproved
+		member.IsArrival = true
+		if uc.arrivalDateTime != nil {
+			member.ArrivalDateTime = *uc.arrivalDateTime
+		}
+	case ArrivalReviewActionReject:
+		member.ArrivalFlag.Status = entity.ArrivalReviewRejected
+		member.IsArrival = false
+		member.ArrivalDateTime = time.Time{}
+	}
+
+	if err := uc.eventRepo.UpdateVotedMember(event.EventID, *member); err != nil {
+		return nil, fmt.Errorf("到着情報の更新に失敗しました: %w", err)
+	}
+	group, err := uc.groupRepo.FindGroupByEventID(event.EventID)
+	if err != nil || group == nil {
+		return member, nil
+	}
+	uc.cache.InvalidateGroup(group.GroupID)
+
+	if member.HasConfirmedArrival() && !wasConfirmed {
+		// 通知に使う名前が取れなくても承認は取り消さない
+		if user, err := uc.userRepo.FindUserByUserID(member.UserID); err == nil && user != nil {
+			notifyMemberArrived(uc.notifier, group.GroupID, event, user)
+		}
+	}
+
+	return member, nil
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewArrivalUseCase(t *testing.T) {
+	arrivedAt := time.Date(2026, 1, 1, 10, 3, 0, 0, time.UTC)
+
+	newEventRepo := func() *eventRepoStub {
+		return newEventRepoStub(&entity.Event{
+			EventID:       "event-id",
+			EventAuthorID: "author-id",
+			VotedMembers: []entity.VotedMember{{
+				UserID:          "arrival-user-id",
+				Vote:            entity.VoteAttend,
+				IsArrival:       true,
+				ArrivalDateTime: arrivedAt,
+				ArrivalFlag:     &entity.ArrivalFlag{Status: entity.ArrivalReviewPending, Reasons: []string{"理由"}, FlaggedAt: arrivedAt},
+			}, {
+				UserID: "member-id",
+				Vote:   entity.VoteAttend,
+			}},
+		})
+	}
+
+	groupRepo := &groupRepoStub{groups: []*entity.Group{{GroupID: "group-id", GroupEvents: entity.GroupEvents{"event-id"}}}}
+	userRepo := &userRepoStub{users: map[entity.UserID]*entity.User{"arrival-user-id": {UserID: "arrival-user-id", UserName: "到着"}}}
+	cache := NewLeaderboardCache(time.Hour)
+	notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+
+	t.Run("正常系: 承認すると到着が確定し、指定した到着時刻で上書きする", func(t *testing.T) {
+		eventRepo := newEventRepo()
+		override := arrivedAt.Add(-2 * time.Minute)
+
+		// テスト実行
+		member, err := NewReviewArrivalUseCase(eventRepo, groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", ArrivalReviewActionApprove, &override).Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ArrivalReviewApproved, member.ArrivalFlag.Status)
+		assert.Equal(t, entity.UserID("author-id"), member.ArrivalFlag.ReviewedBy)
+		saved := eventRepo.events["event-id"].VotedMembers[0]
+		assert.True(t, saved.HasConfirmedArrival())
+		assert.True(t, override.Equal(saved.ArrivalDateTime))
+	})
+
+	t.Run("正常系: 確認待ちの到着を承認すると他の参加者に通知し、承認済みの到着では通知しない", func(t *testing.T) {
+		eventRepo := newEventRepo()
+		notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+
+		// テスト実行
+		_, err := NewReviewArrivalUseCase(eventRepo, groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", ArrivalReviewActionApprove, nil).Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, notifier.sent["member-id"], 1)
+		assert.Equal(t, entity.NotificationMemberArrived, notifier.sent["member-id"][0].Kind)
+		assert.Equal(t, entity.GroupID("group-id"), notifier.sent["member-id"][0].GroupID)
+		assert.Empty(t, notifier.sent["arrival-user-id"])
+
+		// 到着時刻を直すために承認し直しても、もう一度は通知しない
+		_, err = NewReviewArrivalUseCase(eventRepo, groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", ArrivalReviewActionApprove, nil).Execute()
+		assert.NoError(t, err)
+		assert.Len(t, notifier.sent["member-id"], 1)
+	})
+
+	t.Run("正常系: 確認するとグループのリーダーボードを破棄する", func(t *testing.T) {
+		now := time.Now()
+		key := newLeaderboardCacheKey("group-id", LeaderboardMetricTotalLateMinutes, now.Add(-time.Hour), now)
+		cache.set(key, &GetGroupLeaderboardResponse{GroupID: "group-id"}, now)
+
+		_, err := NewReviewArrivalUseCase(newEventRepo(), groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", ArrivalReviewActionApprove, nil).Execute()
+
+		assert.NoError(t, err)
+		_, ok := cache.get(key, now)
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 却下すると到着を取り消し、通知しない", func(t *testing.T) {
+		eventRepo := newEventRepo()
+		notifier := &recordingNotifier{sent: map[entity.UserID][]entity.Notification{}}
+
+		member, err := NewReviewArrivalUseCase(eventRepo, groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", ArrivalReviewActionReject, nil).Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ArrivalReviewRejected, member.ArrivalFlag.Status)
+		saved := eventRepo.events["event-id"].VotedMembers[0]
+		assert.False(t, saved.IsArrival)
+		assert.True(t, saved.ArrivalDateTime.IsZero())
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("異常系: 確定済みのイベントの到着は変えられない", func(t *testing.T) {
+		eventRepo := newEventRepo()
+		finalizedAt := arrivedAt.Add(time.Hour)
+		eventRepo.events["event-id"].FinalizedAt = &finalizedAt
+
+		member, err := NewReviewArrivalUseCase(eventRepo, groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", ArrivalReviewActionReject, nil).Execute()
+
+		assert.ErrorIs(t, err, ErrEventAlreadyFinalized)
+		assert.Nil(t, member)
+		assert.True(t, eventRepo.events["event-id"].VotedMembers[0].IsArrival)
+	})
+
+	t.Run("異常系: 作成者でなければ確認できない", func(t *testing.T) {
+		_, err := NewReviewArrivalUseCase(newEventRepo(), groupRepo, userRepo, cache, notifier, "member-id", "event-id", "arrival-user-id", ArrivalReviewActionApprove, nil).Execute()
+
+		assert.ErrorIs(t, err, ErrNotEventAuthor)
+	})
+
+	t.Run("異常系: 知らない操作", func(t *testing.T) {
+		_, err := NewReviewArrivalUseCase(newEventRepo(), groupRepo, userRepo, cache, notifier, "author-id", "event-id", "arrival-user-id", "delete", nil).Execute()
+
+		assert.ErrorIs(t, err, ErrInvalidReviewAction)
+	})
+}
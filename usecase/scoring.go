@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"time"
+)
+
+// scoreEvent はランキングの結果とグループの設定から参加者ごとのポイントを計算する
+// 参加と投票していながら到着が確認できなかったメンバーは欠席として減点する
+func scoreEvent(event *entity.Event, groupID entity.GroupID, rule entity.ScoringRule, ranking []ArrivalRank, finalizedAt time.Time) []entity.Score {
+	eventStart := time.Time(event.EventStartDateTime)
+	scores := make([]entity.Score, 0, len(event.VotedMembers))
+
+	ranked := make(map[entity.UserID]bool, len(ranking))
+	for _, rank := range ranking {
+		ranked[rank.UserID] = true
+
+		score := entity.Score{
+			ScoreID:            entity.NewScoreID(event.EventID, rank.UserID),
+			GroupID:            groupID,
+			EventID:            event.EventID,
+			SeasonID:           event.SeasonID,
+			UserID:             rank.UserID,
+			Rank:               rank.Rank,
+			EventStartDateTime: eventStart,
+			FinalizedAt:        finalizedAt,
+		}
+
+		if rank.ArrivalTime <= 0 {
+			score.Outcome = entity.ScoreOutcomeOnTime
+			score.Points = entity.Points(rule.EarlyBonus)
+		} else {
+			score.Outcome = entity.ScoreOutcomeLate
+			score.LateMinutes = rank.ArrivalTime
+			score.Points = entity.Points(-rule.LatePenaltyPerMinute * rank.ArrivalTime)
+		}
+
+		scores = append(scores, score)
+	}
+
+	for _, member := range event.VotedMembers {
+		if member.Vote != entity.VoteAttend || member.HasConfirmedArrival() || ranked[member.UserID] {
+			continue
+		}
+
+		scores = append(scores, entity.Score{
+			ScoreID:            entity.NewScoreID(event.EventID, member.UserID),
+			GroupID:            groupID,
+			EventID:            event.EventID,
+			SeasonID:           event.SeasonID,
+			UserID:             member.UserID,
+			Outcome:            entity.ScoreOutcomeNoShow,
+			Points:             entity.Points(-rule.NoShowPenalty),
+			EventStartDateTime: eventStart,
+			FinalizedAt:        finalizedAt,
+		})
+	}
+
+	return scores
+}
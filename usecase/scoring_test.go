@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreEvent(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	finalizedAt := start.Add(3 * time.Hour)
+
+	testCases := []struct {
+		name            string
+		rule            entity.ScoringRule
+		member          entity.VotedMember
+		rank            *ArrivalRank
+		expectedScored  bool
+		expectedOutcome entity.ScoreOutcome
+		expectedLate    int
+		expectedPoints  entity.Points
+	}{
+		{
+			name:            "開始より前に到着した",
+			rule:            entity.DefaultScoringRule(),
+			member:          entity.VotedMember{UserID: "user-id", Vote: entity.VoteAttend, IsArrival: true},
+			rank:            &ArrivalRank{Rank: 1, UserID: "user-id", ArrivalTime: -5},
+			expectedScored:  true,
+			expectedOutcome: entity.ScoreOutcomeOnTime,
+			expectedPoints:  10,
+		},
+		{
+			name:            "開始ちょうどは時間通り",
+			rule:            entity.DefaultScoringRule(),
+			member:          entity.VotedMember{UserID: "user-id", Vote: entity.VoteAttend, IsArrival: true},
+			rank:            &ArrivalRank{Rank: 1, UserID: "user-id", ArrivalTime: 0},
+			expectedScored:  true,
+			expectedOutcome: entity.ScoreOutcomeOnTime,
+			expectedPoints:  10,
+		},
+		{
+			name:            "1 分の遅刻",
+			rule:            entity.DefaultScoringRule(),
+			member:          entity.VotedMember{UserID: "user-id", Vote: entity.VoteAttend, IsArrival: true},
+			rank:            &ArrivalRank{Rank: 2, UserID: "user-id", ArrivalTime: 1},
+			expectedScored:  true,
+			expectedOutcome: entity.ScoreOutcomeLate,
+			expectedLate:    1,
+			expectedPoints:  -1,
+		},
+		{
+			name:            "グループの設定で 1 分あたりの減点を変えた遅刻",
+			rule:            entity.ScoringRule{EarlyBonus: 5, LatePenaltyPerMinute: 2, NoShowPenalty: 50},
+			member:          entity.VotedMember{UserID: "user-id", Vote: entity.VoteAttend, IsArrival: true},
+			rank:            &ArrivalRank{Rank: 3, UserID: "user-id", ArrivalTime: 15},
+			expectedScored:  true,
+			expectedOutcome: entity.ScoreOutcomeLate,
+			expectedLate:    15,
+			expectedPoints:  -30,
+		},
+		{
+			name:            "参加と投票して来なかった",
+			rule:            entity.DefaultScoringRule(),
+			member:          entity.VotedMember{UserID: "user-id", Vote: entity.VoteAttend},
+			expectedScored:  true,
+			expectedOutcome: entity.ScoreOutcomeNoShow,
+			expectedPoints:  -30,
+		},
+		{
+			name:            "到着が確認待ちのまま確定した",
+			rule:            entity.DefaultScoringRule(),
+			member:          entity.VotedMember{UserID: "user-id", Vote: entity.VoteAttend, IsArrival: true, ArrivalFlag: &entity.ArrivalFlag{Status: entity.ArrivalReviewPending}},
+			expectedScored:  true,
+			expectedOutcome: entity.ScoreOutcomeNoShow,
+			expectedPoints:  -30,
+		},
+		{
+			name:           "不参加と投票した",
+			rule:           entity.DefaultScoringRule(),
+			member:         entity.VotedMember{UserID: "user-id", Vote: "不参加"},
+			expectedScored: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := &entity.Event{
+				EventID:            "event-id",
+				SeasonID:           "season-id",
+				EventStartDateTime: entity.StartDateTIme(start),
+				VotedMembers:       []entity.VotedMember{tc.member},
+			}
+			ranking := []ArrivalRank{}
+			if tc.rank != nil {
+				ranking = append(ranking, *tc.rank)
+			}
+
+			// テスト実行
+			scores := scoreEvent(event, "group-id", tc.rule, ranking, finalizedAt)
+
+			// 結果の検証
+			if !tc.expectedScored {
+				assert.Empty(t, scores)
+				return
+			}
+			assert.Len(t, scores, 1)
+			score := scores[0]
+			assert.Equal(t, entity.NewScoreID("event-id", "user-id"), score.ScoreID)
+			assert.Equal(t, entity.GroupID("group-id"), score.GroupID)
+			assert.Equal(t, entity.SeasonID("season-id"), score.SeasonID)
+			assert.Equal(t, tc.expectedOutcome, score.Outcome)
+			assert.Equal(t, tc.expectedLate, score.LateMinutes)
+			assert.Equal(t, tc.expectedPoints, score.Points)
+			assert.True(t, start.Equal(score.EventStartDateTime))
+			assert.True(t, finalizedAt.Equal(score.FinalizedAt))
+			if tc.rank != nil {
+				assert.Equal(t, tc.rank.Rank, score.Rank)
+			}
+		})
+	}
+}
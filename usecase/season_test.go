@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSeasonUseCase(t *testing.T) {
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+
+	newFixture := func() (*groupRepoStub, *eventRepoStub, *scoreRepoStub, *seasonRepoStub) {
+		inSeason := &entity.Event{EventID: "in-season-event-id", EventStartDateTime: entity.StartDateTIme(start.AddDate(0, 0, 7))}
+		beforeSeason := &entity.Event{EventID: "before-season-event-id", EventStartDateTime: entity.StartDateTIme(start.AddDate(0, 0, -7))}
+		group := &entity.Group{
+			GroupID:        "group-id",
+			GroupManagerID: "manager-id",
+			GroupMembers:   entity.GroupMembers{"manager-id", "member-id"},
+			GroupEvents:    entity.GroupEvents{inSeason.EventID, beforeSeason.EventID},
+		}
+		scoreRepo := &scoreRepoStub{scores: []entity.Score{
+			{ScoreID: entity.NewScoreID(inSeason.EventID, "member-id"), GroupID: "group-id", EventID: inSeason.EventID, UserID: "member-id", Outcome: entity.ScoreOutcomeOnTime, Points: 10, EventStartDateTime: time.Time(inSeason.EventStartDateTime)},
+			{ScoreID: entity.NewScoreID(beforeSeason.EventID, "member-id"), GroupID: "group-id", EventID: beforeSeason.EventID, UserID: "member-id", Outcome: entity.ScoreOutcomeOnTime, Points: 10, EventStartDateTime: time.Time(beforeSeason.EventStartDateTime)},
+		}}
+		return &groupRepoStub{groups: []*entity.Group{group}}, newEventRepoStub(inSeason, beforeSeason), scoreRepo, &seasonRepoStub{}
+	}
+
+	t.Run("正常系: 期間内に確定済みのスコアもシーズンに割り当て、締めた順位表に含める", func(t *testing.T) {
+		groupRepo, eventRepo, scoreRepo, seasonRepo := newFixture()
+
+		// テスト実行
+		season, err := NewCreateSeasonUseCase(groupRepo, seasonRepo, eventRepo, scoreRepo, "manager-id", "group-id", "春", start, end).Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, season.SeasonID, eventRepo.events["in-season-event-id"].SeasonID)
+		assert.Empty(t, eventRepo.events["before-season-event-id"].SeasonID)
+		assert.Equal(t, season.SeasonID, scoreRepo.scores[0].SeasonID)
+		assert.Empty(t, scoreRepo.scores[1].SeasonID)
+
+		userRepo := &userRepoStub{users: map[entity.UserID]*entity.User{"member-id": {UserID: "member-id", UserName: "メンバー"}}}
+		closed, err := NewCloseSeasonUseCase(groupRepo, userRepo, seasonRepo, scoreRepo, "manager-id", "group-id", season.SeasonID).Execute()
+		assert.NoError(t, err)
+		assert.Len(t, closed.FinalStandings, 1)
+		assert.Equal(t, entity.UserID("member-id"), closed.FinalStandings[0].UserID)
+		assert.Equal(t, 1, closed.FinalStandings[0].Events)
+	})
+
+	t.Run("異常系: 既存のシーズンと期間が重なる", func(t *testing.T) {
+		groupRepo, eventRepo, scoreRepo, seasonRepo := newFixture()
+		_, err := NewCreateSeasonUseCase(groupRepo, seasonRepo, eventRepo, scoreRepo, "manager-id", "group-id", "春", start, end).Execute()
+		assert.NoError(t, err)
+
+		_, err = NewCreateSeasonUseCase(groupRepo, seasonRepo, eventRepo, scoreRepo, "manager-id", "group-id", "夏", end.AddDate(0, 0, -1), end.AddDate(0, 3, 0)).Execute()
+
+		assert.ErrorIs(t, err, ErrSeasonOverlap)
+	})
+
+	t.Run("異常系: 管理者以外は作成できない", func(t *testing.T) {
+		groupRepo, eventRepo, scoreRepo, seasonRepo := newFixture()
+
+		_, err := NewCreateSeasonUseCase(groupRepo, seasonRepo, eventRepo, scoreRepo, "member-id", "group-id", "春", start, end).Execute()
+
+		assert.ErrorIs(t, err, ErrNotGroupManager)
+		assert.Empty(t, scoreRepo.scores[0].SeasonID)
+	})
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"sort"
+	"time"
+)
+
+type SettlementShare struct {
+	UserID      entity.UserID   `json:"user_id"`
+	Name        entity.UserName `json:"name"`
+	Alias       entity.Alias    `json:"alias"`
+	LateMinutes int             `json:"late_minutes"`
+	// 参加と投票したが到着が確認できていない。終了時刻まで遅刻したものとして扱う
+	NoShow bool `json:"no_show"`
+	// 費用を人数で割った額
+	BaseAmount entity.Cost `json:"base_amount"`
+	// 遅刻した参加者は上乗せ額（正）、時間通りに着いた参加者は上乗せ分からの割引額（負）
+	Adjustment entity.Cost `json:"adjustment"`
+	Amount     entity.Cost `json:"amount"`
+	Paid       bool        `json:"paid"`
+	PaidAt     *time.Time  `json:"paid_at,omitempty"`
+}
+
+type EventSettlement struct {
+	EventID entity.EventID `json:"event_id"`
+	Cost    entity.Cost    `json:"cost"`
+	// 費用を立て替えたイベントの作成者。参加者はこのユーザーに支払う
+	PayeeID       entity.UserID         `json:"payee_id"`
+	SurchargeRule *entity.SurchargeRule `json:"surcharge_rule,omitempty"`
+	// イベントが終わるまでは到着に応じて金額が変わる
+	Provisional bool              `json:"provisional"`
+	Shares      []SettlementShare `json:"shares"`
+	Collected   entity.Cost       `json:"collected"`
+	Outstanding entity.Cost       `json:"outstanding"`
+}
+
+// settleEvent はイベントの費用を参加と投票したメンバー全員で分担する
+// 到着が確認できていないメンバーは終了時刻（終了前は現在時刻）まで遅刻したものとして、最大の上乗せを負担する
+// 端数の 1 円は遅く着いた参加者から順に負担し、遅刻の上乗せ分は時間通りに着いた参加者で均等に差し引く
+// 上乗せの合計は時間通りに着いた参加者の負担額を超えないよう比例して減らす
+// 作成者は立て替えた本人のため、自分の分は支払い済みとして扱う
+func settleEvent(event *entity.Event, now time.Time) *EventSettlement {
+	settlement := &EventSettlement{
+		EventID:       event.EventID,
+		Cost:          event.Cost,
+		PayeeID:       event.EventAuthorID,
+		SurchargeRule: event.SurchargeRule,
+		Provisional:   now.Before(time.Time(event.EventEndDateTime)),
+		Shares:        []SettlementShare{},
+	}
+
+	cutoff := time.Time(event.EventEndDateTime)
+	if now.Before(cutoff) {
+		cutoff = now
+	}
+
+	var participants []settlementParticipant
+	for _, member := range event.VotedMembers {
+		if member.Vote != entity.VoteAttend {
+			continue
+		}
+		if member.HasConfirmedArrival() {
+			participants = append(participants, settlementParticipant{userID: member.UserID, arrivedAt: member.ArrivalDateTime})
+		} else {
+			participants = append(participants, settlementParticipant{userID: member.UserID, arrivedAt: cutoff, noShow: true})
+		}
+	}
+	if len(participants) == 0 {
+		return settlement
+	}
+
+	sort.SliceStable(participants, func(i, j int) bool {
+		if !participants[i].arrivedAt.Equal(participants[j].arrivedAt) {
+			return participants[i].arrivedAt.Before(participants[j].arrivedAt)
+		}
+		return participants[i].userID < participants[j].userID
+	})
+
+	cost := event.Cost
+	if cost < 0 {
+		cost = 0
+	}
+
+	n := len(participants)
+	eventStart := time.Time(event.EventStartDateTime)
+	shares := make([]SettlementShare, n)
+	var onTime []int
+	var onTimeTotal, surchargeTotal entity.Cost
+	for i, participant := range participants {
+		share := SettlementShare{
+			UserID:     participant.userID,
+			NoShow:     participant.noShow,
+			BaseAmount: cost / entity.Cost(n),
+		}
+		if i >= n-int(cost%entity.Cost(n)) {
+			share.BaseAmount++
+		}
+
+		// 到着ランキングと同じく分単位で切り捨てて遅刻を判定する
+		if lateMinutes := int(participant.arrivedAt.Sub(eventStart).Minutes()); lateMinutes > 0 {
+			share.LateMinutes = lateMinutes
+			if event.SurchargeRule != nil {
+				share.Adjustment = event.SurchargeRule.Surcharge(lateMinutes)
+			}
+			surchargeTotal += share.Adjustment
+		} else {
+			onTime = append(onTime, i)
+			onTimeTotal += share.BaseAmount
+		}
+		shares[i] = share
+	}
+
+	// 差し引く相手がいない、または差し引ける額を超える場合は上乗せを減らす
+	if surchargeTotal > onTimeTotal {
+		scaled := entity.Cost(0)
+		for i := range shares {
+			if shares[i].LateMinutes > 0 {
+				shares[i].Adjustment = shares[i].Adjustment * onTimeTotal / surchargeTotal
+				scaled += shares[i].Adjustment
+			}
+		}
+		surchargeTotal = scaled
+	}
+
+	// 端数は負担額の大きい、遅く着いた参加者から順に多く差し引く
+	if m := entity.Cost(len(onTime)); m > 0 {
+		for k, i := range onTime {
+			discount := surchargeTotal / m
+			if k >= len(onTime)-int(surchargeTotal%m) {
+				discount++
+			}
+			shares[i].Adjustment = -discount
+		}
+	}
+
+	for i := range shares {
+		share := &shares[i]
+		share.Amount = share.BaseAmount + share.Adjustment
+
+		if share.UserID == event.EventAuthorID {
+			share.Paid = true
+			continue
+		}
+		if payment, ok := event.Payments[share.UserID]; ok {
+			paidAt := payment.PaidAt
+			share.Paid = true
+			share.PaidAt = &paidAt
+			settlement.Collected += share.Amount
+		} else {
+			settlement.Outstanding += share.Amount
+		}
+	}
+
+	settlement.Shares = shares
+	return settlement
+}
+
+type settlementParticipant struct {
+	userID    entity.UserID
+	arrivedAt time.Time
+	noShow    bool
+}
+
+// findShare は精算の中から参加者の分担を探す
+func (s *EventSettlement) findShare(userID entity.UserID) *SettlementShare {
+	for i := range s.Shares {
+		if s.Shares[i].UserID == userID {
+			return &s.Shares[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettleEvent(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := &entity.Event{
+		EventID:            "settlement-event-id",
+		Cost:               3000,
+		EventAuthorID:      "on-time-user",
+		EventStartDateTime: entity.StartDateTIme(start),
+		EventEndDateTime:   entity.EndDateTime(start.Add(2 * time.Hour)),
+		SurchargeRule:      &entity.SurchargeRule{PerMinute: 10, Max: 500},
+		VotedMembers: []entity.VotedMember{
+			{UserID: "on-time-user", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: start.Add(-5 * time.Minute)},
+			{UserID: "late-user", Vote: entity.VoteAttend, IsArrival: true, ArrivalDateTime: start.Add(20 * time.Minute)},
+			{UserID: "no-show-user", Vote: entity.VoteAttend},
+			{UserID: "not-attending-user", Vote: "不参加"},
+		},
+	}
+
+	t.Run("正常系: 到着していない参加者も分担し、最大の上乗せを負担する", func(t *testing.T) {
+		// テスト実行
+		settlement := settleEvent(event, start.Add(3*time.Hour))
+
+		// 結果の検証
+		assert.False(t, settlement.Provisional)
+		assert.Len(t, settlement.Shares, 3)
+
+		onTime := settlement.findShare("on-time-user")
+		late := settlement.findShare("late-user")
+		noShow := settlement.findShare("no-show-user")
+		assert.Nil(t, settlement.findShare("not-attending-user"))
+
+		for _, share := range []*SettlementShare{onTime, late, noShow} {
+			assert.Equal(t, entity.Cost(1000), share.BaseAmount)
+		}
+		assert.False(t, late.NoShow)
+		assert.Equal(t, entity.Cost(200), late.Adjustment)
+		assert.True(t, noShow.NoShow)
+		assert.Equal(t, 120, noShow.LateMinutes)
+		assert.Equal(t, entity.Cost(500), noShow.Adjustment)
+		assert.Equal(t, entity.Cost(-700), onTime.Adjustment)
+
+		var total entity.Cost
+		for _, share := range settlement.Shares {
+			total += share.Amount
+		}
+		assert.Equal(t, event.Cost, total)
+		assert.Equal(t, late.Amount+noShow.Amount, settlement.Outstanding)
+	})
+
+	t.Run("正常系: 終了前は現在時刻まで遅刻したものとして仮の金額を出す", func(t *testing.T) {
+		// テスト実行
+		settlement := settleEvent(event, start.Add(30*time.Minute))
+
+		// 結果の検証
+		assert.True(t, settlement.Provisional)
+		noShow := settlement.findShare("no-show-user")
+		assert.True(t, noShow.NoShow)
+		assert.Equal(t, 30, noShow.LateMinutes)
+		assert.Equal(t, entity.Cost(300), noShow.Adjustment)
+	})
+}
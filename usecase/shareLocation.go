@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrLocationSharingClosed = errors.New("location sharing has ended for this event")
+	ErrLocationThrottled     = errors.New("location update throttled")
+	ErrInvalidLocation       = errors.New("invalid location")
+	ErrLocationSharingOff    = errors.New("location sharing is turned off for this group")
+)
+
+// 位置履歴はイベント開始前の移動から記録する
+const locationTrailLeadTime = 3 * time.Hour
+
+// LocationThrottle は送信者ごとに位置情報の配信間隔を制限する
+// 同じユーザーが複数接続しても制限が効くよう、イベントとユーザーの組で管理する
+type LocationThrottle struct {
+	interval time.Duration
+	mu       sync.Mutex
+	lastSent map[locationThrottleKey]time.Time
+}
+
+type locationThrottleKey struct {
+	eventID entity.EventID
+	userID  entity.UserID
+}
+
+func NewLocationThrottle(interval time.Duration) *LocationThrottle {
+	return &LocationThrottle{
+		interval: interval,
+		lastSent: make(map[locationThrottleKey]time.Time),
+	}
+}
+
+func (lt *LocationThrottle) Allow(eventID entity.EventID, userID entity.UserID, now time.Time) bool {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	key := locationThrottleKey{eventID: eventID, userID: userID}
+	if last, ok := lt.lastSent[key]; ok && now.Sub(last) < lt.interval {
+		return false
+	}
+	lt.lastSent[key] = now
+
+	// 古いエントリが溜まり続けないよう、間隔を過ぎたものを掃除する
+	if len(lt.lastSent) > 1024 {
+		for k, t := range lt.lastSent {
+			if now.Sub(t) >= lt.interval {
+				delete(lt.lastSent, k)
+			}
+		}
+	}
+
+	return true
+}
+
+// LocationSharingSession は 1 接続分の位置共有セッション
+// 共有を停止しているグループでは他の参加者の位置を受け取るだけで、自分の位置は送信・記録しない
+type LocationSharingSession struct {
+	eventID        entity.EventID
+	userID         entity.UserID
+	sharingEnabled bool
+	recordFrom     time.Time
+	endsAt         time.Time
+	locationRepo   repository.LocationRepository
+	historyRepo    repository.LocationHistoryRepository
+	hub            service.LocationHub
+	throttle       *LocationThrottle
+	subscription   service.LocationSubscription
+}
+
+func (s *LocationSharingSession) Updates() <-chan entity.UserLocation {
+	return s.subscription.Updates()
+}
+
+func (s *LocationSharingSession) EndsAt() time.Time {
+	return s.endsAt
+}
+
+func (s *LocationSharingSession) Publish(latitude entity.Latitude, longitude entity.Longitude) error {
+	now := time.Now()
+	if !now.Before(s.endsAt) {
+		return ErrLocationSharingClosed
+	}
+
+	if !s.sharingEnabled {
+		return ErrLocationSharingOff
+	}
+
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return ErrInvalidLocation
+	}
+
+	if !s.throttle.Allow(s.eventID, s.userID, now) {
+		return ErrLocationThrottled
+	}
+
+	location := entity.UserLocation{
+		UserID:     s.userID,
+		Latitude:   latitude,
+		Longitude:  longitude,
+		RecordedAt: now,
+	}
+
+	// 到着予測で使うため最新位置として保存してから配信する
+	if _, err := s.locationRepo.UpdateLocation(location); err != nil {
+		return fmt.Errorf("位置情報の保存に失敗しました: %w", err)
+	}
+
+	if !now.Before(s.recordFrom) {
+		if err := s.historyRepo.AppendLocation(s.eventID, location); err != nil {
+			return fmt.Errorf("位置履歴の保存に失敗しました: %w", err)
+		}
+	}
+
+	return s.hub.Publish(s.eventID, location)
+}
+
+func (s *LocationSharingSession) Close() {
+	s.subscription.Close()
+}
+
+type JoinLocationSharingUseCase interface {
+	Execute() (*LocationSharingSession, error)
+}
+
+type JoinLocationSharingUseCaseImpl struct {
+	eventRepo    repository.EventRepository
+	groupRepo    repository.GroupRepository
+	locationRepo repository.LocationRepository
+	historyRepo  repository.LocationHistoryRepository
+	hub          service.LocationHub
+	throttle     *LocationThrottle
+	user         *entity.User
+	eventID      entity.EventID
+}
+
+func NewJoinLocationSharingUseCase(eventRepo repository.EventRepository, groupRepo repository.GroupRepository, locationRepo repository.LocationRepository, historyRepo repository.LocationHistoryRepository, hub service.LocationHub, throttle *LocationThrottle, user *entity.User, eventID entity.EventID) *JoinLocationSharingUseCaseImpl {
+	return &JoinLocationSharingUseCaseImpl{
+		eventRepo:    eventRepo,
+		groupRepo:    groupRepo,
+		locationRepo: locationRepo,
+		historyRepo:  historyRepo,
+		hub:          hub,
+		throttle:     throttle,
+		user:         user,
+		eventID:      eventID,
+	}
+}
+
+func (uc *JoinLocationSharingUseCaseImpl) Execute() (*LocationSharingSession, error) {
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	// イベント終了後は共有を開始させない
+	endsAt := time.Time(event.EventEndDateTime)
+	if !time.Now().Before(endsAt) {
+		return nil, ErrLocationSharingClosed
+	}
+
+	group, err := findEventGroup(uc.groupRepo, event.EventID, uc.user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.hub.Subscribe(event.EventID, uc.user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocationSharingSession{
+		eventID:        event.EventID,
+		userID:         uc.user.UserID,
+		sharingEnabled: uc.user.SharesLocationWith(group.GroupID),
+		recordFrom:     time.Time(event.EventStartDateTime).Add(-locationTrailLeadTime),
+		endsAt:         endsAt,
+		locationRepo:   uc.locationRepo,
+		historyRepo:    uc.historyRepo,
+		hub:            uc.hub,
+		throttle:       uc.throttle,
+		subscription:   subscription,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/service"
+	"chikokulympic-api/infrastructure/realtime"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationThrottle(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	throttle := NewLocationThrottle(2 * time.Second)
+
+	assert.True(t, throttle.Allow("event-id", "user-id", base))
+	// 間隔の中の更新は捨てる
+	assert.False(t, throttle.Allow("event-id", "user-id", base.Add(time.Second)))
+	assert.False(t, throttle.Allow("event-id", "user-id", base.Add(2*time.Second-time.Nanosecond)))
+	// 他のユーザーや他のイベントには影響しない
+	assert.True(t, throttle.Allow("event-id", "other-user-id", base.Add(time.Second)))
+	assert.True(t, throttle.Allow("other-event-id", "user-id", base.Add(time.Second)))
+	// 間隔ちょうどで再び送れる。捨てた更新は間隔を延ばさない
+	assert.True(t, throttle.Allow("event-id", "user-id", base.Add(2*time.Second)))
+}
+
+func TestJoinLocationSharingUseCase(t *testing.T) {
+	newFixture := func(start, end time.Time) (*eventRepoStub, *groupRepoStub) {
+		event := &entity.Event{
+			EventID:            "event-id",
+			EventStartDateTime: entity.StartDateTIme(start),
+			EventEndDateTime:   entity.EndDateTime(end),
+		}
+		group := &entity.Group{
+			GroupID:      "group-id",
+			GroupMembers: entity.GroupMembers{"sender-id", "receiver-id"},
+			GroupEvents:  entity.GroupEvents{"event-id"},
+		}
+		return newEventRepoStub(event), &groupRepoStub{groups: []*entity.Group{group}}
+	}
+
+	join := func(eventRepo *eventRepoStub, groupRepo *groupRepoStub, locationRepo *locationRepoStub, historyRepo *historyRepoStub, hub service.LocationHub, throttle *LocationThrottle, user *entity.User) (*LocationSharingSession, error) {
+		return NewJoinLocationSharingUseCase(eventRepo, groupRepo, locationRepo, historyRepo, hub, throttle, user, "event-id").Execute()
+	}
+
+	t.Run("正常系: メンバーの位置は他の参加者に配信され、最新位置と履歴に保存される", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(time.Hour), now.Add(2*time.Hour))
+		locationRepo, historyRepo := newLocationRepoStub(), &historyRepoStub{}
+		hub := realtime.NewInMemoryLocationHub()
+		throttle := NewLocationThrottle(time.Minute)
+
+		sender, err := join(eventRepo, groupRepo, locationRepo, historyRepo, hub, throttle, &entity.User{UserID: "sender-id"})
+		assert.NoError(t, err)
+		defer sender.Close()
+		receiver, err := join(eventRepo, groupRepo, locationRepo, historyRepo, hub, throttle, &entity.User{UserID: "receiver-id"})
+		assert.NoError(t, err)
+		defer receiver.Close()
+
+		// テスト実行
+		err = sender.Publish(35.6, 139.7)
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Latitude(35.6), (<-receiver.Updates()).Latitude)
+		assert.Empty(t, sender.Updates())
+		saved, _ := locationRepo.FindLocationByUserID("sender-id")
+		assert.NotNil(t, saved)
+		assert.Len(t, historyRepo.points, 1)
+	})
+
+	t.Run("正常系: 開始より前の移動は履歴に残さない", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(locationTrailLeadTime+time.Hour), now.Add(locationTrailLeadTime+2*time.Hour))
+		locationRepo, historyRepo := newLocationRepoStub(), &historyRepoStub{}
+
+		session, err := join(eventRepo, groupRepo, locationRepo, historyRepo, realtime.NewInMemoryLocationHub(), NewLocationThrottle(time.Minute), &entity.User{UserID: "sender-id"})
+		assert.NoError(t, err)
+		defer session.Close()
+
+		assert.NoError(t, session.Publish(35.6, 139.7))
+		assert.Empty(t, historyRepo.points)
+	})
+
+	t.Run("正常系: 間隔の中の更新は配信しない", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(time.Hour), now.Add(2*time.Hour))
+		hub := realtime.NewInMemoryLocationHub()
+		throttle := NewLocationThrottle(time.Minute)
+
+		sender, err := join(eventRepo, groupRepo, newLocationRepoStub(), &historyRepoStub{}, hub, throttle, &entity.User{UserID: "sender-id"})
+		assert.NoError(t, err)
+		defer sender.Close()
+		receiver, err := join(eventRepo, groupRepo, newLocationRepoStub(), &historyRepoStub{}, hub, throttle, &entity.User{UserID: "receiver-id"})
+		assert.NoError(t, err)
+		defer receiver.Close()
+
+		// テスト実行
+		assert.NoError(t, sender.Publish(35.6, 139.7))
+		err = sender.Publish(35.7, 139.8)
+
+		// 結果の検証
+		assert.ErrorIs(t, err, ErrLocationThrottled)
+		assert.Len(t, receiver.Updates(), 1)
+	})
+
+	t.Run("正常系: 共有を停止したユーザーは受け取れるが、送信も記録もしない", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(time.Hour), now.Add(2*time.Hour))
+		locationRepo, historyRepo := newLocationRepoStub(), &historyRepoStub{}
+		hub := realtime.NewInMemoryLocationHub()
+		throttle := NewLocationThrottle(time.Minute)
+
+		optedOut, err := join(eventRepo, groupRepo, locationRepo, historyRepo, hub, throttle, &entity.User{UserID: "sender-id", LocationOptOutGroups: []entity.GroupID{"group-id"}})
+		assert.NoError(t, err)
+		defer optedOut.Close()
+		receiver, err := join(eventRepo, groupRepo, locationRepo, historyRepo, hub, throttle, &entity.User{UserID: "receiver-id"})
+		assert.NoError(t, err)
+		defer receiver.Close()
+
+		// テスト実行
+		err = optedOut.Publish(35.6, 139.7)
+
+		// 結果の検証
+		assert.ErrorIs(t, err, ErrLocationSharingOff)
+		assert.Empty(t, receiver.Updates())
+		assert.Empty(t, locationRepo.locations)
+		assert.Empty(t, historyRepo.points)
+
+		// 他の参加者の位置は受け取れる
+		assert.NoError(t, receiver.Publish(35.6, 139.7))
+		assert.Len(t, optedOut.Updates(), 1)
+	})
+
+	t.Run("異常系: グループのメンバーでなければ参加できない", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(time.Hour), now.Add(2*time.Hour))
+
+		session, err := join(eventRepo, groupRepo, newLocationRepoStub(), &historyRepoStub{}, realtime.NewInMemoryLocationHub(), NewLocationThrottle(time.Minute), &entity.User{UserID: "outsider-id"})
+
+		assert.ErrorIs(t, err, ErrNotGroupMember)
+		assert.Nil(t, session)
+	})
+
+	t.Run("異常系: 終了したイベントには参加できない", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+		session, err := join(eventRepo, groupRepo, newLocationRepoStub(), &historyRepoStub{}, realtime.NewInMemoryLocationHub(), NewLocationThrottle(time.Minute), &entity.User{UserID: "sender-id"})
+
+		assert.ErrorIs(t, err, ErrLocationSharingClosed)
+		assert.Nil(t, session)
+	})
+
+	t.Run("異常系: 範囲外の座標は送信しない", func(t *testing.T) {
+		now := time.Now()
+		eventRepo, groupRepo := newFixture(now.Add(time.Hour), now.Add(2*time.Hour))
+
+		session, err := join(eventRepo, groupRepo, newLocationRepoStub(), &historyRepoStub{}, realtime.NewInMemoryLocationHub(), NewLocationThrottle(time.Minute), &entity.User{UserID: "sender-id"})
+		assert.NoError(t, err)
+		defer session.Close()
+
+		assert.ErrorIs(t, session.Publish(91, 139.7), ErrInvalidLocation)
+		assert.ErrorIs(t, session.Publish(35.6, -181), ErrInvalidLocation)
+	})
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import "chikokulympic-api/domain/entity"
+
+// TitleStats は称号の判定に使うグループ内でのユーザーの成績
+type TitleStats struct {
+	UserID           entity.UserID
+	Events           int
+	TotalLateMinutes int
+	LateCount        int
+	NoShowCount      int
+	// 直近から数えて連続で時間通りに到着したイベント数
+	OnTimeStreak int
+}
+
+// TitleRule は称号を与える条件。Metric の値が Threshold 以上のメンバーが称号を得る
+// Exclusive な称号は条件を満たすメンバーのうち、値が最も大きいメンバーだけが得る
+type TitleRule struct {
+	Name        entity.Alias
+	Description string
+	Metric      func(stats TitleStats) int
+	Threshold   int
+	Exclusive   bool
+}
+
+// DefaultTitleRules は称号の一覧。先にある称号ほどエイリアスとして優先して表示する
+func DefaultTitleRules() []TitleRule {
+	return []TitleRule{
+		{
+			Name:        "遅刻王",
+			Description: "グループで遅刻の合計時間が最も長い",
+			Metric:      func(stats TitleStats) int { return stats.TotalLateMinutes },
+			Threshold:   1,
+			Exclusive:   true,
+		},
+		{
+			Name:        "ドタキャン常習犯",
+			Description: "参加と言いながら 3 回以上来なかった",
+			Metric:      func(stats TitleStats) int { return stats.NoShowCount },
+			Threshold:   3,
+		},
+		{
+			Name:        "皆勤賞",
+			Description: "5 回連続で時間通りに到着した",
+			Metric:      func(stats TitleStats) int { return stats.OnTimeStreak },
+			Threshold:   5,
+		},
+	}
+}
+
+// collectTitleStats は開始時刻の昇順に並んだスコアからユーザーごとの成績を集計する
+func collectTitleStats(scores []entity.Score) map[entity.UserID]*TitleStats {
+	stats := make(map[entity.UserID]*TitleStats)
+	for _, score := range scores {
+		// 退会したユーザーには称号を与えない
+		if score.UserID.IsAnonymous() {
+			continue
+		}
+		stat, ok := stats[score.UserID]
+		if !ok {
+			stat = &TitleStats{UserID: score.UserID}
+			stats[score.UserID] = stat
+		}
+
+		stat.Events++
+		stat.TotalLateMinutes += score.LateMinutes
+		switch score.Outcome {
+		case entity.ScoreOutcomeOnTime:
+			stat.OnTimeStreak++
+		case entity.ScoreOutcomeLate:
+			stat.LateCount++
+			stat.OnTimeStreak = 0
+		case entity.ScoreOutcomeNoShow:
+			stat.NoShowCount++
+			stat.OnTimeStreak = 0
+		}
+	}
+	return stats
+}
+
+// qualifiedUsers は規則の条件を満たすユーザーを返す
+func (r TitleRule) qualifiedUsers(stats map[entity.UserID]*TitleStats) map[entity.UserID]bool {
+	qualified := make(map[entity.UserID]bool)
+
+	best := r.Threshold
+	for userID, stat := range stats {
+		value := r.Metric(*stat)
+		if value < r.Threshold {
+			continue
+		}
+		if r.Exclusive {
+			// 同じ値で並んだ場合は全員に与える
+			if value < best {
+				continue
+			}
+			if value > best {
+				best = value
+				qualified = make(map[entity.UserID]bool)
+			}
+		}
+		qualified[userID] = true
+	}
+
+	return qualified
+}
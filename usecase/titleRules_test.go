@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func onTimeScore(userID entity.UserID) entity.Score {
+	return entity.Score{UserID: userID, Outcome: entity.ScoreOutcomeOnTime}
+}
+
+func lateScore(userID entity.UserID, minutes int) entity.Score {
+	return entity.Score{UserID: userID, Outcome: entity.ScoreOutcomeLate, LateMinutes: minutes}
+}
+
+func noShowScore(userID entity.UserID) entity.Score {
+	return entity.Score{UserID: userID, Outcome: entity.ScoreOutcomeNoShow}
+}
+
+func repeatScore(score entity.Score, n int) []entity.Score {
+	scores := make([]entity.Score, n)
+	for i := range scores {
+		scores[i] = score
+	}
+	return scores
+}
+
+func concatScores(groups ...[]entity.Score) []entity.Score {
+	scores := []entity.Score{}
+	for _, group := range groups {
+		scores = append(scores, group...)
+	}
+	return scores
+}
+
+func TestCollectTitleStats(t *testing.T) {
+	scores := concatScores(
+		[]entity.Score{lateScore("user-id", 5), noShowScore("user-id")},
+		repeatScore(onTimeScore("user-id"), 2),
+		[]entity.Score{lateScore("user-id", 3), onTimeScore("user-id")},
+		[]entity.Score{lateScore(entity.NewAnonymousUserID("token"), 30)},
+	)
+
+	// テスト実行
+	stats := collectTitleStats(scores)
+
+	// 結果の検証: 退会したユーザーは集計しない
+	assert.Len(t, stats, 1)
+	stat := stats["user-id"]
+	assert.Equal(t, 6, stat.Events)
+	assert.Equal(t, 8, stat.TotalLateMinutes)
+	assert.Equal(t, 2, stat.LateCount)
+	assert.Equal(t, 1, stat.NoShowCount)
+	// 連続記録は最後の遅刻で途切れ、その後の 1 回だけを数える
+	assert.Equal(t, 1, stat.OnTimeStreak)
+}
+
+func TestDefaultTitleRules(t *testing.T) {
+	rules := map[entity.Alias]TitleRule{}
+	for _, rule := range DefaultTitleRules() {
+		rules[rule.Name] = rule
+	}
+
+	testCases := []struct {
+		name     string
+		rule     entity.Alias
+		scores   []entity.Score
+		expected []entity.UserID
+	}{
+		{
+			name:     "遅刻王: 遅刻の合計が最も長いメンバーだけが得る",
+			rule:     "遅刻王",
+			scores:   []entity.Score{lateScore("a", 10), lateScore("b", 4), lateScore("b", 5), lateScore("c", 1)},
+			expected: []entity.UserID{"a"},
+		},
+		{
+			name:     "遅刻王: 合計が並んだら全員が得る",
+			rule:     "遅刻王",
+			scores:   []entity.Score{lateScore("a", 10), lateScore("b", 4), lateScore("b", 6), onTimeScore("c")},
+			expected: []entity.UserID{"a", "b"},
+		},
+		{
+			name:     "遅刻王: 誰も遅刻していなければ誰も得ない",
+			rule:     "遅刻王",
+			scores:   []entity.Score{onTimeScore("a"), noShowScore("b")},
+			expected: []entity.UserID{},
+		},
+		{
+			name:     "ドタキャン常習犯: 2 回では得ない",
+			rule:     "ドタキャン常習犯",
+			scores:   repeatScore(noShowScore("a"), 2),
+			expected: []entity.UserID{},
+		},
+		{
+			name:     "ドタキャン常習犯: 3 回で得る。間に出席しても数は減らない",
+			rule:     "ドタキャン常習犯",
+			scores:   concatScores(repeatScore(noShowScore("a"), 2), []entity.Score{onTimeScore("a"), noShowScore("a"), noShowScore("b")}),
+			expected: []entity.UserID{"a"},
+		},
+		{
+			name:     "皆勤賞: 4 回連続では得ない",
+			rule:     "皆勤賞",
+			scores:   repeatScore(onTimeScore("a"), 4),
+			expected: []entity.UserID{},
+		},
+		{
+			name:     "皆勤賞: 5 回連続で得る",
+			rule:     "皆勤賞",
+			scores:   concatScores([]entity.Score{lateScore("a", 1)}, repeatScore(onTimeScore("a"), 5)),
+			expected: []entity.UserID{"a"},
+		},
+		{
+			name:     "皆勤賞: 直近の遅刻で連続記録が途切れる",
+			rule:     "皆勤賞",
+			scores:   concatScores(repeatScore(onTimeScore("a"), 5), []entity.Score{lateScore("a", 1)}),
+			expected: []entity.UserID{},
+		},
+		{
+			name:     "皆勤賞: 直近の欠席で連続記録が途切れる",
+			rule:     "皆勤賞",
+			scores:   concatScores(repeatScore(onTimeScore("a"), 6), []entity.Score{noShowScore("a")}, repeatScore(onTimeScore("a"), 4)),
+			expected: []entity.UserID{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, ok := rules[tc.rule]
+			assert.True(t, ok)
+
+			// テスト実行
+			qualified := rule.qualifiedUsers(collectTitleStats(tc.scores))
+
+			// 結果の検証
+			users := []entity.UserID{}
+			for userID := range qualified {
+				users = append(users, userID)
+			}
+			assert.ElementsMatch(t, tc.expected, users)
+		})
+	}
+}
+
+func TestSelectAlias(t *testing.T) {
+	rules := DefaultTitleRules()
+	revokedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	titles := []entity.Title{{Name: "皆勤賞"}, {Name: "遅刻王"}, {Name: "ドタキャン常習犯", RevokedAt: &revokedAt}}
+
+	// 規則の並びで先にある称号を優先する
+	assert.Equal(t, entity.Alias("遅刻王"), selectAlias(rules, titles, ""))
+	// 希望した称号を保持していれば希望を優先する
+	assert.Equal(t, entity.Alias("皆勤賞"), selectAlias(rules, titles, "皆勤賞"))
+	// 取り消された称号を希望しても使わない
+	assert.Equal(t, entity.Alias("遅刻王"), selectAlias(rules, titles, "ドタキャン常習犯"))
+	assert.Equal(t, entity.Alias(""), selectAlias(rules, nil, ""))
+}
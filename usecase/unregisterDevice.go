@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+)
+
+var ErrDeviceNotFound = errors.New("device not found")
+
+type UnregisterDeviceUseCase interface {
+	Execute() error
+}
+
+type UnregisterDeviceUseCaseImpl struct {
+	deviceRepo repository.DeviceRepository
+	userID     entity.UserID
+	deviceID   entity.DeviceID
+}
+
+// NewUnregisterDeviceUseCase はログアウトした端末にプッシュ通知を送らないよう登録を解除する
+func NewUnregisterDeviceUseCase(deviceRepo repository.DeviceRepository, userID entity.UserID, deviceID entity.DeviceID) *UnregisterDeviceUseCaseImpl {
+	return &UnregisterDeviceUseCaseImpl{
+		deviceRepo: deviceRepo,
+		userID:     userID,
+		deviceID:   deviceID,
+	}
+}
+
+func (uc *UnregisterDeviceUseCaseImpl) Execute() error {
+	deleted, err := uc.deviceRepo.DeleteDevice(uc.userID, uc.deviceID)
+	if err != nil {
+		return fmt.Errorf("端末の登録解除に失敗しました: %w", err)
+	}
+	if !deleted {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
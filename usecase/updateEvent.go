@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidEditScope   = errors.New("invalid edit scope")
+	ErrNotRecurringEvent  = errors.New("event is not part of a recurring series")
+	ErrEventSeriesMissing = errors.New("event series not found")
+)
+
+// EditScope は繰り返しイベントの回を編集するときに、どの回まで変更するかを表す
+type EditScope string
+
+const (
+	EditScopeThis      EditScope = "this"
+	EditScopeFollowing EditScope = "following"
+)
+
+// EventPatch はイベントの部分更新。nil の項目は変更しない
+// 開始日時だけを変えた場合は、終了日時と締切日時も同じだけずらす
+type EventPatch struct {
+	EventTitle           *entity.EventTitle
+	EventDescription     *entity.EventDescription
+	EventLocationName    *entity.LocationName
+	Cost                 *entity.Cost
+	EventMessage         *entity.EventMessage
+	Latitude             *entity.Latitude
+	Longitude            *entity.Longitude
+	EventStartDateTime   *time.Time
+	EventEndDateTime     *time.Time
+	EventClosingDateTime *time.Time
+}
+
+// eventShift は編集対象の回で変わった日時の差分。以降の回にも同じ差分を適用する
+type eventShift struct {
+	start   time.Duration
+	end     time.Duration
+	closing time.Duration
+}
+
+func (p EventPatch) shiftFor(event *entity.Event) eventShift {
+	var shift eventShift
+	if p.EventStartDateTime != nil {
+		shift.start = p.EventStartDateTime.Sub(time.Time(event.EventStartDateTime))
+	}
+	shift.end, shift.closing = shift.start, shift.start
+	if p.EventEndDateTime != nil {
+		shift.end = p.EventEndDateTime.Sub(time.Time(event.EventEndDateTime))
+	}
+	if p.EventClosingDateTime != nil {
+		shift.closing = p.EventClosingDateTime.Sub(time.Time(event.EventClosingDateTime))
+	}
+	return shift
+}
+
+func (p EventPatch) applyContent(template *entity.EventTemplate) {
+	if p.EventTitle != nil {
+		template.EventTitle = *p.EventTitle
+	}
+	if p.EventDescription != nil {
+		template.EventDescription = *p.EventDescription
+	}
+	if p.EventLocationName != nil {
+		template.EventLocationName = *p.EventLocationName
+	}
+	if p.Cost != nil {
+		template.Cost = *p.Cost
+	}
+	if p.EventMessage != nil {
+		template.EventMessage = *p.EventMessage
+	}
+	if p.Latitude != nil {
+		template.Latitude = *p.Latitude
+	}
+	if p.Longitude != nil {
+		template.Longitude = *p.Longitude
+	}
+}
+
+// apply はイベントの内容を変更し、日時を shift だけずらす。投票や到着の記録はそのまま残す
+func (p EventPatch) apply(event *entity.Event, shift eventShift) {
+	template := entity.EventTemplate{
+		EventTitle:        event.EventTitle,
+		EventDescription:  event.EventDescription,
+		EventLocationName: event.EventLocationName,
+		Cost:              event.Cost,
+		EventMessage:      event.EventMessage,
+		EventAuthorID:     event.EventAuthorID,
+		Latitude:          event.Latitude,
+		Longitude:         event.Longitude,
+	}
+	p.applyContent(&template)
+
+	event.EventTitle = template.EventTitle
+	event.EventDescription = template.EventDescription
+	event.EventLocationName = template.EventLocationName
+	event.Cost = template.Cost
+	event.EventMessage = template.EventMessage
+	event.Latitude = template.Latitude
+	event.Longitude = template.Longitude
+	event.EventStartDateTime = entity.StartDateTIme(time.Time(event.EventStartDateTime).Add(shift.start))
+	event.EventEndDateTime = entity.EndDateTime(time.Time(event.EventEndDateTime).Add(shift.end))
+	event.EventClosingDateTime = entity.EventClosingDateTime(time.Time(event.EventClosingDateTime).Add(shift.closing))
+}
+
+// fieldsFor は apply で変更したイベントのうち、変更した項目だけを保存する内容を返す
+func (p EventPatch) fieldsFor(event *entity.Event, shift eventShift) entity.EventUpdate {
+	update := entity.EventUpdate{
+		EventTitle:        p.EventTitle,
+		EventDescription:  p.EventDescription,
+		EventLocationName: p.EventLocationName,
+		Cost:              p.Cost,
+		EventMessage:      p.EventMessage,
+		Latitude:          p.Latitude,
+		Longitude:         p.Longitude,
+	}
+	if shift.start != 0 {
+		update.EventStartDateTime = &event.EventStartDateTime
+	}
+	if shift.end != 0 {
+		update.EventEndDateTime = &event.EventEndDateTime
+	}
+	if shift.closing != 0 {
+		update.EventClosingDateTime = &event.EventClosingDateTime
+	}
+	return update
+}
+
+type UpdateEventUseCase interface {
+	Execute() ([]entity.Event, error)
+}
+
+type UpdateEventUseCaseImpl struct {
+	eventRepo  repository.EventRepository
+	seriesRepo repository.EventSeriesRepository
+	groupRepo  repository.GroupRepository
+	seasonRepo repository.SeasonRepository
+	userID     entity.UserID
+	eventID    entity.EventID
+	patch      EventPatch
+	scope      EditScope
+}
+
+// NewUpdateEventUseCase はイベントを編集する。編集できるのはイベントの作成者のみ
+// 繰り返しイベントの回で scope が following の場合は、以降の回もまとめて変更し、
+// 繰り返しをこの回で分割して以降に作成される回にも変更を引き継ぐ
+func NewUpdateEventUseCase(eventRepo repository.EventRepository, seriesRepo repository.EventSeriesRepository, groupRepo repository.GroupRepository, seasonRepo repository.SeasonRepository, userID entity.UserID, eventID entity.EventID, patch EventPatch, scope EditScope) *UpdateEventUseCaseImpl {
+	return &UpdateEventUseCaseImpl{
+		eventRepo:  eventRepo,
+		seriesRepo: seriesRepo,
+		groupRepo:  groupRepo,
+		seasonRepo: seasonRepo,
+		userID:     userID,
+		eventID:    eventID,
+		patch:      patch,
+		scope:      scope,
+	}
+}
+
+func (uc *UpdateEventUseCaseImpl) Execute() ([]entity.Event, error) {
+	if uc.scope == "" {
+		uc.scope = EditScopeThis
+	}
+	if uc.scope != EditScopeThis && uc.scope != EditScopeFollowing {
+		return nil, ErrInvalidEditScope
+	}
+
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+	if event.EventAuthorID != uc.userID {
+		return nil, ErrNotEventAuthor
+	}
+	if event.FinalizedAt != nil {
+		return nil, ErrEventAlreadyFinalized
+	}
+
+	shift := uc.patch.shiftFor(event)
+
+	edited := *event
+	uc.patch.apply(&edited, shift)
+	if !time.Time(edited.EventStartDateTime).Before(time.Time(edited.EventEndDateTime)) {
+		return nil, ErrInvalidEventTime
+	}
+
+	group, err := uc.groupRepo.FindGroupByEventID(event.EventID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	if uc.scope == EditScopeThis {
+		updated, err := uc.updateOccurrence(group.GroupID, &edited, uc.patch.fieldsFor(&edited, shift), shift)
+		if err != nil {
+			return nil, err
+		}
+		return []entity.Event{*updated}, nil
+	}
+
+	if event.SeriesID == "" || event.RecurrenceID == nil {
+		return nil, ErrNotRecurringEvent
+	}
+	return uc.updateFollowing(group.GroupID, event, shift)
+}
+
+// updateFollowing は繰り返しを編集対象の回の直前で終わらせ、この回以降を新しい繰り返しに移す
+func (uc *UpdateEventUseCaseImpl) updateFollowing(groupID entity.GroupID, event *entity.Event, shift eventShift) ([]entity.Event, error) {
+	series, err := uc.seriesRepo.FindSeriesBySeriesID(event.SeriesID)
+	if err != nil || series == nil {
+		return nil, ErrEventSeriesMissing
+	}
+
+	recurrenceID := *event.RecurrenceID
+	location := series.Location()
+
+	following := *series
+	following.StartDateTime = recurrenceID.Add(shift.start)
+	following.Duration += shift.end - shift.start
+	following.ClosingLeadTime += shift.start - shift.closing
+	following.MaterializedUntil = series.MaterializedUntil.Add(shift.start)
+	uc.patch.applyContent(&following.Template)
+
+	following.Rule.ByDay = shiftRecurrenceDays(series.Rule.ByDay, calendarDaysBetween(recurrenceID, following.StartDateTime, location))
+	if series.Rule.Until != nil {
+		until := series.Rule.Until.Add(shift.start)
+		following.Rule.Until = &until
+	}
+	if series.Rule.Count > 0 {
+		before, _ := expandRecurrence(series.Rule, series.StartDateTime, location, series.StartDateTime, recurrenceID)
+		following.Rule.Count = series.Rule.Count - len(before)
+	}
+
+	created, err := uc.seriesRepo.CreateSeries(following)
+	if err != nil {
+		return nil, fmt.Errorf("繰り返しイベントの分割に失敗しました: %w", err)
+	}
+
+	occurrences, err := uc.eventRepo.FindEventsBySeriesID(series.SeriesID, recurrenceID)
+	if err != nil {
+		return nil, fmt.Errorf("繰り返しイベントの回の取得に失敗しました: %w", err)
+	}
+
+	updated := make([]entity.Event, 0, len(occurrences))
+	for i := range occurrences {
+		occurrence := occurrences[i]
+		// 確定済みの回は記録を変えないよう元の繰り返しに残す
+		if occurrence.FinalizedAt != nil {
+			continue
+		}
+
+		uc.patch.apply(&occurrence, shift)
+		movedID := occurrence.RecurrenceID.Add(shift.start)
+		occurrence.SeriesID = created.SeriesID
+		occurrence.RecurrenceID = &movedID
+
+		fields := uc.patch.fieldsFor(&occurrence, shift)
+		fields.SeriesID = &occurrence.SeriesID
+		fields.RecurrenceID = occurrence.RecurrenceID
+
+		result, err := uc.updateOccurrence(groupID, &occurrence, fields, shift)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, *result)
+	}
+
+	// 元の繰り返しはこの回の直前で終わらせ、以降の回を作成しないようにする
+	until := recurrenceID.Add(-time.Second)
+	series.Rule.Count = 0
+	series.Rule.Until = &until
+	series.Completed = true
+	if _, err := uc.seriesRepo.UpdateSeries(*series); err != nil {
+		return nil, fmt.Errorf("繰り返しイベントの更新に失敗しました: %w", err)
+	}
+
+	return updated, nil
+}
+
+// updateOccurrence はイベントの変更した項目を保存する。開始日時が変わった場合はシーズンを割り当て直す
+// 投票や到着など、同時に書き換えられる記録は上書きしない
+func (uc *UpdateEventUseCaseImpl) updateOccurrence(groupID entity.GroupID, event *entity.Event, fields entity.EventUpdate, shift eventShift) (*entity.Event, error) {
+	if shift.start != 0 {
+		seasonID, err := findEventSeason(uc.seasonRepo, groupID, time.Time(event.EventStartDateTime))
+		if err != nil {
+			return nil, err
+		}
+		fields.SeasonID = &seasonID
+	}
+
+	updated, err := uc.eventRepo.UpdateEventFields(event.EventID, fields)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの更新に失敗しました: %w", err)
+	}
+	return updated, nil
+}
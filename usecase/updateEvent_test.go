@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"chikokulympic-api/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateEventUseCaseFollowing(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	// 2026-01-09 は金曜日
+	dtstart := time.Date(2026, 1, 9, 19, 0, 0, 0, tokyo)
+	const count = 6
+
+	newFixture := func() (*eventRepoStub, *seriesRepoStub, *groupRepoStub, []entity.EventID) {
+		series := &entity.EventSeries{
+			SeriesID:          "series-id",
+			GroupID:           "group-id",
+			Rule:              entity.RecurrenceRule{Frequency: entity.RecurrenceWeekly, Interval: 1, Count: count, ByDay: []entity.RecurrenceDay{{Weekday: time.Friday}}},
+			TimeZone:          "Asia/Tokyo",
+			StartDateTime:     dtstart,
+			Duration:          2 * time.Hour,
+			ClosingLeadTime:   time.Hour,
+			Template:          entity.EventTemplate{EventTitle: "定例会", EventAuthorID: "author-id"},
+			MaterializedUntil: dtstart.AddDate(0, 0, 7*count),
+			Completed:         true,
+		}
+		starts, _ := expandRecurrence(series.Rule, series.StartDateTime, tokyo, series.StartDateTime, series.MaterializedUntil)
+		assert.Len(t, starts, count)
+
+		eventRepo := newEventRepoStub()
+		group := &entity.Group{GroupID: "group-id", GroupMembers: entity.GroupMembers{"author-id"}}
+		eventIDs := []entity.EventID{}
+		for i, start := range starts {
+			event := series.Occurrence(start)
+			event.EventID = entity.EventID("event-" + strconv.Itoa(i))
+			eventRepo.events[event.EventID] = &event
+			group.GroupEvents = append(group.GroupEvents, event.EventID)
+			eventIDs = append(eventIDs, event.EventID)
+		}
+		return eventRepo, newSeriesRepoStub(series), &groupRepoStub{groups: []*entity.Group{group}}, eventIDs
+	}
+
+	t.Run("正常系: 編集した回から新しい繰り返しに分け、以降の回の日時と内容を変える", func(t *testing.T) {
+		eventRepo, seriesRepo, groupRepo, eventIDs := newFixture()
+		// 3 回目を 1 日遅らせ、以降を土曜日にする
+		title := entity.EventTitle("土曜定例会")
+		target := eventRepo.events[eventIDs[2]]
+		newStart := time.Time(target.EventStartDateTime).AddDate(0, 0, 1)
+		patch := EventPatch{EventTitle: &title, EventStartDateTime: &newStart}
+
+		// テスト実行
+		updated, err := NewUpdateEventUseCase(eventRepo, seriesRepo, groupRepo, &seasonRepoStub{}, "author-id", target.EventID, patch, EditScopeFollowing).Execute()
+
+		// 結果の検証
+		assert.NoError(t, err)
+		assert.Len(t, updated, count-2)
+
+		original := seriesRepo.series["series-id"]
+		assert.True(t, original.Completed)
+		assert.Zero(t, original.Rule.Count)
+		assert.True(t, original.Rule.Until.Before(dtstart.AddDate(0, 0, 14)))
+
+		var following *entity.EventSeries
+		for id, series := range seriesRepo.series {
+			if id != "series-id" {
+				following = series
+			}
+		}
+		assert.NotNil(t, following)
+		assert.True(t, newStart.Equal(following.StartDateTime))
+		assert.Equal(t, []entity.RecurrenceDay{{Weekday: time.Saturday}}, following.Rule.ByDay)
+		assert.Equal(t, title, following.Template.EventTitle)
+		assert.Equal(t, 2*time.Hour, following.Duration)
+		assert.Equal(t, time.Hour, following.ClosingLeadTime)
+
+		for i, eventID := range eventIDs {
+			event := eventRepo.events[eventID]
+			original := dtstart.AddDate(0, 0, 7*i)
+			if i < 2 {
+				assert.Equal(t, entity.EventSeriesID("series-id"), event.SeriesID)
+				assert.Equal(t, entity.EventTitle("定例会"), event.EventTitle)
+				assert.True(t, original.Equal(time.Time(event.EventStartDateTime)))
+				continue
+			}
+			moved := original.AddDate(0, 0, 1)
+			assert.Equal(t, following.SeriesID, event.SeriesID)
+			assert.Equal(t, title, event.EventTitle)
+			assert.True(t, moved.Equal(time.Time(event.EventStartDateTime)))
+			assert.True(t, moved.Add(2*time.Hour).Equal(time.Time(event.EventEndDateTime)))
+			assert.True(t, moved.Add(-time.Hour).Equal(time.Time(event.EventClosingDateTime)))
+			assert.True(t, moved.Equal(*event.RecurrenceID))
+		}
+	})
+
+	t.Run("正常系: 分けた後の 2 つの繰り返しの回数は元の COUNT と一致し、以降の回は新しい繰り返しの回と重なる", func(t *testing.T) {
+		for split := 1; split < count; split++ {
+			eventRepo, seriesRepo, groupRepo, eventIDs := newFixture()
+			target := eventRepo.events[eventIDs[split]]
+			newStart := time.Time(target.EventStartDateTime).Add(30 * time.Minute)
+
+			_, err := NewUpdateEventUseCase(eventRepo, seriesRepo, groupRepo, &seasonRepoStub{}, "author-id", target.EventID, EventPatch{EventStartDateTime: &newStart}, EditScopeFollowing).Execute()
+			assert.NoError(t, err)
+
+			total := 0
+			for _, series := range seriesRepo.series {
+				starts, exhausted := expandRecurrence(series.Rule, series.StartDateTime, tokyo, series.StartDateTime, dtstart.AddDate(1, 0, 0))
+				assert.True(t, exhausted)
+				total += len(starts)
+
+				// 各回の開始日時は、その繰り返しを展開した結果と一致する
+				expected := map[time.Time]bool{}
+				for _, start := range starts {
+					expected[start.UTC()] = true
+				}
+				for _, event := range eventRepo.events {
+					if event.SeriesID == series.SeriesID {
+						assert.True(t, expected[time.Time(event.EventStartDateTime).UTC()], "split %d: %s", split, time.Time(event.EventStartDateTime))
+					}
+				}
+			}
+			assert.Equal(t, count, total, "split %d", split)
+		}
+	})
+
+	t.Run("正常系: 確定済みの回は元の繰り返しに残し、変更しない", func(t *testing.T) {
+		eventRepo, seriesRepo, groupRepo, eventIDs := newFixture()
+		finalizedAt := dtstart
+		eventRepo.events[eventIDs[4]].FinalizedAt = &finalizedAt
+		title := entity.EventTitle("変更後")
+
+		updated, err := NewUpdateEventUseCase(eventRepo, seriesRepo, groupRepo, &seasonRepoStub{}, "author-id", eventIDs[2], EventPatch{EventTitle: &title}, EditScopeFollowing).Execute()
+
+		assert.NoError(t, err)
+		assert.Len(t, updated, count-3)
+		finalized := eventRepo.events[eventIDs[4]]
+		assert.Equal(t, entity.EventSeriesID("series-id"), finalized.SeriesID)
+		assert.Equal(t, entity.EventTitle("定例会"), finalized.EventTitle)
+	})
+
+	t.Run("異常系: 繰り返しでないイベントは以降の回を変更できない", func(t *testing.T) {
+		eventRepo, seriesRepo, groupRepo, eventIDs := newFixture()
+		event := eventRepo.events[eventIDs[0]]
+		event.SeriesID = ""
+		event.RecurrenceID = nil
+		title := entity.EventTitle("変更後")
+
+		_, err := NewUpdateEventUseCase(eventRepo, seriesRepo, groupRepo, &seasonRepoStub{}, "author-id", event.EventID, EventPatch{EventTitle: &title}, EditScopeFollowing).Execute()
+
+		assert.ErrorIs(t, err, ErrNotRecurringEvent)
+	})
+
+	t.Run("異常系: 作成者以外は編集できない", func(t *testing.T) {
+		eventRepo, seriesRepo, groupRepo, eventIDs := newFixture()
+		title := entity.EventTitle("変更後")
+
+		_, err := NewUpdateEventUseCase(eventRepo, seriesRepo, groupRepo, &seasonRepoStub{}, "other-user-id", eventIDs[0], EventPatch{EventTitle: &title}, EditScopeFollowing).Execute()
+
+		assert.ErrorIs(t, err, ErrNotEventAuthor)
+		assert.Len(t, seriesRepo.series, 1)
+	})
+}
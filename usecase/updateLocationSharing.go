@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+)
+
+type UpdateLocationSharingUseCase interface {
+	Execute() error
+}
+
+type UpdateLocationSharingUseCaseImpl struct {
+	userRepo  repository.UserRepository
+	groupRepo repository.GroupRepository
+	userID    entity.UserID
+	groupID   entity.GroupID
+	enabled   bool
+}
+
+func NewUpdateLocationSharingUseCase(userRepo repository.UserRepository, groupRepo repository.GroupRepository, userID entity.UserID, groupID entity.GroupID, enabled bool) *UpdateLocationSharingUseCaseImpl {
+	return &UpdateLocationSharingUseCaseImpl{
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+		userID:    userID,
+		groupID:   groupID,
+		enabled:   enabled,
+	}
+}
+
+func (uc *UpdateLocationSharingUseCaseImpl) Execute() error {
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotGroupMember, err)
+	}
+
+	if !isGroupMember(group, uc.userID) {
+		return ErrNotGroupMember
+	}
+
+	return uc.userRepo.SetLocationSharing(uc.userID, group.GroupID, uc.enabled)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"fmt"
+	"time"
+)
+
+type UpdateNotificationSettingsUseCase interface {
+	Execute() (*entity.NotificationSettings, error)
+}
+
+type UpdateNotificationSettingsUseCaseImpl struct {
+	settingsRepo repository.NotificationSettingsRepository
+	groupRepo    repository.GroupRepository
+	settings     entity.NotificationSettings
+}
+
+// NewUpdateNotificationSettingsUseCase は通知の設定を置き換える
+// グループごとの設定は所属しているグループにしか設定できない
+func NewUpdateNotificationSettingsUseCase(settingsRepo repository.NotificationSettingsRepository, groupRepo repository.GroupRepository, settings entity.NotificationSettings) *UpdateNotificationSettingsUseCaseImpl {
+	return &UpdateNotificationSettingsUseCaseImpl{
+		settingsRepo: settingsRepo,
+		groupRepo:    groupRepo,
+		settings:     settings,
+	}
+}
+
+func (uc *UpdateNotificationSettingsUseCaseImpl) Execute() (*entity.NotificationSettings, error) {
+	settings := uc.settings
+
+	if len(settings.Groups) > 0 {
+		groups, err := uc.groupRepo.FindGroupsByUserID(settings.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("グループの取得に失敗しました: %w", err)
+		}
+		joined := make(map[entity.GroupID]bool, len(groups))
+		for _, group := range groups {
+			joined[group.GroupID] = true
+		}
+		for _, group := range settings.Groups {
+			if !joined[group.GroupID] {
+				return nil, fmt.Errorf("%w: %s", ErrNotGroupMember, group.GroupID)
+			}
+		}
+	}
+
+	settings.FillDefaults()
+	settings.UpdatedAt = time.Now()
+	if err := uc.settingsRepo.SaveSettings(settings); err != nil {
+		return nil, fmt.Errorf("通知の設定の保存に失敗しました: %w", err)
+	}
+
+	return &settings, nil
+}
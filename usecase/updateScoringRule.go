@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotGroupManager    = errors.New("not the manager of this group")
+	ErrInvalidScoringRule = errors.New("invalid scoring rule")
+)
+
+type UpdateScoringRuleUseCase interface {
+	Execute() (*entity.ScoringRule, error)
+}
+
+type UpdateScoringRuleUseCaseImpl struct {
+	groupRepo repository.GroupRepository
+	userID    entity.UserID
+	groupID   entity.GroupID
+	rule      entity.ScoringRule
+}
+
+// NewUpdateScoringRuleUseCase はグループのポイント計算の設定を変更する。変更できるのはグループの管理者のみ
+// 確定済みのイベントのポイントは再計算しない
+func NewUpdateScoringRuleUseCase(groupRepo repository.GroupRepository, userID entity.UserID, groupID entity.GroupID, rule entity.ScoringRule) *UpdateScoringRuleUseCaseImpl {
+	return &UpdateScoringRuleUseCaseImpl{
+		groupRepo: groupRepo,
+		userID:    userID,
+		groupID:   groupID,
+		rule:      rule,
+	}
+}
+
+func (uc *UpdateScoringRuleUseCaseImpl) Execute() (*entity.ScoringRule, error) {
+	// 減点は正の値で指定し、計算時に符号を反転させる
+	if uc.rule.EarlyBonus < 0 || uc.rule.LatePenaltyPerMinute < 0 || uc.rule.NoShowPenalty < 0 {
+		return nil, ErrInvalidScoringRule
+	}
+
+	group, err := uc.groupRepo.FindGroupByGroupID(uc.groupID)
+	if err != nil || group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.GroupManagerID != uc.userID {
+		return nil, ErrNotGroupManager
+	}
+
+	if err := uc.groupRepo.UpdateScoringRule(group.GroupID, uc.rule); err != nil {
+		return nil, fmt.Errorf("ポイント設定の更新に失敗しました: %w", err)
+	}
+
+	return &uc.rule, nil
+}
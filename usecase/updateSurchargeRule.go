@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidSurchargeRule = errors.New("invalid surcharge rule")
+
+type UpdateSurchargeRuleUseCase interface {
+	Execute() (*entity.SurchargeRule, error)
+}
+
+type UpdateSurchargeRuleUseCaseImpl struct {
+	eventRepo repository.EventRepository
+	userID    entity.UserID
+	eventID   entity.EventID
+	rule      entity.SurchargeRule
+}
+
+// NewUpdateSurchargeRuleUseCase はイベントの遅刻の上乗せ額を設定する。設定できるのはイベントの作成者のみ
+// 1 分あたりの額を 0 にすると上乗せしなくなる
+func NewUpdateSurchargeRuleUseCase(eventRepo repository.EventRepository, userID entity.UserID, eventID entity.EventID, rule entity.SurchargeRule) *UpdateSurchargeRuleUseCaseImpl {
+	return &UpdateSurchargeRuleUseCaseImpl{
+		eventRepo: eventRepo,
+		userID:    userID,
+		eventID:   eventID,
+		rule:      rule,
+	}
+}
+
+func (uc *UpdateSurchargeRuleUseCaseImpl) Execute() (*entity.SurchargeRule, error) {
+	if uc.rule.PerMinute < 0 || uc.rule.Max < 0 {
+		return nil, ErrInvalidSurchargeRule
+	}
+
+	event, err := uc.eventRepo.FindEventByEventID(uc.eventID)
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+	if event.EventAuthorID != uc.userID {
+		return nil, ErrNotEventAuthor
+	}
+
+	if _, err := uc.eventRepo.UpdateEventFields(event.EventID, entity.EventUpdate{SurchargeRule: &uc.rule}); err != nil {
+		return nil, fmt.Errorf("上乗せ額の設定に失敗しました: %w", err)
+	}
+
+	return &uc.rule, nil
+}
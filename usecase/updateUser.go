@@ -3,24 +3,57 @@ package usecase
 import (
 	"chikokulympic-api/domain/entity"
 	"chikokulympic-api/domain/repository"
+	"errors"
+	"fmt"
 )
 
+// ErrAliasNotHeld はエイリアスに希望した称号を保持していないことを表す
+var ErrAliasNotHeld = errors.New("alias title not held")
+
 type UpdateUserUseCase interface {
 	Execute() (*entity.User, error)
 }
 
 type UpdateUserUseCaseImpl struct {
-	userRepo repository.UserRepository
-	user     *entity.User
+	userRepo  repository.UserRepository
+	titleRepo repository.TitleRepository
+	userID    entity.UserID
+	update    entity.UserProfileUpdate
 }
 
-func NewUpdateUserUseCase(userRepo repository.UserRepository, user *entity.User) *UpdateUserUseCaseImpl {
+// NewUpdateUserUseCase は update で指定されたプロフィールの項目だけを更新する
+func NewUpdateUserUseCase(userRepo repository.UserRepository, titleRepo repository.TitleRepository, userID entity.UserID, update entity.UserProfileUpdate) *UpdateUserUseCaseImpl {
 	return &UpdateUserUseCaseImpl{
-		userRepo: userRepo,
-		user:     user,
+		userRepo:  userRepo,
+		titleRepo: titleRepo,
+		userID:    userID,
+		update:    update,
 	}
 }
 
 func (uc *UpdateUserUseCaseImpl) Execute() (*entity.User, error) {
-	return uc.userRepo.UpdateUser(*uc.user)
+	update := uc.update
+
+	// エイリアスの希望が変わったら、表示するエイリアスもその場で選び直す
+	if update.AliasPreference != nil {
+		titles, err := uc.titleRepo.FindTitlesByUserID(uc.userID)
+		if err != nil {
+			return nil, fmt.Errorf("称号の取得に失敗しました: %w", err)
+		}
+		preference := *update.AliasPreference
+		if preference != "" && !holdsTitle(titles, preference) {
+			return nil, ErrAliasNotHeld
+		}
+		alias := selectAlias(DefaultTitleRules(), titles, preference)
+		update.Alias = &alias
+	}
+
+	user, err := uc.userRepo.UpdateUserProfile(uc.userID, update)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの更新に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
 }
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"bytes"
+	"chikokulympic-api/domain/entity"
+	"chikokulympic-api/domain/repository"
+	"chikokulympic-api/domain/service"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type UploadUserIconUseCase interface {
+	Execute() (*entity.User, error)
+}
+
+type UploadUserIconUseCaseImpl struct {
+	userRepo  repository.UserRepository
+	blobStore service.BlobStore
+	userID    entity.UserID
+	data      []byte
+}
+
+// NewUploadUserIconUseCase はアップロードされた画像からアイコンを作って保存し、ユーザーのアイコンをこのサーバーの URL にする
+func NewUploadUserIconUseCase(userRepo repository.UserRepository, blobStore service.BlobStore, userID entity.UserID, data []byte) *UploadUserIconUseCaseImpl {
+	return &UploadUserIconUseCaseImpl{
+		userRepo:  userRepo,
+		blobStore: blobStore,
+		userID:    userID,
+		data:      data,
+	}
+}
+
+func (uc *UploadUserIconUseCaseImpl) Execute() (*entity.User, error) {
+	icons, err := renderIcons(uc.data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, size := range IconSizes {
+		if _, err := uc.blobStore.Put(userIconKey(uc.userID, size), bytes.NewReader(icons[size])); err != nil {
+			return nil, fmt.Errorf("アイコンの保存に失敗しました: %w", err)
+		}
+	}
+
+	// 同じ URL のままだとクライアントのキャッシュに古いアイコンが残るため、更新日時を付ける
+	icon := entity.UserIcon(fmt.Sprintf("/users/%s/icon?v=%s", url.PathEscape(string(uc.userID)), strconv.FormatInt(time.Now().Unix(), 10)))
+	user, err := uc.userRepo.UpdateUserProfile(uc.userID, entity.UserProfileUpdate{UserIcon: &icon})
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの更新に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func userIconKey(userID entity.UserID, size int) string {
+	return fmt.Sprintf("icons/%s/%d.jpg", userID, size)
+}
+
+// deleteUserIcons はアップロードされたアイコンをすべての大きさについて削除する
+func deleteUserIcons(blobStore service.BlobStore, userID entity.UserID) error {
+	for _, size := range IconSizes {
+		if err := blobStore.Delete(userIconKey(userID, size)); err != nil {
+			return fmt.Errorf("アイコンの削除に失敗しました: %w", err)
+		}
+	}
+	return nil
+}